@@ -0,0 +1,19 @@
+# Restart the proxy whenever its configuration file changes.
+action "qnap_container_restart" "proxy" {
+  config {
+    container_id = qnap_container.proxy.id
+  }
+}
+
+resource "qnap_container_file" "proxy_config" {
+  container_id = qnap_container.proxy.id
+  path         = "/etc/nginx/nginx.conf"
+  source       = "${path.module}/nginx.conf"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_update]
+      actions = [action.qnap_container_restart.proxy]
+    }
+  }
+}
@@ -0,0 +1,5 @@
+action "qnap_container_start" "worker" {
+  config {
+    container_id = qnap_container.worker.id
+  }
+}
@@ -0,0 +1,6 @@
+action "qnap_container_stop" "worker" {
+  config {
+    container_id      = qnap_container.worker.id
+    stop_grace_period = "30s"
+  }
+}
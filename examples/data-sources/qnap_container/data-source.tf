@@ -0,0 +1,7 @@
+data "qnap_container" "proxy" {
+  name = "reverse-proxy"
+}
+
+output "proxy_address" {
+  value = data.qnap_container.proxy.networks[0].ipaddress
+}
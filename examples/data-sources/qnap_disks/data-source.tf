@@ -0,0 +1,15 @@
+data "qnap_disks" "nas" {}
+
+resource "qnap_container" "database" {
+  name              = "database"
+  image             = "postgres:16"
+  type              = "docker"
+  removeanonvolumes = true
+
+  lifecycle {
+    precondition {
+      condition     = data.qnap_disks.nas.healthy
+      error_message = "Disks ${join(", ", [for disk in data.qnap_disks.nas.disks : disk.slot if disk.smart_status != "good"])} of the NAS are failing."
+    }
+  }
+}
@@ -0,0 +1,15 @@
+data "qnap_firmware" "nas" {}
+
+resource "qnap_container" "web" {
+  name              = "web"
+  image             = "nginx:latest"
+  type              = "docker"
+  removeanonvolumes = true
+
+  lifecycle {
+    precondition {
+      condition     = !data.qnap_firmware.nas.update_available
+      error_message = "Firmware ${data.qnap_firmware.nas.latest_version} is available, update the NAS from ${data.qnap_firmware.nas.version} first."
+    }
+  }
+}
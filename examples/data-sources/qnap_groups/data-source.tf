@@ -0,0 +1,16 @@
+data "qnap_groups" "all" {}
+
+locals {
+  media_gid = one([for group in data.qnap_groups.all.groups : group.gid if group.name == "media"])
+}
+
+resource "qnap_container" "sonarr" {
+  name              = "sonarr"
+  image             = "linuxserver/sonarr:latest"
+  type              = "docker"
+  removeanonvolumes = true
+  env = {
+    PUID = "1000"
+    PGID = tostring(local.media_gid)
+  }
+}
@@ -0,0 +1,12 @@
+data "qnap_network" "backend" {
+  name = "backend"
+}
+
+resource "qnap_container" "web" {
+  name              = "web"
+  image             = "nginx:latest"
+  type              = "docker"
+  network           = data.qnap_network.backend.name
+  networktype       = "bridge"
+  removeanonvolumes = true
+}
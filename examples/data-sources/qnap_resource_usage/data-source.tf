@@ -0,0 +1,16 @@
+data "qnap_resource_usage" "nas" {}
+
+resource "qnap_container" "worker" {
+  name              = "worker"
+  image             = "busybox:latest"
+  type              = "docker"
+  cmd               = ["sleep", "infinity"]
+  removeanonvolumes = true
+
+  lifecycle {
+    precondition {
+      condition     = data.qnap_resource_usage.nas.memory_usage < 90
+      error_message = "The NAS is using more than 90% of its memory."
+    }
+  }
+}
@@ -0,0 +1,16 @@
+data "qnap_snapshots" "data_vol" {
+  volume = "DataVol1"
+}
+
+resource "qnap_app" "nextcloud" {
+  name   = "nextcloud"
+  status = "running"
+  yml    = file("${path.module}/nextcloud.yml")
+
+  lifecycle {
+    precondition {
+      condition     = length(data.qnap_snapshots.data_vol.snapshots) > 0
+      error_message = "Take a snapshot of DataVol1 before upgrading Nextcloud."
+    }
+  }
+}
@@ -0,0 +1,18 @@
+data "qnap_volume" "media" {
+  name = "media"
+}
+
+resource "qnap_container" "jellyfin" {
+  name              = "jellyfin"
+  image             = "jellyfin/jellyfin:latest"
+  type              = "docker"
+  removeanonvolumes = true
+  volumes = [
+    {
+      type        = "volume"
+      name        = data.qnap_volume.media.name
+      destination = "/media"
+      permission  = "readOnly"
+    }
+  ]
+}
@@ -0,0 +1,18 @@
+locals {
+  web = provider::qnap::docker_run_to_container(
+    "docker run -d --name web -p 8080:80 -e TZ=UTC -v /share/web:/usr/share/nginx/html:ro --restart unless-stopped nginx:latest"
+  )
+}
+
+resource "qnap_container" "web" {
+  name              = local.web.name
+  image             = local.web.image
+  type              = local.web.type
+  network           = local.web.network
+  networktype       = local.web.networktype
+  env               = local.web.env
+  portbindings      = local.web.portbindings
+  volumes           = local.web.volumes
+  restartpolicy     = local.web.restartpolicy
+  removeanonvolumes = true
+}
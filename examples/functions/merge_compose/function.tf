@@ -0,0 +1,8 @@
+resource "qnap_app" "web" {
+  name              = "web"
+  removeanonvolumes = true
+  yml               = provider::qnap::merge_compose(
+    file("${path.module}/docker-compose.yml"),
+    file("${path.module}/docker-compose.prod.yml"),
+  )
+}
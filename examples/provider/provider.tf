@@ -1,5 +1,16 @@
 provider "qnap" {
-  endpoint   = "https://api.example.com"
-  access_key = "your-access-key"
-  secret_key = "your-secret-key"
-}
\ No newline at end of file
+  host         = "nas.example.com"
+  port         = 5001
+  username     = "admin"
+  password     = var.qnap_password
+  ca_cert_file = "${path.module}/nas-ca.pem"
+
+  default_timeouts {
+    create = "20m"
+    delete = "5m"
+  }
+
+  notifications {
+    source = "Terraform (production)"
+  }
+}
@@ -33,4 +33,78 @@ resource "qnap_container" "bazarr10" {
       permission  = "writable"
     },
   ]
-}
\ No newline at end of file
+}
+resource "qnap_container" "private" {
+  name              = "private-app"
+  image             = "ghcr.io/example/app:v1.0.0"
+  type              = "docker"
+  removeanonvolumes = true
+  registry_auth = {
+    server_address = "ghcr.io"
+    username       = "example"
+    password       = var.registry_token
+  }
+}
+resource "qnap_container" "large_image" {
+  name              = "ml-worker"
+  image             = "ghcr.io/example/ml-worker:latest"
+  type              = "docker"
+  removeanonvolumes = true
+  timeouts {
+    create = "45m"
+    delete = "2m"
+  }
+}
+resource "qnap_container" "web" {
+  name              = "web"
+  image             = "nginx:1.27"
+  type              = "docker"
+  removeanonvolumes = true
+  replace_strategy  = "blue_green"
+  portbindings = [
+    {
+      host      = 8080
+      container = 80
+      protocol  = "tcp"
+      hostip    = "0.0.0.0"
+    },
+  ]
+  readiness = {
+    tcp_port    = 8080
+    log_pattern = "start worker process"
+  }
+}
+
+# Let Container Station update the container to the newest image of the tag at night.
+resource "qnap_container" "auto_updated" {
+  name              = "auto_updated"
+  image             = "nginx:latest"
+  type              = "docker"
+  removeanonvolumes = true
+  adopt_recreated   = true
+  auto_update = {
+    interval     = "24h"
+    window_start = "02:00"
+    window_end   = "05:00"
+  }
+}
+
+# Start the application only once the database it connects to is running and healthy.
+resource "qnap_container" "database" {
+  name              = "database"
+  image             = "postgres:16"
+  type              = "docker"
+  removeanonvolumes = true
+  env = {
+    POSTGRES_PASSWORD = "change-me"
+  }
+}
+
+resource "qnap_container" "application" {
+  name                  = "application"
+  image                 = "nginx:latest"
+  type                  = "docker"
+  removeanonvolumes     = true
+  depends_on_containers = [qnap_container.database.id]
+  wait_for_healthy      = true
+}
@@ -0,0 +1,11 @@
+# Keep a snapshot of the application container for every image it runs. The earlier snapshots are kept on the NAS so
+# the container can be rolled back to one of them after a failed upgrade.
+resource "qnap_container_commit" "snapshot" {
+  container_id = qnap_container.app.id
+  image        = "snapshots/app:${replace(qnap_container.app.image, "/[:/]/", "-")}"
+  comment      = "Snapshot of ${qnap_container.app.image}"
+  author       = "Terraform"
+  triggers = {
+    image = qnap_container.app.image
+  }
+}
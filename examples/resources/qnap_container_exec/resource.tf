@@ -0,0 +1,24 @@
+# Run the database migrations whenever the application container is replaced.
+resource "qnap_container_exec" "migrate" {
+  container_id = qnap_container.app.id
+  command      = ["sh", "-c", "php artisan migrate --force"]
+  user         = "www-data"
+  working_dir  = "/var/www/html"
+  env = {
+    APP_ENV = "production"
+  }
+}
+
+output "migrate_output" {
+  value = qnap_container_exec.migrate.output
+}
+
+# Clear the cache on every apply without failing it.
+resource "qnap_container_exec" "clear_cache" {
+  container_id  = qnap_container.app.id
+  command       = ["php", "artisan", "cache:clear"]
+  fail_on_error = false
+  triggers = {
+    always = timestamp()
+  }
+}
@@ -0,0 +1,28 @@
+# Upload a configuration file into a container.
+resource "qnap_container_file" "nginx_conf" {
+  container_id = qnap_container.web.id
+  path         = "/etc/nginx/conf.d/default.conf"
+  source       = "${path.module}/nginx/default.conf"
+}
+
+# Restart the container when the configuration changes.
+resource "qnap_container" "web" {
+  name              = "web"
+  image             = "nginx:latest"
+  network           = "bridge"
+  networktype       = "default"
+  status            = "running"
+  type              = "docker"
+  removeanonvolumes = true
+  restart_triggers = {
+    config = qnap_container_file.nginx_conf.content_sha256
+  }
+}
+
+# Seed a named volume with a file.
+resource "qnap_container_file" "settings" {
+  volume  = qnap_volume.data.name
+  path    = "config/settings.json"
+  content = jsonencode({ log_level = "info" })
+  mode    = "0600"
+}
@@ -0,0 +1,29 @@
+resource "qnap_container_network" "backend" {
+  name     = "backend"
+  driver   = "bridge"
+  subnet   = "172.30.0.0/16"
+  gateway  = "172.30.0.1"
+  ip_range = "172.30.5.0/24"
+}
+
+# Containers on a macvlan network get an address on the LAN of the adaptor.
+resource "qnap_container_network" "lan" {
+  name    = "lan"
+  driver  = "macvlan"
+  parent  = "eth0"
+  mode    = "bridge"
+  subnet  = "192.168.1.0/24"
+  gateway = "192.168.1.1"
+  # Containers get addresses from the upper half of the LAN, except the hosts already using them.
+  ip_range           = "192.168.1.128/25"
+  excluded_ip_ranges = ["192.168.1.128/32", "192.168.1.200/29"]
+}
+
+resource "qnap_container" "api" {
+  name              = "api"
+  image             = "nginx:latest"
+  type              = "docker"
+  network           = qnap_container_network.backend.name
+  networktype       = "bridge"
+  removeanonvolumes = true
+}
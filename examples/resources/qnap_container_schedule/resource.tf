@@ -0,0 +1,16 @@
+# Restart a container that degrades over time every night at 3:00.
+resource "qnap_container_schedule" "nightly_restart" {
+  name         = "nightly-restart-app"
+  container_id = qnap_container.app.id
+  action       = "restart"
+  schedule     = "0 3 * * *"
+}
+
+# Stop a batch worker on weekends, the schedule can be paused without removing it.
+resource "qnap_container_schedule" "weekend_stop" {
+  name         = "weekend-stop-worker"
+  container_id = qnap_container.worker.id
+  action       = "stop"
+  schedule     = "0 20 * * 5"
+  enabled      = false
+}
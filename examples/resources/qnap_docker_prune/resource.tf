@@ -0,0 +1,17 @@
+# List what a full prune would remove without removing it.
+resource "qnap_docker_prune" "preview" {
+  dry_run       = true
+  prune_images  = "unused"
+  prune_volumes = true
+}
+
+output "prunable_images" {
+  value = qnap_docker_prune.preview.images
+}
+
+# Remove the stopped containers, dangling images and unused networks whenever the app is redeployed.
+resource "qnap_docker_prune" "cleanup" {
+  triggers = {
+    app = qnap_container.app.id
+  }
+}
@@ -0,0 +1,2 @@
+# Groups are imported by name.
+terraform import qnap_group.media media
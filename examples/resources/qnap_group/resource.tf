@@ -0,0 +1,14 @@
+# Grant the accounts of the media group access to the shared folder the media server writes into.
+resource "qnap_group" "media" {
+  name        = "media"
+  gid         = 1100
+  description = "Media library users"
+  members     = ["alice", "bob"]
+}
+
+resource "qnap_smb_share" "media" {
+  share = "media"
+  groups = {
+    (qnap_group.media.name) = "rw"
+  }
+}
@@ -0,0 +1,36 @@
+resource "qnap_image" "nginx" {
+  repository = "nginx"
+  tag        = "1.27"
+}
+
+resource "qnap_image" "private" {
+  repository = "ghcr.io/example/app"
+  tag        = "v1.0.0"
+  registry_auth = {
+    server_address = "ghcr.io"
+    username       = "example"
+    password       = var.registry_token
+  }
+  keep_locally = true
+
+  # Large images get more time and retries before the apply fails.
+  pull_timeout = "45m"
+  pull_retries = 3
+}
+
+# Pin the container to the pulled image, it is replaced whenever a new digest is pulled.
+resource "qnap_container" "web" {
+  name         = "web"
+  image        = qnap_image.nginx.name
+  image_digest = qnap_image.nginx.digest
+  type         = "docker"
+}
+
+# Import an image from an archive on a shared folder for NAS without registry access.
+resource "qnap_image" "offline" {
+  repository = "example/app"
+  tag        = "v1.0.0"
+  import_source = {
+    path = "/Public/images/app-v1.0.0.tar"
+  }
+}
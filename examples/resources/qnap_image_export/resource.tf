@@ -0,0 +1,13 @@
+resource "qnap_image" "app" {
+  repository = "ghcr.io/example/app"
+  tag        = "v1.0.0"
+}
+
+# Save the image to a shared folder, it is exported again whenever a new digest is pulled.
+resource "qnap_image_export" "app" {
+  image_id = qnap_image.app.id
+  path     = "/Public/backup/app-v1.0.0.tar"
+  triggers = {
+    digest = qnap_image.app.digest
+  }
+}
@@ -0,0 +1,2 @@
+# The NFS access of a shared folder is imported by the name of the shared folder.
+terraform import qnap_nfs_share.k8s k8s-data
@@ -0,0 +1,14 @@
+# Export a shared folder to the nodes of a Kubernetes cluster, whose NFS provisioner changes the owner of the volumes.
+resource "qnap_nfs_share" "k8s" {
+  share = "k8s-data"
+  rules = [
+    {
+      host   = "192.168.1.0/24"
+      access = "rw"
+      squash = "no_root_squash"
+    },
+    {
+      host = "backup.local"
+    },
+  ]
+}
@@ -0,0 +1,2 @@
+# The SMB access of a shared folder is imported by the name of the shared folder.
+terraform import qnap_smb_share.media media
@@ -0,0 +1,13 @@
+# Let the media group browse the files a container writes into a hidden shared folder.
+resource "qnap_smb_share" "media" {
+  share        = "media"
+  visible      = false
+  guest_access = "none"
+  users = {
+    jellyfin = "rw"
+  }
+  groups = {
+    media    = "ro"
+    everyone = "deny"
+  }
+}
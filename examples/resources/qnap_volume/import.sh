@@ -0,0 +1,2 @@
+# Volumes are imported by name, including the volumes of docker-compose apps.
+terraform import qnap_volume.data app-data
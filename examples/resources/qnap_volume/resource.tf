@@ -0,0 +1,54 @@
+resource "qnap_volume" "data" {
+  name = "app-data"
+
+  # Bootstrap the site from an archive next to the configuration.
+  initial_content = {
+    content_base64 = filebase64("${path.module}/site.tar.gz")
+  }
+}
+
+resource "qnap_container" "app" {
+  name              = "app"
+  image             = "nginx:latest"
+  type              = "docker"
+  network           = "bridge"
+  networktype       = "default"
+  removeanonvolumes = true
+  volumes = [
+    {
+      type        = "volume"
+      name        = qnap_volume.data.name
+      destination = "/usr/share/nginx/html"
+      permission  = "writable"
+    }
+  ]
+}
+
+# Keep the database on the SSD storage pool.
+resource "qnap_volume" "db" {
+  name      = "db-data"
+  host_path = "/SSD-Data/volumes/db"
+}
+
+# Mount a share of another NAS, the data is not stored on this NAS.
+resource "qnap_volume" "media" {
+  name = "media"
+  remote = {
+    type     = "cifs"
+    server   = "nas2.local"
+    path     = "media/movies"
+    options  = ["vers=3.0", "ro"]
+    username = "media"
+    password = var.media_password
+  }
+}
+
+resource "qnap_volume" "backup" {
+  name = "backup"
+  remote = {
+    type    = "nfs"
+    server  = "192.168.1.20"
+    path    = "/share/backup"
+    options = ["nfsvers=4"]
+  }
+}
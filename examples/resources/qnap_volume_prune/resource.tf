@@ -0,0 +1,15 @@
+# List the dangling anonymous volumes without removing them.
+resource "qnap_volume_prune" "preview" {
+  dry_run = true
+}
+
+output "dangling_volumes" {
+  value = qnap_volume_prune.preview.volumes
+}
+
+# Remove the dangling anonymous volumes whenever the app is redeployed.
+resource "qnap_volume_prune" "cleanup" {
+  triggers = {
+    app = qnap_container.app.id
+  }
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// nasGroup is a group of NAS user accounts.
+type nasGroup struct {
+	Name        string   `json:"name"`
+	GID         int64    `json:"gid,omitempty"`
+	Description string   `json:"description"`
+	Members     []string `json:"members"`
+}
+
+// listGroups returns the groups of user accounts of the NAS.
+func listGroups(ctx context.Context, client *qnap.Client) ([]nasGroup, error) {
+	var response struct {
+		Data []nasGroup `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/system/groups", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
+
+// findGroup returns the group with the given name or nil when it does not exist.
+func findGroup(ctx context.Context, client *qnap.Client, name string) (*nasGroup, error) {
+	groups, err := listGroups(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		if group.Name == name {
+			return &group, nil
+		}
+	}
+	return nil, nil
+}
+
+// createGroup creates a group of user accounts, the NAS assigns the next free GID when it is zero.
+func createGroup(ctx context.Context, client *qnap.Client, group nasGroup) error {
+	if group.Members == nil {
+		group.Members = []string{}
+	}
+	return apiRequest(ctx, client, http.MethodPost, "/container-station/api/v3/system/groups", group, nil)
+}
+
+// updateGroup replaces the description and the members of a group.
+func updateGroup(ctx context.Context, client *qnap.Client, group nasGroup) error {
+	if group.Members == nil {
+		group.Members = []string{}
+	}
+	payload := struct {
+		Description string   `json:"description"`
+		Members     []string `json:"members"`
+	}{Description: group.Description, Members: group.Members}
+	return apiRequest(ctx, client, http.MethodPut, "/container-station/api/v3/system/groups/"+url.PathEscape(group.Name), payload, nil)
+}
+
+// deleteGroup removes a group, the user accounts in it are kept.
+func deleteGroup(ctx context.Context, client *qnap.Client, name string) error {
+	return apiRequest(ctx, client, http.MethodDelete, "/container-station/api/v3/system/groups/"+url.PathEscape(name), nil, nil)
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// apiRequest sends a request to the QNAP API for endpoints that qnap-client-lib does not cover yet.
+// Error responses are returned as an apiError, whose format matches the client library so isNotFound and isAppNotFound
+// keep working.
+func apiRequest(ctx context.Context, client *qnap.Client, method string, path string, payload any, out any) error {
+	var body io.Reader
+	if payload != nil {
+		rb, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(rb)
+	}
+
+	path, err := flavorPath(ctx, client, path)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, client.HostURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	if token := client.Token; token != "" {
+		if parts := strings.SplitN(token, "=", 2); len(parts) == 2 {
+			req.Header.Set("Authorization", "Bearer "+parts[1])
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Cookie", token)
+	}
+
+	res, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return &apiError{Method: method, Path: path, Status: res.StatusCode, Body: string(respBody)}
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+// taskFailedStates are the Container Station task states that will never complete.
+var taskFailedStates = map[string]bool{
+	"failed":    true,
+	"error":     true,
+	"aborted":   true,
+	"canceled":  true,
+	"cancelled": true,
+}
+
+// taskPollInterval is the interval between two polls of the Container Station task list.
+var taskPollInterval = 2 * time.Second
+
+// taskMissingPolls is the number of consecutive polls a task can be missing from the task list before it is considered
+// finished, the NAS prunes finished tasks and may not list short ones at all.
+const taskMissingPolls = 5
+
+// waitForTask polls the Container Station task list until the given task is completed
+// and reports the task progress in the provider logs. A task that stays missing from the task list is considered
+// finished, the callers verify the result of the operation.
+func waitForTask(ctx context.Context, client *qnap.Client, taskID string) error {
+	lastProgress, lastDetail := -1, ""
+	missing := 0
+	for {
+		var tasks qnap.TaskModel
+		err := apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/tasks", nil, &tasks)
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, task := range tasks.Data.Items {
+			if task.ID != taskID {
+				continue
+			}
+			found = true
+			if task.State == qnap.TaskStatusCompleted {
+				return nil
+			}
+			if taskFailedStates[task.State] {
+				return fmt.Errorf("task %s (%s) %s: %s", taskID, task.Description, task.State, task.Detail)
+			}
+			if task.Progress != lastProgress || task.Detail != lastDetail {
+				lastProgress, lastDetail = task.Progress, task.Detail
+				tflog.Info(ctx, "Waiting for Container Station task", map[string]interface{}{
+					"task_id":     taskID,
+					"description": task.Description,
+					"progress":    task.Progress,
+					"detail":      task.Detail,
+				})
+			}
+		}
+
+		missing++
+		if found {
+			missing = 0
+		}
+		if missing >= taskMissingPolls {
+			tflog.Debug(ctx, "Container Station task is not listed, considering it finished", map[string]interface{}{
+				"task_id": taskID,
+			})
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("task %s did not complete: %w", taskID, ctx.Err())
+		case <-time.After(taskPollInterval):
+		}
+	}
+}
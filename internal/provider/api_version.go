@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// The client library and the provider send their requests to the v3 Container Station API, the other versions are
+// reached by rewriting the paths of the requests.
+const (
+	defaultAPIVersion = "v3"
+	apiPathPrefix     = "/container-station/api/"
+)
+
+// apiVersionPattern matches the Container Station API versions.
+var apiVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// probedAPIVersions are the Container Station API versions the provider probes for, newest first.
+var probedAPIVersions = []string{"v3", "v2"}
+
+// apiVersionTransport sends the requests to the v3 Container Station API to another version of the API.
+type apiVersionTransport struct {
+	next    http.RoundTripper
+	version string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *apiVersionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	setAPIVersion(req, t.version)
+	return t.next.RoundTrip(req)
+}
+
+// setAPIVersion rewrites the path of a request to the v3 Container Station API to the given version.
+func setAPIVersion(req *http.Request, version string) {
+	v3Prefix := apiPathPrefix + defaultAPIVersion + "/"
+	if version == "" || version == defaultAPIVersion || !strings.HasPrefix(req.URL.Path, v3Prefix) {
+		return
+	}
+	req.URL.Path = apiPathPrefix + version + "/" + strings.TrimPrefix(req.URL.Path, v3Prefix)
+	req.URL.RawPath = ""
+}
+
+// probeAPIVersion returns the newest Container Station API version the NAS serves. The probe needs no session, the NAS
+// answers 404 Not Found for the versions it does not serve and 401 Unauthorized for the others.
+func probeAPIVersion(ctx context.Context, client *qnap.Client) string {
+	for _, version := range probedAPIVersions {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.HostURL+apiPathPrefix+version+"/system", nil)
+		if err != nil {
+			break
+		}
+		res, err := client.HTTPClient.Do(req)
+		if err != nil {
+			tflog.Warn(ctx, "Unable to probe the Container Station API version", map[string]any{"error": err.Error()})
+			break
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusNotFound {
+			return version
+		}
+	}
+	return defaultAPIVersion
+}
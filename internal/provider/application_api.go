@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+	"gopkg.in/yaml.v2"
+)
+
+// appUpdateSpec extends the client library application spec with the services that are brought up
+// again by a recreate operation, every service is recreated when it is empty, and their number of replicas.
+type appUpdateSpec struct {
+	qnap.NewAppReqModel
+	Services []string         `json:"services,omitempty"`
+	Scale    map[string]int32 `json:"scale,omitempty"`
+}
+
+// updateApplication brings up the given services of an existing application with the new YAML and number of replicas
+// and waits for the task to complete, the services whose configuration did not change are left running.
+func updateApplication(ctx context.Context, client *qnap.Client, app qnap.NewAppReqModel, services []string, scale map[string]int32) (*qnap.AppRespModel, error) {
+	app.Operation = "recreate"
+	spec := appUpdateSpec{NewAppReqModel: app, Services: services, Scale: scale}
+
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodPost, "/container-station/api/v3/apps/compose", spec, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	err = waitForTask(ctx, client, response.Data.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return inspectApplication(client, app.Name)
+}
+
+// appLimitsSpec is the payload of the Container Station application resource limits endpoint, zero removes a limit.
+type appLimitsSpec struct {
+	CPULimit       int32 `json:"cpu_limit"`
+	MemLimit       int32 `json:"mem_limit"`
+	MemReservation int32 `json:"mem_reservation"`
+}
+
+// updateApplicationLimits changes the CPU and memory limits of an existing application in place.
+func updateApplicationLimits(ctx context.Context, client *qnap.Client, name string, limits appLimitsSpec) (*qnap.AppRespModel, error) {
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodPut, fmt.Sprintf("/container-station/api/v3/apps/%s/resource", name), limits, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Data.TaskID != "" {
+		err = waitForTask(ctx, client, response.Data.TaskID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return inspectApplication(client, name)
+}
+
+// scaleChanges returns the services whose number of replicas changes and the replicas to apply, a service removed
+// from the scale goes back to a single replica.
+func scaleChanges(oldScale map[string]int32, newScale map[string]int32) ([]string, map[string]int32) {
+	services := []string{}
+	scale := map[string]int32{}
+	for service, replicas := range newScale {
+		scale[service] = replicas
+		if oldReplicas, ok := oldScale[service]; !ok || oldReplicas != replicas {
+			services = append(services, service)
+		}
+	}
+	for service := range oldScale {
+		if _, ok := newScale[service]; !ok {
+			scale[service] = 1
+			services = append(services, service)
+		}
+	}
+	sort.Strings(services)
+	return services, scale
+}
+
+// changedServices returns the services that are added or changed between two compose files. It returns nil,
+// meaning every service, when a service is removed or a top level key such as volumes or networks changes.
+func changedServices(oldYAML string, newYAML string) ([]string, error) {
+	var oldCompose, newCompose map[string]interface{}
+	if err := yaml.Unmarshal([]byte(oldYAML), &oldCompose); err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal([]byte(newYAML), &newCompose); err != nil {
+		return nil, err
+	}
+
+	oldServices, _ := oldCompose["services"].(map[interface{}]interface{})
+	newServices, _ := newCompose["services"].(map[interface{}]interface{})
+	delete(oldCompose, "services")
+	delete(newCompose, "services")
+	if !cmp.Equal(oldCompose, newCompose) {
+		return nil, nil
+	}
+
+	for name := range oldServices {
+		if _, ok := newServices[name]; !ok {
+			return nil, nil
+		}
+	}
+
+	services := []string{}
+	for name, service := range newServices {
+		if !cmp.Equal(oldServices[name], service) {
+			services = append(services, fmt.Sprint(name))
+		}
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+// composeImages returns the images of the services of a compose file, services that are only built have no image.
+func composeImages(yamlData string) ([]string, error) {
+	var compose ComposeFile
+	if err := yaml.Unmarshal([]byte(yamlData), &compose); err != nil {
+		return nil, err
+	}
+
+	images := []string{}
+	seen := map[string]bool{}
+	for _, service := range compose.Services {
+		if service.Image == "" || seen[service.Image] {
+			continue
+		}
+		seen[service.Image] = true
+		images = append(images, service.Image)
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+// prepareComposeImages applies the pull policy to the images of a compose file before it is brought up: always pulls
+// every image again, never fails when an image is not stored on the NAS and missing leaves the pulls to Container Station.
+func prepareComposeImages(ctx context.Context, client *qnap.Client, yamlData string, policy string) error {
+	if policy != "always" && policy != "never" {
+		return nil
+	}
+
+	images, err := composeImages(yamlData)
+	if err != nil {
+		return err
+	}
+	for _, image := range images {
+		name, tag := splitImageReference(image)
+		if policy == "always" {
+			_, err = pullImage(ctx, client, imagePullSpec{Name: name, Tag: tag}, defaultImageOperationOptions())
+			if err != nil {
+				return fmt.Errorf("could not pull image %s: %w", image, err)
+			}
+			continue
+		}
+
+		found, err := findImageByName(ctx, client, name, tag)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return fmt.Errorf("image %s is not stored on the NAS and pull_images is never", image)
+		}
+	}
+	return nil
+}
+
+// composeRequirement is a compose feature and the oldest Container Station release that supports it.
+type composeRequirement struct {
+	feature    string
+	minRelease string
+	used       func(compose map[interface{}]interface{}) bool
+}
+
+// composeRequirements lists the compose features that older Container Station releases reject.
+var composeRequirements = []composeRequirement{
+	{
+		feature:    "compose file version 3.8",
+		minRelease: "2.2",
+		used: func(compose map[interface{}]interface{}) bool {
+			return fmt.Sprint(compose["version"]) == "3.8"
+		},
+	},
+	{
+		feature:    "deploy.resources",
+		minRelease: "3.0",
+		used: func(compose map[interface{}]interface{}) bool {
+			return anyService(compose, func(service map[interface{}]interface{}) bool {
+				deploy, _ := service["deploy"].(map[interface{}]interface{})
+				return deploy["resources"] != nil
+			})
+		},
+	},
+	{
+		feature:    "profiles",
+		minRelease: "3.0",
+		used: func(compose map[interface{}]interface{}) bool {
+			return anyService(compose, func(service map[interface{}]interface{}) bool {
+				return service["profiles"] != nil
+			})
+		},
+	},
+}
+
+// anyService reports whether a service of the compose file matches.
+func anyService(compose map[interface{}]interface{}, match func(service map[interface{}]interface{}) bool) bool {
+	services, _ := compose["services"].(map[interface{}]interface{})
+	for _, service := range services {
+		if service, ok := service.(map[interface{}]interface{}); ok && match(service) {
+			return true
+		}
+	}
+	return false
+}
+
+// unsupportedComposeFeatures returns the features of a compose file that the Container Station release does not support.
+func unsupportedComposeFeatures(yamlData string, release string) ([]string, error) {
+	var compose map[interface{}]interface{}
+	if err := yaml.Unmarshal([]byte(yamlData), &compose); err != nil {
+		return nil, err
+	}
+
+	unsupported := []string{}
+	if version := fmt.Sprint(compose["version"]); compareReleases(version, "4") >= 0 {
+		unsupported = append(unsupported, "compose file version "+version)
+	}
+	for _, requirement := range composeRequirements {
+		if requirement.used(compose) && compareReleases(release, requirement.minRelease) < 0 {
+			unsupported = append(unsupported, fmt.Sprintf("%s (requires Container Station %s or later)", requirement.feature, requirement.minRelease))
+		}
+	}
+	return unsupported, nil
+}
+
+// compareReleases compares two dotted release numbers, missing or non numeric parts count as zero.
+func compareReleases(a string, b string) int {
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numberA, numberB int
+		if i < len(partsA) {
+			numberA, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			numberB, _ = strconv.Atoi(partsB[i])
+		}
+		if numberA != numberB {
+			if numberA < numberB {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// externalName returns the name of a network or volume declared as external in a compose file,
+// either external: true with an optional name key or the legacy external.name form.
+func externalName(key string, external interface{}, extra map[string]interface{}) (string, bool) {
+	switch external := external.(type) {
+	case bool:
+		if !external {
+			return "", false
+		}
+		if name, ok := extra["name"].(string); ok && name != "" {
+			return name, true
+		}
+		return key, true
+	case map[interface{}]interface{}:
+		if name, ok := external["name"].(string); ok && name != "" {
+			return name, true
+		}
+		return key, true
+	}
+	return "", false
+}
+
+// missingExternalResources returns the external networks and volumes of a compose file that do not exist on the NAS.
+func missingExternalResources(ctx context.Context, client *qnap.Client, yamlData string) ([]string, []string, error) {
+	var compose ComposeFile
+	if err := yaml.Unmarshal([]byte(yamlData), &compose); err != nil {
+		return nil, nil, err
+	}
+
+	missingNetworks := []string{}
+	if len(compose.Networks) > 0 {
+		networks, err := listNetworks(ctx, client)
+		if err != nil {
+			return nil, nil, err
+		}
+		existing := map[string]bool{}
+		for _, network := range networks {
+			existing[network.Name] = true
+		}
+		for key, network := range compose.Networks {
+			if name, ok := externalName(key, network.External, network.Extra); ok && !existing[name] {
+				missingNetworks = append(missingNetworks, name)
+			}
+		}
+	}
+
+	missingVolumes := []string{}
+	if len(compose.Volumes) > 0 {
+		volumes, err := listVolumes(ctx, client)
+		if err != nil {
+			return nil, nil, err
+		}
+		existing := map[string]bool{}
+		for _, volume := range volumes {
+			existing[volume.Name] = true
+		}
+		for key, volume := range compose.Volumes {
+			if name, ok := externalName(key, volume.External, volume.Extra); ok && !existing[name] {
+				missingVolumes = append(missingVolumes, name)
+			}
+		}
+	}
+
+	sort.Strings(missingNetworks)
+	sort.Strings(missingVolumes)
+	return missingNetworks, missingVolumes, nil
+}
+
+// inspectApplication returns the inspect details of an application.
+func inspectApplication(client *qnap.Client, name string) (*qnap.AppRespModel, error) {
+	app, err := client.InspectApplication(name, requestToken(client))
+	return app, withRequest(err, http.MethodGet, fmt.Sprintf("/container-station/api/v3/apps/%s/inspect", name))
+}
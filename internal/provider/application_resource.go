@@ -3,21 +3,29 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -25,68 +33,83 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mohamed-mfarag/qnap-client-lib"
 	"gopkg.in/yaml.v2"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &appResource{}
-	_ resource.ResourceWithConfigure = &appResource{}
+	_ resource.Resource                   = &appResource{}
+	_ resource.ResourceWithConfigure      = &appResource{}
+	_ resource.ResourceWithModifyPlan     = &appResource{}
+	_ resource.ResourceWithValidateConfig = &appResource{}
 )
 
+// ComposeFile holds the parts of a docker-compose file the provider reads, every other key of the
+// compose schema is kept in Extra. The YAML is sent to the NAS as written, these types are only
+// used to validate and inspect it.
 type ComposeFile struct {
-	Version  string             `yaml:"version"`
-	Services map[string]Service `yaml:"services"`
-	Volumes  map[string]Volume  `yaml:"volumes,omitempty"`
-	Networks map[string]Network `yaml:"networks,omitempty"`
+	Version  string                 `yaml:"version"`
+	Services map[string]Service     `yaml:"services"`
+	Volumes  map[string]Volume      `yaml:"volumes,omitempty"`
+	Networks map[string]Network     `yaml:"networks,omitempty"`
+	Extra    map[string]interface{} `yaml:",inline"`
 }
 
 type Service struct {
-	Image       string            `yaml:"image,omitempty"`
-	Build       BuildConfig       `yaml:"build,omitempty"`
-	Command     string            `yaml:"command,omitempty"`
-	Ports       []string          `yaml:"ports,omitempty"`
-	Environment map[string]string `yaml:"environment,omitempty"`
-	Volumes     []string          `yaml:"volumes,omitempty"`
-	Networks    []string          `yaml:"networks,omitempty"`
-	DependsOn   []string          `yaml:"depends_on,omitempty"`
-	Restart     string            `yaml:"restart,omitempty"`
-}
-
-type BuildConfig struct {
-	Context    string            `yaml:"context,omitempty"`
-	Dockerfile string            `yaml:"dockerfile,omitempty"`
-	Args       map[string]string `yaml:"args,omitempty"`
+	Image string `yaml:"image,omitempty"`
+	// Build is either the build context or a build configuration
+	Build interface{}            `yaml:"build,omitempty"`
+	Extra map[string]interface{} `yaml:",inline"`
 }
 
 type Volume struct {
-	Driver     string            `yaml:"driver,omitempty"`
-	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+	Driver     string                 `yaml:"driver,omitempty"`
+	DriverOpts map[string]string      `yaml:"driver_opts,omitempty"`
+	External   interface{}            `yaml:"external,omitempty"`
+	Extra      map[string]interface{} `yaml:",inline"`
 }
 
 type Network struct {
-	Driver     string            `yaml:"driver,omitempty"`
-	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
-	External   bool              `yaml:"external,omitempty"`
+	Driver     string                 `yaml:"driver,omitempty"`
+	DriverOpts map[string]string      `yaml:"driver_opts,omitempty"`
+	External   interface{}            `yaml:"external,omitempty"`
+	Extra      map[string]interface{} `yaml:",inline"`
 }
 
 type AppSpecModel struct {
 	LastUpdated       basetypes.StringValue `tfsdk:"last_updated"`
 	Name              basetypes.StringValue `tfsdk:"name"`
 	Yml               basetypes.StringValue `tfsdk:"yml"`
+	Environment       basetypes.MapValue    `tfsdk:"environment"`
+	RedeployTriggers  basetypes.MapValue    `tfsdk:"redeploy_triggers"`
+	UpdateStrategy    basetypes.StringValue `tfsdk:"update_strategy"`
+	PullImages        basetypes.StringValue `tfsdk:"pull_images"`
 	DefaultURL        basetypes.ObjectValue `tfsdk:"default_url"`
 	Containers        basetypes.ListValue   `tfsdk:"containers"`
+	ServiceURLs       basetypes.MapValue    `tfsdk:"service_urls"`
+	Scale             basetypes.MapValue    `tfsdk:"scale"`
 	CPULimit          basetypes.Int32Value  `tfsdk:"cpu_limit"`
 	MemLimit          basetypes.Int32Value  `tfsdk:"mem_limit"`
 	MemReservation    basetypes.Int32Value  `tfsdk:"mem_reservation"`
 	RemoveAnonVolumes basetypes.BoolValue   `tfsdk:"removeanonvolumes"`
+	RemoveImages      basetypes.BoolValue   `tfsdk:"remove_image_on_destroy"`
+	DeletionProtect   basetypes.BoolValue   `tfsdk:"deletion_protection"`
 	Status            basetypes.StringValue `tfsdk:"status"`
+	WaitForContainers basetypes.BoolValue   `tfsdk:"wait_for_containers"`
+	WaitTimeout       basetypes.StringValue `tfsdk:"wait_timeout"`
+	RenderedSpec      basetypes.StringValue `tfsdk:"rendered_spec"`
+	Timeouts          *timeoutsModel        `tfsdk:"timeouts"`
 }
 
 type ContainersModel struct {
-	ID   basetypes.StringValue `tfsdk:"id"`
-	Name basetypes.StringValue `tfsdk:"name"`
+	ID     basetypes.StringValue `tfsdk:"id"`
+	Name   basetypes.StringValue `tfsdk:"name"`
+	Status basetypes.StringValue `tfsdk:"status"`
+	Image  basetypes.StringValue `tfsdk:"image"`
+	Health basetypes.StringValue `tfsdk:"health"`
+	Ports  basetypes.ListValue   `tfsdk:"ports"`
 }
 
 type DefaultURLModel struct {
@@ -96,7 +119,9 @@ type DefaultURLModel struct {
 
 // appResource is the resource implementation.
 type appResource struct {
-	client *qnap.Client
+	client   *qnap.Client
+	timeouts operationTimeouts
+	notifier *notifier
 }
 
 // NewAppResource is a helper function to simplify the provider implementation.
@@ -112,6 +137,9 @@ func (r *appResource) Metadata(_ context.Context, req resource.MetadataRequest,
 // Schema defines the schema for the resource.
 func (d *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Required:    true,
@@ -127,21 +155,49 @@ func (d *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			},
 			"status": schema.StringAttribute{
 				Required:    true,
-				Description: "The state of the application (running, stopped). important to note that change in status requires complete recreation of the application - will be updated in the next version.",
+				Description: "The state of the application (running, stopped). Changes start or stop the application in place.",
 				Validators: []validator.String{
 					stringvalidator.OneOf("running", "stopped"),
 				},
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"yml": schema.StringAttribute{
 				Required:    true,
-				Description: "The YAML configuration for the application.",
+				Description: "The docker-compose YAML of the application. Every key of the compose schema is supported, the YAML is validated and sent to the NAS as written. The compose file version and the features that need a recent Container Station are checked against the release installed on the NAS before the YAML is applied. Changes replace the application unless update_strategy is rolling.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace, resp.Diagnostics = replacedOnComposeChange(ctx, req.Plan)
+					}, "Changes replace the application unless update_strategy is rolling.", "Changes replace the application unless `update_strategy` is `rolling`."),
+				},
+			},
+			"environment": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The values substituted into the ${VAR}, ${VAR:-default} and $VAR placeholders of the YAML before it is sent to the NAS, the stored YAML keeps the placeholders. Placeholders of variables missing from the map are sent as written and $$ escapes a literal dollar sign.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace, resp.Diagnostics = replacedOnComposeChange(ctx, req.Plan)
+					}, "Changes replace the application unless update_strategy is rolling.", "Changes replace the application unless `update_strategy` is `rolling`."),
+				},
+			},
+			"redeploy_triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Arbitrary values that bring up every service of the application again in place when changed, even when yml is unchanged, for example the hash of a secret or of a bind-mounted configuration file (e.g. filesha256(\"nginx.conf\")).",
+			},
+			"pull_images": schema.StringAttribute{
+				Optional:    true,
+				Description: "When the images of the services are pulled as the application is created or updated (always, missing, never). always pulls every image again so that mutable tags such as latest are refreshed, missing only pulls the images that are not stored on the NAS and never fails when an image is not stored on the NAS. Defaults to missing.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("always", "missing", "never"),
+				},
+			},
+			"update_strategy": schema.StringAttribute{
+				Optional:    true,
+				Description: "How changes of yml and environment are applied (recreate, rolling). recreate replaces the whole application, rolling brings up again only the services that are added or changed and keeps the others running. Every service is recreated in place when a service is removed or the volumes or networks change. Defaults to recreate.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("recreate", "rolling"),
 				},
 			},
 			"removeanonvolumes": schema.BoolAttribute{
@@ -152,11 +208,16 @@ func (d *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 					boolplanmodifier.RequiresReplace(),
 				},
 			},
+			"remove_image_on_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to remove the images of the application containers when it is destroyed, unless another container still uses them. Defaults to false.",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to refuse to destroy or replace the application until this is set to false and applied. Defaults to false.",
+			},
 			"containers": schema.ListNestedAttribute{
 				Computed: true,
-				PlanModifiers: []planmodifier.List{
-					listplanmodifier.UseStateForUnknown(),
-				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.StringAttribute{
@@ -173,9 +234,39 @@ func (d *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 								stringplanmodifier.UseStateForUnknown(),
 							},
 						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the container, for example running or exited.",
+						},
+						"image": schema.StringAttribute{
+							Computed:    true,
+							Description: "The image the container runs.",
+						},
+						"health": schema.StringAttribute{
+							Computed:    true,
+							Description: "The health check status of the container (starting, healthy, unhealthy), empty when the service has no health check.",
+						},
+						"ports": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "The published ports of the container in the hostIP:host:container/protocol format.",
+						},
 					},
 				},
-				Description: "The list of containers in the application.",
+				Description: "The list of containers in the application, refreshed on every read.",
+			},
+			"scale": schema.MapAttribute{
+				ElementType: types.Int32Type,
+				Optional:    true,
+				Description: "The number of replicas of the services by service name, applied after the application is deployed like docker compose up --scale. Changes are applied in place and the containers of every replica are listed in containers. Services that are not listed keep a single replica.",
+				Validators: []validator.Map{
+					mapvalidator.ValueInt32sAre(int32validator.AtLeast(0)),
+				},
+			},
+			"service_urls": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The URL each service is reachable at by service name, the address of the NAS with the port the service publishes on every interface. The service of default_url uses the port and protocol of default_url, services without a published TCP port are left out.",
 			},
 			"default_url": schema.SingleNestedAttribute{
 				Optional:    true,
@@ -205,41 +296,62 @@ func (d *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			},
 			"cpu_limit": schema.Int32Attribute{
 				Optional:    true,
-				Computed:    true,
-				Description: "The CPU limit for the application.",
-				PlanModifiers: []planmodifier.Int32{
-					int32planmodifier.UseStateForUnknown(),
-					int32planmodifier.RequiresReplace(),
+				Description: "The CPU limit for the application. Changes are applied in place, a value changed outside of Terraform is reported as drift and reset on the next apply unless the attribute is listed in lifecycle.ignore_changes. Unset means no limit.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
 				},
 			},
 			"mem_limit": schema.Int32Attribute{
 				Optional:    true,
-				Computed:    true,
-				Description: "The memory limit for the application.",
-				PlanModifiers: []planmodifier.Int32{
-					int32planmodifier.UseStateForUnknown(),
-					int32planmodifier.RequiresReplace(),
+				Description: "The memory limit for the application. Changes are applied in place, a value changed outside of Terraform is reported as drift and reset on the next apply unless the attribute is listed in lifecycle.ignore_changes. Unset means no limit.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
 				},
 			},
 			"mem_reservation": schema.Int32Attribute{
 				Optional:    true,
-				Computed:    true,
-				Description: "The memory reservation for the application.",
-				PlanModifiers: []planmodifier.Int32{
-					int32planmodifier.UseStateForUnknown(),
-					int32planmodifier.RequiresReplace(),
+				Description: "The memory reservation for the application. Changes are applied in place, a value changed outside of Terraform is reported as drift and reset on the next apply unless the attribute is listed in lifecycle.ignore_changes. Unset means no limit.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+			"wait_for_containers": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to wait for every container of the application to be running, and healthy when it has a health check, when the application is created or started. The apply fails with the status of each container when they are not ready within wait_timeout. Defaults to false.",
+			},
+			"wait_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "The maximum duration to wait for the containers (e.g. '90s', '10m'). Defaults to 5m.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^([0-9]+(h|m|s))+$`), "Timeout must be a duration (e.g. '90s', '30m', '1h30m')."),
+					stringvalidator.AlsoRequires(path.MatchRoot("wait_for_containers")),
 				},
 			},
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
 				Description: "The last updated timestamp of the application.",
 			},
+			"rendered_spec": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The JSON request submitted to the NAS when the compose file was last deployed, with the environment substituted into the YAML, to archive the deployed manifest or diff it outside of Terraform. Sensitive as it holds the values of environment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
 
 // Create a new resource.
 func (r *appResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	timeouts, diags := resourceTimeouts(ctx, r.timeouts, req.Plan.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, timeouts.Create)
+	defer cancel()
 
 	var plan, state *AppSpecModel
 
@@ -255,25 +367,102 @@ func (r *appResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	resp.Diagnostics.Append(r.checkComposeCompatibility(ctx, newAppPlan.Yml)...)
+	resp.Diagnostics.Append(r.checkExternalResources(ctx, newAppPlan.Yml, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := prepareComposeImages(ctx, r.client, newAppPlan.Yml, plan.PullImages.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error pulling application images",
+			errorDetail("Could not prepare the images of app "+plan.Name.ValueString(), err),
+		)
+		return
+	}
+
 	// Create new app
-	app, err := r.client.CreateApplication(newAppPlan, &r.client.Token)
+	app, err := r.client.CreateApplication(newAppPlan, requestToken(r.client))
+	err = withRequest(err, http.MethodPost, "/container-station/api/v3/apps/compose")
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating app",
-			"Could not create app, unexpected error: "+err.Error(),
+			errorDetail("Could not create app", err),
+		)
+		return
+	}
+
+	// An application that fails to be set up after it is created is not in state, remove it so the next apply can create it again
+	defer func() {
+		if resp.Diagnostics.HasError() && resp.State.Raw.IsNull() {
+			resp.Diagnostics.Append(r.removePartialApplication(plan)...)
+		}
+	}()
+
+	scale, diags := scaleOf(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(scale) > 0 {
+		services, scale := scaleChanges(nil, scale)
+		app, err = updateApplication(ctx, r.client, newAppPlan, services, scale)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error scaling application",
+				errorDetail("Could not scale the services of application "+plan.Name.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	// Applications are started on creation
+	if plan.Status.ValueString() == "stopped" {
+		app, err = r.changeStatus(plan.Name.ValueString(), plan.Status.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error changing application status",
+				errorDetail("Could not stop application "+plan.Name.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	app, containers, err := r.readContainers(ctx, plan, app)
+	var notReady *containersNotReadyError
+	if err != nil && !errors.As(err, &notReady) {
+		resp.Diagnostics.AddError(
+			"Error reading application containers",
+			errorDetail("Could not read the containers of application "+plan.Name.ValueString(), err),
 		)
 		return
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	state, diags = GetCurrentState(ctx, plan, app)
+	state, diags = GetCurrentState(ctx, plan, app, containers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.ServiceURLs, diags = r.serviceURLs(ctx, app, containers)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// special handling for the removeanonvolumes attribute
+	// special handling for the removeanonvolumes, remove_image_on_destroy and wait attributes
 	state.RemoveAnonVolumes = plan.RemoveAnonVolumes
+	state.RemoveImages = plan.RemoveImages
+	state.DeletionProtect = plan.DeletionProtect
+	state.UpdateStrategy = plan.UpdateStrategy
+	state.PullImages = plan.PullImages
+	state.Scale = plan.Scale
+	state.WaitForContainers = plan.WaitForContainers
+	state.WaitTimeout = plan.WaitTimeout
+	state.RedeployTriggers = plan.RedeployTriggers
+	state.RenderedSpec = renderSpec(newAppPlan)
+	state.Timeouts = plan.Timeouts
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, state)
@@ -281,19 +470,36 @@ func (r *appResource) Create(ctx context.Context, req resource.CreateRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// The application is kept in state so that it is replaced on the next apply
+	if notReady != nil {
+		resp.Diagnostics.AddError(
+			"Application containers are not ready",
+			"Application "+plan.Name.ValueString()+" was created but its containers did not become ready: "+notReady.Error(),
+		)
+	}
+	r.notifier.notify(ctx, &resp.Diagnostics, "Application "+plan.Name.ValueString()+" was created.")
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	timeouts, diags := resourceTimeouts(ctx, r.timeouts, req.State.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, timeouts.Read)
+	defer cancel()
+
 	// Get current state
 	var priorState, newState *AppSpecModel
-	diags := req.State.Get(ctx, &priorState)
+	diags = req.State.Get(ctx, &priorState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	// Get refreshed application value from QNAP
-	currentState, err := r.client.InspectApplication(priorState.Name.ValueString(), &r.client.Token)
+	currentState, err := inspectApplication(r.client, priorState.Name.ValueString())
 	if err != nil {
 		//Handle errors, such as resource not found
 		if isAppNotFound(err) {
@@ -302,13 +508,27 @@ func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		}
 		resp.Diagnostics.AddError(
 			"Unable to Read Resource",
-			"An error occurred while reading the resource: "+err.Error(),
+			errorDetail("An error occurred while reading the resource", err),
+		)
+		return
+	}
+
+	containers, err := r.inspectContainers(ctx, currentState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the containers of the application", err),
 		)
 		return
 	}
 
 	// Check if state is matching or not and return new status
-	newState, diags = GetCurrentState(ctx, priorState, currentState)
+	newState, diags = GetCurrentState(ctx, priorState, currentState, containers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	newState.ServiceURLs, diags = r.serviceURLs(ctx, currentState, containers)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -316,6 +536,16 @@ func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 	// Check if the RemoveAnonVolumes is equal
 	newState.RemoveAnonVolumes = priorState.RemoveAnonVolumes
+	newState.RemoveImages = priorState.RemoveImages
+	newState.DeletionProtect = priorState.DeletionProtect
+	newState.UpdateStrategy = priorState.UpdateStrategy
+	newState.PullImages = priorState.PullImages
+	newState.Scale = priorState.Scale
+	newState.WaitForContainers = priorState.WaitForContainers
+	newState.WaitTimeout = priorState.WaitTimeout
+	newState.RedeployTriggers = priorState.RedeployTriggers
+	newState.RenderedSpec = priorState.RenderedSpec
+	newState.Timeouts = priorState.Timeouts
 	// Set refreshed state
 
 	diags = resp.State.Set(ctx, &newState)
@@ -327,27 +557,327 @@ func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	timeouts, diags := resourceTimeouts(ctx, r.timeouts, req.Plan.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, timeouts.Update)
+	defer cancel()
+
+	// Retrieve values from plan and prior state
+	var plan, state AppSpecModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every other attribute requires replacement, only the status, the limits, the compose changes of a rolling update
+	// and the provider options are updated in place
+	var app *qnap.AppRespModel
+	renderedSpec := state.RenderedSpec
+	redeploy := !plan.RedeployTriggers.Equal(state.RedeployTriggers)
+	if !plan.Yml.Equal(state.Yml) || !plan.Environment.Equal(state.Environment) || redeploy {
+		newAppPlan, diags := ReadState(ctx, req.Plan)
+		resp.Diagnostics.Append(diags...)
+		stateEnvironment, diags := environmentOf(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.checkComposeCompatibility(ctx, newAppPlan.Yml)...)
+		resp.Diagnostics.Append(r.checkExternalResources(ctx, newAppPlan.Yml, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		services, err := changedServices(substituteVariables(state.Yml.ValueString(), stateEnvironment), newAppPlan.Yml)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating application",
+				errorDetail("Could not compare the services of application "+plan.Name.ValueString(), err),
+			)
+			return
+		}
+		// Every service is brought up again when a redeploy trigger changed
+		if redeploy {
+			services = nil
+		}
+		tflog.Info(ctx, "Updating application services", map[string]interface{}{
+			"application": plan.Name.ValueString(),
+			"services":    services,
+		})
+
+		err = prepareComposeImages(ctx, r.client, newAppPlan.Yml, plan.PullImages.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error pulling application images",
+				errorDetail("Could not prepare the images of application "+plan.Name.ValueString(), err),
+			)
+			return
+		}
+
+		scale, diags := scaleOf(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		app, err = updateApplication(ctx, r.client, newAppPlan, services, scale)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating application",
+				errorDetail("Could not update application "+plan.Name.ValueString(), err),
+			)
+			return
+		}
+		renderedSpec = renderSpec(newAppPlan)
+	} else if !plan.Scale.Equal(state.Scale) {
+		newAppPlan, diags := ReadState(ctx, req.Plan)
+		resp.Diagnostics.Append(diags...)
+		stateScale, diags := scaleOf(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		planScale, diags := scaleOf(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var err error
+		services, scale := scaleChanges(stateScale, planScale)
+		app, err = updateApplication(ctx, r.client, newAppPlan, services, scale)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error scaling application",
+				errorDetail("Could not scale the services of application "+plan.Name.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	if !plan.CPULimit.Equal(state.CPULimit) || !plan.MemLimit.Equal(state.MemLimit) || !plan.MemReservation.Equal(state.MemReservation) {
+		var err error
+		app, err = updateApplicationLimits(ctx, r.client, plan.Name.ValueString(), appLimitsSpec{
+			CPULimit:       plan.CPULimit.ValueInt32(),
+			MemLimit:       plan.MemLimit.ValueInt32(),
+			MemReservation: plan.MemReservation.ValueInt32(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating application limits",
+				errorDetail("Could not update the limits of application "+plan.Name.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	// Services brought up by the update are running
+	if !plan.Status.Equal(state.Status) || (app != nil && plan.Status.ValueString() == "stopped") {
+		var err error
+		app, err = r.changeStatus(plan.Name.ValueString(), plan.Status.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error changing application status",
+				errorDetail("Could not change the status of application "+plan.Name.ValueString()+" to "+plan.Status.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	var notReady *containersNotReadyError
+	if app != nil {
+		app, containers, err := r.readContainers(ctx, &plan, app)
+		if err != nil && !errors.As(err, &notReady) {
+			resp.Diagnostics.AddError(
+				"Error reading application containers",
+				errorDetail("Could not read the containers of application "+plan.Name.ValueString(), err),
+			)
+			return
+		}
+
+		newState, diags := GetCurrentState(ctx, &plan, app, containers)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		newState.ServiceURLs, diags = r.serviceURLs(ctx, app, containers)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		newState.RemoveAnonVolumes = plan.RemoveAnonVolumes
+		state = *newState
+	}
+
+	state.RemoveImages = plan.RemoveImages
+	state.DeletionProtect = plan.DeletionProtect
+	state.UpdateStrategy = plan.UpdateStrategy
+	state.PullImages = plan.PullImages
+	state.Scale = plan.Scale
+	state.WaitForContainers = plan.WaitForContainers
+	state.WaitTimeout = plan.WaitTimeout
+	state.RedeployTriggers = plan.RedeployTriggers
+	state.RenderedSpec = renderedSpec
+	state.Timeouts = plan.Timeouts
+	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if notReady != nil {
+		resp.Diagnostics.AddError(
+			"Application containers are not ready",
+			"Application "+plan.Name.ValueString()+" was updated but its containers did not become ready: "+notReady.Error(),
+		)
+	}
+	r.notifier.notify(ctx, &resp.Diagnostics, "Application "+plan.Name.ValueString()+" was updated.")
 }
 
 // Delete removes the resource from the Terraform state.
 func (r *appResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	timeouts, diags := resourceTimeouts(ctx, r.timeouts, req.State.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, timeouts.Delete)
+	defer cancel()
+
 	// Retrieve values from state
 	var state AppSpecModel
-	diags := req.State.Get(ctx, &state)
+	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Delete existing order
-	_, err := r.client.DeleteApplication(state.Name.ValueString(), state.RemoveAnonVolumes.ValueBool(), &r.client.Token)
+	if state.DeletionProtect.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Deletion Protection Enabled",
+			"Application "+state.Name.ValueString()+" has deletion_protection enabled and cannot be destroyed or replaced. "+
+				"Set deletion_protection to false and apply the change before destroying it.",
+		)
+		return
+	}
+
+	// The image IDs are read before deletion as the containers are removed with the application
+	var imageIDs []string
+	if state.RemoveImages.ValueBool() {
+		var containers []ContainersModel
+		diags = state.Containers.ElementsAs(ctx, &containers, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, appContainer := range containers {
+			container, err := inspectContainer(ctx, r.client, appContainer.ID.ValueString(), "docker")
+			if err != nil {
+				if isNotFound(err) {
+					continue
+				}
+				resp.Diagnostics.AddError(
+					"Unable to Read Resource",
+					errorDetail("An error occurred while reading the image of container "+appContainer.Name.ValueString(), err),
+				)
+				return
+			}
+			imageIDs = append(imageIDs, container.Data.ImageID)
+		}
+	}
+
+	// Delete existing application
+	_, err := r.client.DeleteApplication(state.Name.ValueString(), state.RemoveAnonVolumes.ValueBool(), requestToken(r.client))
+	err = withRequest(err, http.MethodDelete, "/container-station/api/v3/apps")
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Deleting HashiCups Order",
-			"Could not delete order, unexpected error: "+err.Error(),
+			"Error Deleting Application",
+			errorDetail("Could not delete application "+state.Name.ValueString(), err),
 		)
 		return
 	}
+
+	// The application is already removed, failing to remove the images does not fail the destroy
+	if len(imageIDs) > 0 {
+		err = removeUnusedImages(ctx, r.client, imageIDs)
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Unable to remove images",
+				errorDetail("The application was removed but its images could not be removed", err),
+			)
+		}
+	}
+	r.notifier.notify(ctx, &resp.Diagnostics, "Application "+state.Name.ValueString()+" was destroyed.")
+}
+
+// ValidateConfig validates the combination of attributes in the configuration.
+func (r *appResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config AppSpecModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Yml.IsUnknown() || config.Scale.IsNull() || config.Scale.IsUnknown() {
+		return
+	}
+
+	var compose ComposeFile
+	if err := yaml.Unmarshal([]byte(config.Yml.ValueString()), &compose); err != nil {
+		return
+	}
+	for service := range config.Scale.Elements() {
+		if _, ok := compose.Services[service]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("scale").AtMapKey(service),
+				"Unknown service",
+				"The compose file has no service named "+service+" to scale.",
+			)
+		}
+	}
+}
+
+// ModifyPlan warns about the external networks and volumes of a new or changed compose file that do not exist on the NAS
+// and plans a new rendered_spec when the compose file is deployed again.
+func (r *appResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the application is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan AppSpecModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var state AppSpecModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() || (plan.Yml.Equal(state.Yml) && plan.Environment.Equal(state.Environment)) {
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rendered_spec"), types.StringUnknown())...)
+	}
+
+	// Nothing to check when the compose file is unknown or the provider is not configured yet
+	if plan.Yml.IsUnknown() || r.client == nil {
+		return
+	}
+
+	// The values of the environment may only be known once applied
+	environment, diags := environmentOf(ctx, &plan)
+	if diags.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.checkExternalResources(ctx, substituteVariables(plan.Yml.ValueString(), environment), true)...)
 }
 
 // Configure adds the provider configured client to the resource.
@@ -358,20 +888,282 @@ func (r *appResource) Configure(ctx context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*qnap.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *qnap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.timeouts = data.timeouts
+	r.notifier = data.notifier
 }
 
-// Helper function to validate the YAML and convert it to String.
+// removePartialApplication deletes an application whose creation failed after Container Station created it.
+func (r *appResource) removePartialApplication(plan *AppSpecModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, err := r.client.DeleteApplication(plan.Name.ValueString(), plan.RemoveAnonVolumes.ValueBool(), requestToken(r.client))
+	err = withRequest(err, http.MethodDelete, "/container-station/api/v3/apps")
+	if err != nil {
+		diags.AddError(
+			"Error Removing Partially Created Application",
+			errorDetail("Application "+plan.Name.ValueString()+" was created but could not be set up nor removed, "+
+				"remove it on the NAS or import it before applying again", err),
+		)
+		return diags
+	}
+	diags.AddWarning(
+		"Partially Created Application Removed",
+		"Application "+plan.Name.ValueString()+" was created but could not be set up, so it was removed. The next apply creates it again.",
+	)
+	return diags
+}
+
+// changeStatus starts or stops an application and returns its inspect details.
+func (r *appResource) changeStatus(name string, status string) (*qnap.AppRespModel, error) {
+	var err error
+	if status == "running" {
+		_, err = r.client.StartApplication(name, requestToken(r.client))
+		err = withRequest(err, http.MethodPut, "/container-station/api/v3/apps/start")
+	} else {
+		_, err = r.client.StopApplication(name, requestToken(r.client))
+		err = withRequest(err, http.MethodPut, "/container-station/api/v3/apps/stop")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return inspectApplication(r.client, name)
+}
+
+// inspectContainers returns the inspect details of the application containers by ID,
+// containers removed while the application is read are left out.
+func (r *appResource) inspectContainers(ctx context.Context, app *qnap.AppRespModel) (map[string]*containerDetails, error) {
+	containers := map[string]*containerDetails{}
+	for _, appContainer := range app.Data.Containers {
+		container, err := inspectContainer(ctx, r.client, appContainer.ID, "docker")
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		containers[appContainer.ID] = container
+	}
+	return containers, nil
+}
+
+// checkComposeCompatibility checks that the Container Station release of the NAS supports the features of the compose file.
+func (r *appResource) checkComposeCompatibility(ctx context.Context, yamlData string) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	system, err := getSystemInfo(ctx, r.client)
+	if err != nil || system.Version == "" {
+		tflog.Warn(ctx, "Skipping the compose compatibility check as the Container Station release is not available", map[string]interface{}{
+			"error": fmt.Sprint(err),
+		})
+		return diagnostics
+	}
+
+	unsupported, err := unsupportedComposeFeatures(yamlData, system.Version)
+	if err != nil {
+		return diagnostics
+	}
+	if len(unsupported) > 0 {
+		diagnostics.AddAttributeError(
+			path.Root("yml"),
+			"Unsupported compose features",
+			fmt.Sprintf("Container Station %s on the NAS does not support %s. Update Container Station or remove them from the YAML.", system.Version, strings.Join(unsupported, ", ")),
+		)
+	}
+	return diagnostics
+}
+
+// checkExternalResources checks that the external networks and volumes of the compose file exist on the NAS. While planning
+// they are reported as warnings, as other resources of the same apply may create them, and as errors otherwise.
+func (r *appResource) checkExternalResources(ctx context.Context, yamlData string, planning bool) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	report := diagnostics.AddAttributeError
+	if planning {
+		report = diagnostics.AddAttributeWarning
+	}
+
+	networks, volumes, err := missingExternalResources(ctx, r.client, yamlData)
+	if err != nil {
+		report(
+			path.Root("yml"),
+			"Unable to check external resources",
+			errorDetail("Could not check that the external networks and volumes of the application exist on the NAS", err),
+		)
+		return diagnostics
+	}
+	for _, network := range networks {
+		report(
+			path.Root("yml"),
+			"Missing external network",
+			"The compose file uses the external network "+network+" which does not exist on the NAS. Create it first, for example with a qnap_container_network resource, or remove external so that the application creates it.",
+		)
+	}
+	for _, volume := range volumes {
+		report(
+			path.Root("yml"),
+			"Missing external volume",
+			"The compose file uses the external volume "+volume+" which does not exist on the NAS. Create it first, for example with a qnap_volume resource, or remove external so that the application creates it.",
+		)
+	}
+	return diagnostics
+}
+
+// defaultAppWaitTimeout is the maximum duration to wait for the application containers when wait_timeout is not set.
+const defaultAppWaitTimeout = 5 * time.Minute
+
+// containersNotReadyError is returned when the application containers are not ready in time,
+// the application and container details read last are returned with it.
+type containersNotReadyError struct {
+	pending []string
+	cause   error
+}
+
+func (e *containersNotReadyError) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %s", strings.Join(e.pending, ", "), e.cause)
+	}
+	return strings.Join(e.pending, ", ")
+}
+
+// readContainers returns the inspect details of the application containers, waiting for them to be ready first
+// when the plan asks for it.
+func (r *appResource) readContainers(ctx context.Context, plan *AppSpecModel, app *qnap.AppRespModel) (*qnap.AppRespModel, map[string]*containerDetails, error) {
+	if !plan.WaitForContainers.ValueBool() || plan.Status.ValueString() != "running" {
+		containers, err := r.inspectContainers(ctx, app)
+		return app, containers, err
+	}
+
+	timeout := defaultAppWaitTimeout
+	if !plan.WaitTimeout.IsNull() && !plan.WaitTimeout.IsUnknown() {
+		var err error
+		timeout, err = time.ParseDuration(plan.WaitTimeout.ValueString())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return r.waitForContainers(ctx, plan.Name.ValueString(), timeout)
+}
+
+// waitForContainers polls the application until every container is running, and healthy when it has a health check.
+// When the containers are not ready in time it returns the last details read and the status of each pending container.
+func (r *appResource) waitForContainers(ctx context.Context, name string, timeout time.Duration) (*qnap.AppRespModel, map[string]*containerDetails, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		app, err := inspectApplication(r.client, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		containers, err := r.inspectContainers(ctx, app)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var pending []string
+		if len(app.Data.Containers) == 0 {
+			pending = append(pending, "no containers created")
+		}
+		for _, appContainer := range app.Data.Containers {
+			container := containers[appContainer.ID]
+			switch {
+			case container == nil:
+				pending = append(pending, appContainer.Name+": removed")
+			case container.Data.Status != "running":
+				pending = append(pending, appContainer.Name+": "+container.Data.Status)
+			case container.Data.DockerStatus.Health != "" && container.Data.DockerStatus.Health != "healthy":
+				pending = append(pending, appContainer.Name+": "+container.Data.Status+" ("+container.Data.DockerStatus.Health+")")
+			}
+		}
+		if len(pending) == 0 {
+			return app, containers, nil
+		}
+		if time.Now().After(deadline) {
+			return app, containers, &containersNotReadyError{pending: pending, cause: fmt.Errorf("timed out after %s", timeout)}
+		}
+
+		tflog.Info(ctx, "Waiting for application containers", map[string]interface{}{
+			"application": name,
+			"pending":     pending,
+		})
+		select {
+		case <-ctx.Done():
+			return app, containers, &containersNotReadyError{pending: pending, cause: ctx.Err()}
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// composeServiceLabel is the label docker compose sets to the service name on the containers it creates.
+const composeServiceLabel = "com.docker.compose.service"
+
+// serviceURLs returns the URL of each service of the application that publishes a TCP port on every interface of the NAS.
+func (r *appResource) serviceURLs(ctx context.Context, app *qnap.AppRespModel, containers map[string]*containerDetails) (types.Map, diag.Diagnostics) {
+	host := r.client.HostURL
+	if hostURL, err := url.Parse(r.client.HostURL); err == nil && hostURL.Hostname() != "" {
+		host = hostURL.Hostname()
+	}
+	defaultURL := app.Data.DefaultURL
+
+	urls := map[string]string{}
+	for _, appContainer := range app.Data.Containers {
+		container := containers[appContainer.ID]
+		if container == nil {
+			continue
+		}
+		service := container.Data.Labels[composeServiceLabel]
+		if service == "" {
+			continue
+		}
+
+		for _, port := range container.Data.PortBindings {
+			if port.Host == 0 || port.Protocol == "udp" || (port.HostIP != "" && port.HostIP != "0.0.0.0" && port.HostIP != "::") {
+				continue
+			}
+			isDefault := service == defaultURL.Service && port.Container == defaultURL.Port
+			if _, ok := urls[service]; ok && !isDefault {
+				continue
+			}
+
+			scheme := "http"
+			if isDefault && defaultURL.Protocol != "" {
+				scheme = strings.ToLower(defaultURL.Protocol)
+			} else if port.Container == 443 {
+				scheme = "https"
+			}
+			urls[service] = scheme + "://" + net.JoinHostPort(host, strconv.Itoa(int(port.Host)))
+			if isDefault {
+				break
+			}
+		}
+	}
+	return types.MapValueFrom(ctx, types.StringType, urls)
+}
+
+// containerPorts formats the published ports of a container in the hostIP:host:container/protocol format.
+func containerPorts(container *containerDetails) []string {
+	ports := []string{}
+	if container == nil {
+		return ports
+	}
+	for _, port := range container.Data.PortBindings {
+		binding := fmt.Sprintf("%d:%d/%s", port.Host, port.Container, port.Protocol)
+		if port.HostIP != "" {
+			binding = port.HostIP + ":" + binding
+		}
+		ports = append(ports, binding)
+	}
+	return ports
+}
+
+// Helper function to validate the YAML, the YAML is returned untouched so no compose key is dropped.
 func validateYAML(yamlData string) (string, error) {
 	var compose ComposeFile
 	err := yaml.Unmarshal([]byte(yamlData), &compose)
@@ -387,17 +1179,62 @@ func validateYAML(yamlData string) (string, error) {
 	}
 
 	for serviceName, service := range compose.Services {
-		if service.Image == "" && service.Build.Context == "" {
+		if service.Image == "" && service.Build == nil {
 			return "", fmt.Errorf("service '%s' must have either an image or a build context", serviceName)
 		}
 	}
 
-	ValidatedYamlData, err := yaml.Marshal(compose)
-	if err != nil {
-		return "", fmt.Errorf("error converting to YAMML: %w", err)
+	return yamlData, nil
+}
+
+// environmentOf returns the environment values of the application, empty when none are set.
+func environmentOf(ctx context.Context, app *AppSpecModel) (map[string]string, diag.Diagnostics) {
+	environment := map[string]string{}
+	if app.Environment.IsNull() || app.Environment.IsUnknown() {
+		return environment, nil
 	}
+	diags := app.Environment.ElementsAs(ctx, &environment, false)
+	return environment, diags
+}
+
+// scaleOf returns the number of replicas of the services of the application, empty when none are set.
+func scaleOf(ctx context.Context, app *AppSpecModel) (map[string]int32, diag.Diagnostics) {
+	scale := map[string]int32{}
+	if app.Scale.IsNull() || app.Scale.IsUnknown() {
+		return scale, nil
+	}
+	diags := app.Scale.ElementsAs(ctx, &scale, false)
+	return scale, diags
+}
 
-	return string(ValidatedYamlData), nil
+// replacedOnComposeChange returns whether a change of the compose attributes replaces the application,
+// the rolling update strategy updates the application in place instead.
+func replacedOnComposeChange(ctx context.Context, plan tfsdk.Plan) (bool, diag.Diagnostics) {
+	var strategy types.String
+	diags := plan.GetAttribute(ctx, path.Root("update_strategy"), &strategy)
+	return strategy.ValueString() != "rolling", diags
+}
+
+// composeVariable matches the $$ escape and the ${VAR}, ${VAR:-default}, ${VAR-default} and $VAR placeholders of a compose file.
+var composeVariable = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(?::?-[^}]*)?\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// substituteVariables replaces the placeholders of the variables defined in environment with their values,
+// every other placeholder and escape is left for Container Station to resolve.
+func substituteVariables(yamlData string, environment map[string]string) string {
+	if len(environment) == 0 {
+		return yamlData
+	}
+	return composeVariable.ReplaceAllStringFunc(yamlData, func(match string) string {
+		groups := composeVariable.FindStringSubmatch(match)
+		name := groups[1]
+		if name == "" {
+			name = groups[2]
+		}
+		if value, ok := environment[name]; ok {
+			return value
+		}
+		return match
+	})
 }
 
 // Helper function to check if the error is due to the application not being found.
@@ -405,14 +1242,12 @@ func isAppNotFound(mess error) bool {
 	var status int
 	_, err := fmt.Sscanf(mess.Error(), "status: %d,", &status)
 	if err != nil {
-		fmt.Println("Error parsing status:", err)
 		return false
 	}
 
 	// Step 2: Extract the JSON part from the input string
 	start := strings.Index(mess.Error(), "body: {")
 	if start == -1 {
-		fmt.Println("Error: Could not find the body")
 		return false
 	}
 	jsonStr := mess.Error()[start+6:]
@@ -426,14 +1261,9 @@ func isAppNotFound(mess error) bool {
 	var resp Response
 	err = json.Unmarshal([]byte(jsonStr), &resp)
 	if err != nil {
-		fmt.Println("Error unmarshaling JSON:", err)
 		return false
 	}
 
-	// Output the extracted values
-	fmt.Println("Status:", status)
-	fmt.Println("Code:", resp.Code)
-	fmt.Println("Message:", resp.Message)
 	if status == 404 && resp.Code == 1009 && resp.Message == "cannot find compose" {
 		return true
 	}
@@ -451,10 +1281,16 @@ func ReadState(ctx context.Context, state tfsdk.Plan) (qnap.NewAppReqModel, diag
 		return qnap.NewAppReqModel{}, diagnostics
 	}
 
+	environment, diags := environmentOf(ctx, plan)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return qnap.NewAppReqModel{}, diagnostics
+	}
+
 	// Validate and convert YAML to JSON
-	jsonString, err := validateYAML(plan.Yml.ValueString())
+	jsonString, err := validateYAML(substituteVariables(plan.Yml.ValueString(), environment))
 	if err != nil {
-		diagnostics.AddError("error validating and converting YAML to string", err.Error())
+		diagnostics.AddError("Invalid Application YAML", errorDetail("Could not validate and convert the YAML of the application", err))
 		return qnap.NewAppReqModel{}, diagnostics
 	}
 
@@ -487,48 +1323,49 @@ func ReadState(ctx context.Context, state tfsdk.Plan) (qnap.NewAppReqModel, diag
 	return newApp, diagnostics
 }
 
+// limitValue returns the value of a limit reported by the NAS, no limit is kept null when the limit is not set.
+func limitValue(prior types.Int32, current int32) types.Int32 {
+	if prior.IsNull() && current == 0 {
+		return prior
+	}
+	return types.Int32Value(current)
+}
+
 // Helper function to compare the old state with the current state and generate a final state.
-func GetCurrentState(ctx context.Context, priorState *AppSpecModel, currentState *qnap.AppRespModel) (*AppSpecModel, diag.Diagnostics) {
+func GetCurrentState(ctx context.Context, priorState *AppSpecModel, currentState *qnap.AppRespModel, containers map[string]*containerDetails) (*AppSpecModel, diag.Diagnostics) {
 
 	// Map response attributes to priorState attributes
 	var newState *AppSpecModel = &AppSpecModel{}
-	var priorStateCompose, currentStateCompose *ComposeFile
+	var priorStateCompose, currentStateCompose interface{}
 	var diagnostics diag.Diagnostics
 
-	err := yaml.Unmarshal([]byte(priorState.Yml.ValueString()), &priorStateCompose)
+	// The NAS returns the YAML with the environment values substituted
+	environment, diags := environmentOf(ctx, priorState)
+	if diags.HasError() {
+		return nil, diags
+	}
+	newState.Environment = priorState.Environment
+
+	err := yaml.Unmarshal([]byte(substituteVariables(priorState.Yml.ValueString(), environment)), &priorStateCompose)
 	if err != nil {
-		diagnostics.AddError("invalid YAML from priorState", err.Error())
+		diagnostics.AddError("Invalid Application YAML", errorDetail("Could not parse the YAML of the application in state", err))
 		return nil, diagnostics
 	}
 	err = yaml.Unmarshal([]byte(currentState.Data.Yml), &currentStateCompose)
 	if err != nil {
-		diagnostics.AddError("invalid YAML from QNAP", err.Error())
+		diagnostics.AddError("Invalid Application YAML", errorDetail("Could not parse the YAML of the application on the NAS", err))
 		return nil, diagnostics
 	}
-	// Check if the compose files are equal - Usually does not change.
-	if cmp.Equal(&currentStateCompose, &priorStateCompose) {
+	// Check if the compose files are equal regardless of formatting - Usually does not change.
+	if cmp.Equal(currentStateCompose, priorStateCompose) {
 		newState.Yml = priorState.Yml
 	} else {
 		newState.Yml = types.StringValue(currentState.Data.Yml)
 	}
-	// Check if the CPU limit is equal
-	if priorState.CPULimit.Equal(types.Int32Value(currentState.Data.CPULimit)) {
-		newState.CPULimit = priorState.CPULimit
-	} else {
-		newState.CPULimit = types.Int32Value(currentState.Data.CPULimit)
-	}
-	// Check if the Mem limit is equal
-	if priorState.MemLimit.Equal(types.Int32Value(currentState.Data.MemLimit)) {
-		newState.MemLimit = priorState.MemLimit
-	} else {
-		newState.MemLimit = types.Int32Value(currentState.Data.MemLimit)
-	}
-	// Check if the Mem reservation is equal
-	if priorState.MemReservation.Equal(types.Int32Value(currentState.Data.MemReservation)) {
-		newState.MemReservation = priorState.MemReservation
-	} else {
-		newState.MemReservation = types.Int32Value(currentState.Data.MemReservation)
-	}
+	// The limits set on the NAS are reported as they are so that changes made in the UI show as drift
+	newState.CPULimit = limitValue(priorState.CPULimit, currentState.Data.CPULimit)
+	newState.MemLimit = limitValue(priorState.MemLimit, currentState.Data.MemLimit)
+	newState.MemReservation = limitValue(priorState.MemReservation, currentState.Data.MemReservation)
 	// Check if the status is equal
 	if priorState.Status.Equal(types.StringValue(currentState.Data.Status)) {
 		newState.Status = priorState.Status
@@ -540,14 +1377,34 @@ func GetCurrentState(ctx context.Context, priorState *AppSpecModel, currentState
 	var containerListElements []attr.Value
 	// Define the types for each attribute in the map
 	containerAttrTypes := map[string]attr.Type{
-		"name": types.StringType,
-		"id":   types.StringType,
+		"name":   types.StringType,
+		"id":     types.StringType,
+		"status": types.StringType,
+		"image":  types.StringType,
+		"health": types.StringType,
+		"ports":  types.ListType{ElemType: types.StringType},
 	}
 	for _, container := range currentState.Data.Containers {
+		details := containers[container.ID]
+		var status, image, health string
+		if details != nil {
+			status = details.Data.Status
+			image = details.Data.Image
+			health = details.Data.DockerStatus.Health
+		}
+		ports, diags := types.ListValueFrom(ctx, types.StringType, containerPorts(details))
+		if diags.HasError() {
+			return nil, diags
+		}
+
 		// Map the attributes' values
 		containerMap := map[string]attr.Value{
-			"name": types.StringValue(container.Name),
-			"id":   types.StringValue(container.ID),
+			"name":   types.StringValue(container.Name),
+			"id":     types.StringValue(container.ID),
+			"status": types.StringValue(status),
+			"image":  types.StringValue(image),
+			"health": types.StringValue(health),
+			"ports":  ports,
 		}
 
 		containerObject, diags := types.ObjectValue(containerAttrTypes, containerMap)
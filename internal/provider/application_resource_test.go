@@ -1,9 +1,11 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccApplicationResource(t *testing.T) {
@@ -18,6 +20,7 @@ func TestAccApplicationResource(t *testing.T) {
 					status            = "running"
 					name              = "terraform_test_full_coverage_2"
 					removeanonvolumes = true
+					remove_image_on_destroy = true
 					yml               = "version: '3'\nservices:\n  postgres:\n    image: postgres:15.1\n    restart: always\n    ports:\n      - 127.0.0.1:5432:5432\n    volumes:\n      - postgres_db:/var/lib/postgresql/data\n    environment:\n      POSTGRES_USER: postgres_qnap_user\n      POSTGRES_PASSWORD: postgres_qnap_pwd\n\n  phppgadmin:\n    image: qnapsystem/phppgadmin:7.13.0-1\n    restart: on-failure\n    ports:\n      - 7070:80\n    depends_on:\n      - postgres\n    environment:\n      PHP_PG_ADMIN_SERVER_HOST: postgres\n      PHP_PG_ADMIN_SERVER_PORT: 5432\n\nvolumes:\n  postgres_db:\n"
 					}
 
@@ -26,7 +29,313 @@ func TestAccApplicationResource(t *testing.T) {
 					resource.TestCheckResourceAttr("qnap_app.full_coverage", "name", "terraform_test_full_coverage_2"),
 					resource.TestCheckResourceAttr("qnap_app.full_coverage", "status", "running"),
 					resource.TestCheckResourceAttr("qnap_app.full_coverage", "removeanonvolumes", "true"),
+					resource.TestCheckResourceAttr("qnap_app.full_coverage", "remove_image_on_destroy", "true"),
+					resource.TestMatchResourceAttr("qnap_app.full_coverage", "rendered_spec", regexp.MustCompile(`"name": "terraform_test_full_coverage_2"`)),
 					resource.TestCheckResourceAttr("qnap_app.full_coverage", "containers.#", "2"),
+					resource.TestCheckResourceAttr("qnap_app.full_coverage", "containers.0.status", "running"),
+					resource.TestCheckResourceAttrSet("qnap_app.full_coverage", "containers.0.image"),
+					resource.TestMatchResourceAttr("qnap_app.full_coverage", "service_urls.phppgadmin", regexp.MustCompile(`^http://.+:7070$`)),
+					resource.TestCheckNoResourceAttr("qnap_app.full_coverage", "service_urls.postgres"),
+				),
+			},
+			// test case 1 - stop in place
+			{
+				Config: `
+					resource "qnap_app" "full_coverage" {
+					status            = "stopped"
+					name              = "terraform_test_full_coverage_2"
+					removeanonvolumes = true
+					remove_image_on_destroy = true
+					yml               = "version: '3'\nservices:\n  postgres:\n    image: postgres:15.1\n    restart: always\n    ports:\n      - 127.0.0.1:5432:5432\n    volumes:\n      - postgres_db:/var/lib/postgresql/data\n    environment:\n      POSTGRES_USER: postgres_qnap_user\n      POSTGRES_PASSWORD: postgres_qnap_pwd\n\n  phppgadmin:\n    image: qnapsystem/phppgadmin:7.13.0-1\n    restart: on-failure\n    ports:\n      - 7070:80\n    depends_on:\n      - postgres\n    environment:\n      PHP_PG_ADMIN_SERVER_HOST: postgres\n      PHP_PG_ADMIN_SERVER_PORT: 5432\n\nvolumes:\n  postgres_db:\n"
+					}
+
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_app.full_coverage", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_app.full_coverage", "status", "stopped"),
+					resource.TestCheckResourceAttr("qnap_app.full_coverage", "containers.0.status", "exited"),
+				),
+			},
+			// test case 2 - compose keys beyond image, ports and environment are kept
+			{
+				Config: `
+					resource "qnap_app" "full_schema" {
+					status            = "running"
+					name              = "terraform_test_full_schema"
+					removeanonvolumes = true
+					pull_images       = "always"
+					scale = {
+						web = 2
+					}
+					yml               = <<-EOT
+						version: '3.8'
+						services:
+						  web:
+						    image: nginx:1.26.2
+						    entrypoint: ["/docker-entrypoint.sh"]
+						    command: ["nginx", "-g", "daemon off;"]
+						    cap_add:
+						      - NET_ADMIN
+						    environment:
+						      - NGINX_ENTRYPOINT_QUIET_LOGS=1
+						    healthcheck:
+						      test: ["CMD", "curl", "-f", "http://localhost"]
+						      interval: 30s
+						    logging:
+						      driver: json-file
+						      options:
+						        max-size: 10m
+						    deploy:
+						      resources:
+						        limits:
+						          memory: 256M
+					EOT
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_app.full_schema", "containers.#", "2"),
+					resource.TestCheckResourceAttr("qnap_app.full_schema", "scale.web", "2"),
+					resource.TestCheckResourceAttr("qnap_app.full_schema", "pull_images", "always"),
+					resource.TestMatchResourceAttr("qnap_app.full_schema", "yml", regexp.MustCompile(`healthcheck:`)),
+					resource.TestMatchResourceAttr("qnap_app.full_schema", "yml", regexp.MustCompile(`NGINX_ENTRYPOINT_QUIET_LOGS=1`)),
+				),
+			},
+			// test case 3 - environment values are substituted into the YAML
+			{
+				Config: `
+					resource "qnap_app" "environment" {
+					status            = "running"
+					name              = "terraform_test_environment"
+					removeanonvolumes = true
+					wait_for_containers = true
+					wait_timeout        = "3m"
+					environment = {
+						POSTGRES_TAG      = "15.1"
+						POSTGRES_PASSWORD = "postgres_qnap_pwd"
+					}
+					yml               = <<-EOT
+						version: '3'
+						services:
+						  postgres:
+						    image: postgres:$${POSTGRES_TAG}
+						    environment:
+						      POSTGRES_USER: $${POSTGRES_USER:-postgres}
+						      POSTGRES_PASSWORD: $${POSTGRES_PASSWORD}
+					EOT
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_app.environment", "containers.0.image", "postgres:15.1"),
+					resource.TestCheckResourceAttr("qnap_app.environment", "containers.0.status", "running"),
+					resource.TestMatchResourceAttr("qnap_app.environment", "yml", regexp.MustCompile(`\$\{POSTGRES_PASSWORD\}`)),
+				),
+			},
+			// test case 3 - rolling update of the changed service
+			{
+				Config: `
+					resource "qnap_app" "environment" {
+					status            = "running"
+					name              = "terraform_test_environment"
+					removeanonvolumes = true
+					update_strategy   = "rolling"
+					wait_for_containers = true
+					wait_timeout        = "3m"
+					environment = {
+						POSTGRES_TAG      = "15.2"
+						POSTGRES_PASSWORD = "postgres_qnap_pwd"
+					}
+					yml               = <<-EOT
+						version: '3'
+						services:
+						  postgres:
+						    image: postgres:$${POSTGRES_TAG}
+						    environment:
+						      POSTGRES_USER: $${POSTGRES_USER:-postgres}
+						      POSTGRES_PASSWORD: $${POSTGRES_PASSWORD}
+					EOT
+					}
+
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_app.environment", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_app.environment", "containers.0.image", "postgres:15.2"),
+					resource.TestCheckResourceAttr("qnap_app.environment", "update_strategy", "rolling"),
+				),
+			},
+			// test case 3 - limits are updated in place
+			{
+				Config: `
+					resource "qnap_app" "environment" {
+					status            = "running"
+					name              = "terraform_test_environment"
+					removeanonvolumes = true
+					update_strategy   = "rolling"
+					cpu_limit         = 1
+					mem_limit         = 512
+					environment = {
+						POSTGRES_TAG      = "15.2"
+						POSTGRES_PASSWORD = "postgres_qnap_pwd"
+					}
+					yml               = <<-EOT
+						version: '3'
+						services:
+						  postgres:
+						    image: postgres:$${POSTGRES_TAG}
+						    environment:
+						      POSTGRES_USER: $${POSTGRES_USER:-postgres}
+						      POSTGRES_PASSWORD: $${POSTGRES_PASSWORD}
+					EOT
+					}
+
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_app.environment", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_app.environment", "cpu_limit", "1"),
+					resource.TestCheckResourceAttr("qnap_app.environment", "mem_limit", "512"),
+					resource.TestCheckNoResourceAttr("qnap_app.environment", "mem_reservation"),
+				),
+			},
+			// test case 4 - unsupported compose file version
+			{
+				Config: `
+					resource "qnap_app" "unsupported" {
+					status            = "running"
+					name              = "terraform_test_unsupported"
+					removeanonvolumes = true
+					yml               = "version: '4.0'\nservices:\n  web:\n    image: nginx:latest\n"
+					}
+
+				`,
+				ExpectError: regexp.MustCompile("Unsupported compose features"),
+			},
+			// test case 4 - unknown service to scale
+			{
+				Config: `
+					resource "qnap_app" "unsupported" {
+					status            = "running"
+					name              = "terraform_test_unsupported"
+					removeanonvolumes = true
+					scale = {
+						api = 2
+					}
+					yml               = "version: '3'\nservices:\n  web:\n    image: nginx:latest\n"
+					}
+
+				`,
+				ExpectError: regexp.MustCompile("Unknown service"),
+			},
+			// test case 5 - missing external network
+			{
+				Config: `
+					resource "qnap_app" "external" {
+					status            = "running"
+					name              = "terraform_test_external"
+					removeanonvolumes = true
+					yml               = "version: '3'\nservices:\n  web:\n    image: nginx:latest\n    networks:\n      - shared\nnetworks:\n  shared:\n    external: true\n    name: terraform_test_missing_network\n"
+					}
+
+				`,
+				ExpectError: regexp.MustCompile("Missing external network"),
+			},
+			// test case 6 - protected application is not destroyed
+			{
+				Config: `
+					resource "qnap_app" "protected" {
+					status              = "running"
+					name                = "terraform_test_protected"
+					removeanonvolumes   = true
+					deletion_protection = true
+					yml                 = "version: '3'\nservices:\n  web:\n    image: nginx:latest\n"
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_app.protected", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config: `
+					resource "qnap_app" "protected" {
+					status              = "running"
+					name                = "terraform_test_protected"
+					removeanonvolumes   = true
+					deletion_protection = true
+					yml                 = "version: '3'\nservices:\n  web:\n    image: nginx:latest\n"
+					}
+
+				`,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("Deletion Protection Enabled"),
+			},
+			// test case 7 - application is destroyed once the protection is disabled
+			{
+				Config: `
+					resource "qnap_app" "protected" {
+					status              = "running"
+					name                = "terraform_test_protected"
+					removeanonvolumes   = true
+					deletion_protection = false
+					yml                 = "version: '3'\nservices:\n  web:\n    image: nginx:latest\n"
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_app.protected", "deletion_protection", "false"),
+				),
+			},
+			// test case 8 - changed redeploy trigger brings up the application again in place
+			{
+				Config: `
+					resource "qnap_app" "redeployed" {
+					status            = "running"
+					name              = "terraform_test_redeployed"
+					removeanonvolumes = true
+					yml               = "version: '3'\nservices:\n  web:\n    image: nginx:latest\n"
+					redeploy_triggers = {
+						config = "1"
+					}
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_app.redeployed", "redeploy_triggers.config", "1"),
+				),
+			},
+			{
+				Config: `
+					resource "qnap_app" "redeployed" {
+					status            = "running"
+					name              = "terraform_test_redeployed"
+					removeanonvolumes = true
+					yml               = "version: '3'\nservices:\n  web:\n    image: nginx:latest\n"
+					redeploy_triggers = {
+						config = "2"
+					}
+					}
+
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_app.redeployed", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_app.redeployed", "redeploy_triggers.config", "2"),
+					resource.TestCheckResourceAttr("qnap_app.redeployed", "containers.0.status", "running"),
 				),
 			},
 		},
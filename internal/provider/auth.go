@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // TOTP codes are computed with HMAC-SHA1 (RFC 6238).
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// clientCredentials are the credentials the provider signs in to the NAS with.
+type clientCredentials struct {
+	username     string
+	password     string
+	totpSecret   string
+	securityCode string
+	accessToken  string
+}
+
+// accessTokenCookie is the name of the session cookie an access token is sent in, the token is also sent as a bearer
+// token by the client library and apiRequest.
+const accessTokenCookie = "NAS_SID"
+
+// useAccessToken authenticates the client with an access token instead of a login and checks that the NAS accepts it.
+func useAccessToken(ctx context.Context, client *qnap.Client, accessToken string) error {
+	client.Token = accessTokenCookie + "=" + accessToken
+	_, err := getSystemInfo(ctx, client)
+	if err != nil {
+		return fmt.Errorf("the NAS rejected the access token: %w", err)
+	}
+	return nil
+}
+
+// loginRequest is the payload of the Container Station login endpoint, the security code is only sent in the second
+// step of the login of accounts with two-step verification.
+type loginRequest struct {
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+	SecurityCode string `json:"security_code,omitempty"`
+}
+
+// loginResponse is the answer of the Container Station login endpoint, the session token is in a cookie.
+type loginResponse struct {
+	Username string `json:"username"`
+	Need2SV  bool   `json:"need_2sv"`
+}
+
+// signIn signs in to the NAS and stores the session token in the client. Accounts with two-step verification are
+// asked for a security code after the password, which is computed from the TOTP secret right before it is sent.
+func signIn(ctx context.Context, client *qnap.Client, credentials clientCredentials) error {
+	login := loginRequest{
+		Username: credentials.username,
+		Password: credentials.password,
+	}
+	token, response, err := postLogin(ctx, client, login)
+	if err != nil {
+		return err
+	}
+
+	if response.Need2SV {
+		switch {
+		case credentials.totpSecret != "":
+			login.SecurityCode, err = totpCode(credentials.totpSecret, time.Now())
+			if err != nil {
+				return err
+			}
+		case credentials.securityCode != "":
+			login.SecurityCode = credentials.securityCode
+		default:
+			return errors.New("the account requires two-step verification, set totp_secret or security_code")
+		}
+
+		token, _, err = postLogin(ctx, client, login)
+		if err != nil {
+			return err
+		}
+	}
+
+	if token == "" {
+		return errors.New("the NAS did not return a session token")
+	}
+	client.Auth = qnap.AuthStruct{
+		Username: credentials.username,
+		Password: credentials.password,
+	}
+	client.Token = token
+	return nil
+}
+
+// postLogin sends a login request and returns the session token, empty when the login needs a second step.
+func postLogin(ctx context.Context, client *qnap.Client, login loginRequest) (string, *loginResponse, error) {
+	body, err := json.Marshal(login)
+	if err != nil {
+		return "", nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.HostURL+"/container-station/api/v1/login", bytes.NewReader(body))
+	if err != nil {
+		return "", nil, err
+	}
+
+	res, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return "", nil, err
+	}
+	defer res.Body.Close()
+
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, respBody)
+	}
+
+	var response loginResponse
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, &response); err != nil {
+			return "", nil, err
+		}
+	}
+	if response.Need2SV {
+		return "", &response, nil
+	}
+	return strings.Split(res.Header.Get("Set-Cookie"), ";")[0], &response, nil
+}
+
+// totpCode computes the 6 digit time-based one-time password (RFC 6238) of a base32 encoded secret.
+func totpCode(secret string, now time.Time) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(now.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", code%1000000), nil
+}
+
+// decodeTOTPSecret decodes a base32 TOTP secret as shown by authenticator apps, with or without spaces and padding.
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil || len(key) == 0 {
+		return nil, errors.New("the TOTP secret is not a base32 encoded key")
+	}
+	return key, nil
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// defaultAutoUpdateInterval is used when the auto_update of a container does not set its interval.
+const defaultAutoUpdateInterval = 24 * time.Hour
+
+// autoUpdateModel is the auto_update attribute of a container, the image auto-update of Container Station.
+type autoUpdateModel struct {
+	Interval    types.String `tfsdk:"interval"`
+	WindowStart types.String `tfsdk:"window_start"`
+	WindowEnd   types.String `tfsdk:"window_end"`
+}
+
+// autoUpdateAttribute returns the schema of the auto_update attribute.
+func autoUpdateAttribute() schema.SingleNestedAttribute {
+	clock := stringvalidator.RegexMatches(regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`), "Must be a time of day as HH:MM (e.g. '02:00').")
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "Enables the image auto-update of Container Station for the container: the NAS checks the registry for a new image of the tag and recreates the container with it, replacing a separately managed watchtower container. The recreated container gets a new ID, set adopt_recreated so Terraform adopts it instead of replacing it.",
+		Attributes: map[string]schema.Attribute{
+			"interval": schema.StringAttribute{
+				Optional:    true,
+				Description: "The duration between two checks for a new image (e.g. '6h', '24h'). Defaults to 24h.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^([0-9]+(h|m))+$`), "Must be a duration of hours and minutes (e.g. '30m', '6h')."),
+				},
+			},
+			"window_start": schema.StringAttribute{
+				Optional:    true,
+				Description: "The time of day, in the time zone of the NAS, from which the container may be updated (e.g. '02:00'). Defaults to any time.",
+				Validators: []validator.String{
+					clock,
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("window_end")),
+				},
+			},
+			"window_end": schema.StringAttribute{
+				Optional:    true,
+				Description: "The time of day, in the time zone of the NAS, until which the container may be updated (e.g. '05:00'). A window_end before window_start spans midnight.",
+				Validators: []validator.String{
+					clock,
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("window_start")),
+				},
+			},
+		},
+	}
+}
+
+// containerAutoUpdateSpec is the payload and response of the Container Station container auto-update endpoint. The
+// interval is in seconds.
+type containerAutoUpdateSpec struct {
+	Enabled     bool   `json:"enabled"`
+	Interval    int64  `json:"interval,omitempty"`
+	WindowStart string `json:"windowStart,omitempty"`
+	WindowEnd   string `json:"windowEnd,omitempty"`
+}
+
+// spec returns the auto-update payload of the auto_update of a container, disabled when it is not set.
+func (m *autoUpdateModel) spec() (containerAutoUpdateSpec, error) {
+	if m == nil {
+		return containerAutoUpdateSpec{}, nil
+	}
+	interval := defaultAutoUpdateInterval
+	if !m.Interval.IsNull() {
+		var err error
+		if interval, err = time.ParseDuration(m.Interval.ValueString()); err != nil {
+			return containerAutoUpdateSpec{}, err
+		}
+	}
+	return containerAutoUpdateSpec{
+		Enabled:     true,
+		Interval:    int64(interval / time.Second),
+		WindowStart: m.WindowStart.ValueString(),
+		WindowEnd:   m.WindowEnd.ValueString(),
+	}, nil
+}
+
+// autoUpdateState returns the auto_update of a container from its auto-update settings, nil when it is disabled. The
+// values of the prior auto_update are kept when the NAS returns the same settings so equal durations written
+// differently and defaults left unset do not show as changes.
+func autoUpdateState(settings *containerAutoUpdateSpec, prior *autoUpdateModel) *autoUpdateModel {
+	if settings == nil || !settings.Enabled {
+		return nil
+	}
+	interval := time.Duration(settings.Interval) * time.Second
+	state := &autoUpdateModel{
+		Interval:    types.StringValue(formatAutoUpdateInterval(interval)),
+		WindowStart: types.StringNull(),
+		WindowEnd:   types.StringNull(),
+	}
+	if settings.WindowStart != "" || settings.WindowEnd != "" {
+		state.WindowStart = types.StringValue(settings.WindowStart)
+		state.WindowEnd = types.StringValue(settings.WindowEnd)
+	}
+	if prior != nil {
+		priorInterval, err := time.ParseDuration(prior.Interval.ValueString())
+		if prior.Interval.IsNull() {
+			priorInterval, err = defaultAutoUpdateInterval, nil
+		}
+		if err == nil && priorInterval == interval {
+			state.Interval = prior.Interval
+		}
+	}
+	return state
+}
+
+// formatAutoUpdateInterval returns an interval without its zero minutes and seconds (e.g. '24h', '1h30m').
+func formatAutoUpdateInterval(interval time.Duration) string {
+	formatted := strings.TrimSuffix(interval.String(), "0s")
+	if strings.HasSuffix(formatted, "h0m") {
+		formatted = strings.TrimSuffix(formatted, "0m")
+	}
+	return formatted
+}
+
+// autoUpdateEqual reports whether two auto_update attributes configure the same auto-update.
+func autoUpdateEqual(a *autoUpdateModel, b *autoUpdateModel) bool {
+	aSpec, aErr := a.spec()
+	bSpec, bErr := b.spec()
+	return aErr == nil && bErr == nil && aSpec == bSpec
+}
+
+// getContainerAutoUpdate returns the auto-update settings of a container.
+func getContainerAutoUpdate(ctx context.Context, client *qnap.Client, containerID string, containerType string) (*containerAutoUpdateSpec, error) {
+	var response struct {
+		Data containerAutoUpdateSpec `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, fmt.Sprintf("/container-station/api/v3/containers/%s/%s/auto-update", containerType, containerID), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// setContainerAutoUpdate changes the auto-update settings of a container.
+func setContainerAutoUpdate(ctx context.Context, client *qnap.Client, containerID string, containerType string, settings containerAutoUpdateSpec) error {
+	return apiRequest(ctx, client, http.MethodPut, fmt.Sprintf("/container-station/api/v3/containers/%s/%s/auto-update", containerType, containerID), settings, nil)
+}
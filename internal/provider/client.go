@@ -0,0 +1,318 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// transportOptions are the proxy, TLS settings, timeout, retry policy, rate limit and concurrency limit of the
+// connection to the NAS, and the context to trace the requests with when tracing is enabled.
+type transportOptions struct {
+	proxyURL       *url.URL
+	insecure       bool
+	caCertPEM      string
+	requestTimeout time.Duration
+	maxRetries     int
+	retryBackoff   time.Duration
+	rateLimit      float64
+	rateBurst      int
+	parallelism    int
+	trace          context.Context
+}
+
+// Defaults of the timeout and retry policy of the provider.
+const (
+	defaultRequestTimeout = 10 * time.Second
+	defaultMaxRetries     = 3
+	defaultRetryBackoff   = time.Second
+)
+
+// clientTimeout is the maximum duration of a call of the qnap client, every attempt plus the backoff between them.
+func (o transportOptions) clientTimeout() time.Duration {
+	timeout := o.requestTimeout
+	for attempt := 0; attempt < o.maxRetries; attempt++ {
+		timeout += o.requestTimeout + o.retryBackoff<<attempt
+	}
+	return timeout
+}
+
+// hostURL builds the URL of the NAS from the host and the optional scheme and port, which may also be part of the host.
+// The scheme defaults to https, the port to the default port of the scheme.
+func hostURL(host string, scheme string, port int) (string, error) {
+	raw := host
+	// A bare IPv6 address is bracketed, its last group would be parsed as the port otherwise
+	if ip := net.ParseIP(raw); ip != nil && ip.To4() == nil {
+		raw = "[" + raw + "]"
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "//" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return "", fmt.Errorf("%q is not a valid host name or URL", host)
+	}
+
+	if scheme != "" {
+		if u.Scheme != "" && u.Scheme != scheme {
+			return "", fmt.Errorf("the host uses the %s scheme but the scheme is %s", u.Scheme, scheme)
+		}
+		u.Scheme = scheme
+	}
+	if u.Scheme == "" {
+		u.Scheme = "https"
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("the %s scheme is not supported, use http or https", u.Scheme)
+	}
+
+	if port != 0 {
+		if u.Port() != "" && u.Port() != strconv.Itoa(port) {
+			return "", fmt.Errorf("the host uses port %s but the port is %d", u.Port(), port)
+		}
+		u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(port))
+	}
+	return strings.TrimSuffix(u.String(), "/"), nil
+}
+
+// parseProxyURL parses the URL of an HTTP, HTTPS or SOCKS5 proxy, SOCKS5 proxies resolve the host of the NAS.
+func parseProxyURL(proxy string) (*url.URL, error) {
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("%q is not a valid proxy URL", proxy)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return u, nil
+	}
+	return nil, fmt.Errorf("the %s proxy scheme is not supported, use http, https or socks5", u.Scheme)
+}
+
+// newTransport returns the HTTP transport of the qnap client with the proxy, TLS settings, retry policy, rate limit and
+// concurrency limit of the provider. Without a proxy URL the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables are honored. A custom
+// CA is trusted in addition to the system CAs.
+func newTransport(options transportOptions) (http.RoundTripper, error) {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = defaultTransport.Clone()
+	}
+	if options.proxyURL != nil {
+		transport.Proxy = http.ProxyURL(options.proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: options.insecure,
+	}
+	if options.caCertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(options.caCertPEM)) {
+			return nil, errors.New("no PEM encoded certificate found in the CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport.TLSClientConfig = tlsConfig
+	transport.ResponseHeaderTimeout = options.requestTimeout
+
+	var roundTripper http.RoundTripper = transport
+	if options.trace != nil {
+		roundTripper = &traceTransport{next: roundTripper, ctx: options.trace}
+	}
+	if options.parallelism > 0 {
+		roundTripper = &concurrencyTransport{next: roundTripper, slots: make(chan struct{}, options.parallelism)}
+	}
+	if options.rateLimit > 0 {
+		roundTripper = newRateLimitTransport(roundTripper, options.rateLimit, options.rateBurst)
+	}
+	if options.maxRetries > 0 {
+		roundTripper = &retryTransport{next: roundTripper, maxRetries: options.maxRetries, backoff: options.retryBackoff}
+	}
+	return roundTripper, nil
+}
+
+// rateLimitTransport delays the requests so that no more than rate requests per second are sent on average, with bursts
+// of up to burst requests. The provider shares a single client between all resources, so the limit applies to the whole run.
+type rateLimitTransport struct {
+	next  http.RoundTripper
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimitTransport returns a transport that limits the rate of the requests sent through next.
+func newRateLimitTransport(next http.RoundTripper, rate float64, burst int) *rateLimitTransport {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimitTransport{
+		next:   next,
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns how long to wait until it is available.
+func (t *rateLimitTransport) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.tokens = min(t.burst, t.tokens+now.Sub(t.last).Seconds()*t.rate)
+	t.last = now
+	t.tokens--
+	if t.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-t.tokens / t.rate * float64(time.Second))
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if wait := t.reserve(); wait > 0 {
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+	return t.next.RoundTrip(req)
+}
+
+// concurrencyTransport limits the number of requests in flight, as the web server of the NAS fails under many concurrent
+// requests. A request holds its slot until its response body is closed.
+type concurrencyTransport struct {
+	next  http.RoundTripper
+	slots chan struct{}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case t.slots <- struct{}{}:
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		<-t.slots
+		return nil, err
+	}
+	res.Body = &releaseOnClose{ReadCloser: res.Body, release: func() { <-t.slots }}
+	return res, nil
+}
+
+// releaseOnClose is a response body that calls release once when it is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+// Close implements io.Closer.
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// retryStatusCodes are the transient errors of the web server of the NAS, for example while the Container Station
+// backend restarts.
+var retryStatusCodes = map[int]bool{
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// retryTransport retries the requests that fail with a transient error, waiting twice as long before each retry.
+// Transient errors and connection errors are only retried for requests that do not change anything on the NAS, as
+// the NAS or a reverse proxy in front of it may have processed a request whose response was lost. Requests that
+// change the NAS are only retried on a 503 with a Retry-After header, which tells the request was not processed.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	backoff    time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		res, err := t.next.RoundTrip(req)
+		readOnly := req.Method == http.MethodGet || req.Method == http.MethodHead
+		retry := readOnly
+		if err == nil {
+			retry = (readOnly && retryStatusCodes[res.StatusCode]) ||
+				(res.StatusCode == http.StatusServiceUnavailable && res.Header.Get("Retry-After") != "")
+		}
+		if !retry || attempt >= t.maxRetries || (req.Body != nil && req.GetBody == nil) {
+			return res, err
+		}
+
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = res.Status
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		wait := t.backoff << attempt
+		tflog.Debug(req.Context(), "Retrying QNAP API request", map[string]interface{}{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"reason":  reason,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		})
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// newClient creates a qnap client that sends its requests to the NAS through the session transport, which signs in on
+// the first request. The token of the client is a placeholder the session transport replaces with the session.
+func newClient(host string, session *sessionTransport, timeout time.Duration) *qnap.Client {
+	return &qnap.Client{
+		HostURL: host,
+		HTTPClient: &http.Client{
+			Transport: session,
+			Timeout:   timeout,
+		},
+		Token: sessionPlaceholder,
+	}
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &containerStatusAction{}
+	_ action.ActionWithConfigure = &containerStatusAction{}
+)
+
+// The operations of the container actions.
+const (
+	containerActionRestart = "restart"
+	containerActionStart   = "start"
+	containerActionStop    = "stop"
+)
+
+// containerStatusAction is the implementation of the actions that restart, start and stop a container without
+// changing the qnap_container resource managing it.
+type containerStatusAction struct {
+	client    *qnap.Client
+	timeouts  operationTimeouts
+	operation string
+}
+
+// NewContainerRestartAction is a helper function to simplify the provider implementation.
+func NewContainerRestartAction() action.Action {
+	return &containerStatusAction{operation: containerActionRestart}
+}
+
+// NewContainerStartAction is a helper function to simplify the provider implementation.
+func NewContainerStartAction() action.Action {
+	return &containerStatusAction{operation: containerActionStart}
+}
+
+// NewContainerStopAction is a helper function to simplify the provider implementation.
+func NewContainerStopAction() action.Action {
+	return &containerStatusAction{operation: containerActionStop}
+}
+
+// Metadata returns the action type name.
+func (a *containerStatusAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_" + a.operation
+}
+
+// Schema defines the schema for the action.
+func (a *containerStatusAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	descriptions := map[string]string{
+		containerActionRestart: "Restarts a container, for example after new configuration files are pushed into one of its bind mounts. A stopped container is started. The qnap_container resource of the container is neither changed nor tainted.",
+		containerActionStart:   "Starts a stopped or paused container. The qnap_container resource of the container is neither changed nor tainted, its next refresh reads the new status.",
+		containerActionStop:    "Stops a running container gracefully, its processes are killed when they do not exit within the grace period. The qnap_container resource of the container is neither changed nor tainted, its next refresh reads the new status.",
+	}
+
+	attributes := map[string]schema.Attribute{
+		"container_id": schema.StringAttribute{
+			Required:    true,
+			Description: "The ID of the container (e.g. the id of qnap_container).",
+		},
+		"container_type": schema.StringAttribute{
+			Optional:    true,
+			Description: "The type of the container (docker, lxd). Defaults to docker.",
+			Validators: []validator.String{
+				stringvalidator.OneOf("docker", "lxd"),
+			},
+		},
+	}
+	if a.operation == containerActionStop {
+		attributes["stop_grace_period"] = schema.StringAttribute{
+			Optional:    true,
+			Description: "How long to wait for the container to exit before it is killed (e.g. '30s', '2m'). Defaults to the grace period of Container Station.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^([0-9]+(h|m|s))+$`), "Stop grace period must be a duration (e.g. '10s', '2m')."),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: descriptions[a.operation],
+		Attributes:  attributes,
+	}
+}
+
+// Configure adds the provider configured client to the action.
+func (a *containerStatusAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	a.client = data.client
+	a.timeouts = data.timeouts
+}
+
+// Invoke restarts, starts or stops the container.
+func (a *containerStatusAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	ctx, cancel := withTimeout(ctx, a.timeouts.Update)
+	defer cancel()
+
+	var containerID, containerType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("container_id"), &containerID)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("container_type"), &containerType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	cType := containerType.ValueString()
+	if cType == "" {
+		cType = "docker"
+	}
+
+	container, err := inspectContainer(ctx, a.client, containerID.ValueString(), cType)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Container",
+			errorDetail("An error occurred while reading container "+containerID.ValueString(), err),
+		)
+		return
+	}
+	name := container.Data.Name
+
+	switch a.operation {
+	case containerActionRestart:
+		resp.SendProgress(action.InvokeProgressEvent{Message: "Restarting container " + name})
+		if container.Data.Status == qnap.ContainerStatusRunning {
+			err = restartContainer(ctx, a.client, container.Data.ID, cType)
+		} else {
+			err = changeContainerStatus(ctx, a.client, container.Data.ID, cType, container.Data.Status, qnap.ContainerStatusRunning, 0)
+		}
+	case containerActionStart:
+		resp.SendProgress(action.InvokeProgressEvent{Message: "Starting container " + name})
+		err = changeContainerStatus(ctx, a.client, container.Data.ID, cType, container.Data.Status, qnap.ContainerStatusRunning, 0)
+	case containerActionStop:
+		var gracePeriod types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("stop_grace_period"), &gracePeriod)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		var timeout time.Duration
+		if !gracePeriod.IsNull() {
+			timeout, err = time.ParseDuration(gracePeriod.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("stop_grace_period"), "Invalid Stop Grace Period", err.Error())
+				return
+			}
+		}
+		resp.SendProgress(action.InvokeProgressEvent{Message: "Stopping container " + name})
+		err = changeContainerStatus(ctx, a.client, container.Data.ID, cType, container.Data.Status, containerStatusStopped, timeout)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error changing container status",
+			errorDetail("Could not "+a.operation+" container "+name, err),
+		)
+	}
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccContainerActions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: `
+					resource "qnap_container" "actions" {
+					name              = "terraform_test_actions"
+					image             = "nginx:latest"
+					type              = "docker"
+					status            = "running"
+					removeanonvolumes = true
+					}
+
+					action "qnap_container_restart" "actions" {
+					config {
+						container_id = qnap_container.actions.id
+					}
+					}
+
+					resource "terraform_data" "restart" {
+					input = qnap_container.actions.id
+					lifecycle {
+						action_trigger {
+						events  = [after_create]
+						actions = [action.qnap_container_restart.actions]
+						}
+					}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.actions", "status", "running"),
+				),
+			},
+			// test case 2
+			{
+				Config: `
+					resource "qnap_container" "actions" {
+					name              = "terraform_test_actions"
+					image             = "nginx:latest"
+					type              = "docker"
+					status            = "running"
+					removeanonvolumes = true
+					}
+
+					action "qnap_container_stop" "actions" {
+					config {
+						container_id      = qnap_container.actions.id
+						stop_grace_period = "10s"
+					}
+					}
+
+					resource "terraform_data" "stop" {
+					input = qnap_container.actions.id
+					lifecycle {
+						action_trigger {
+						events  = [after_create]
+						actions = [action.qnap_container_stop.actions]
+						}
+					}
+					}
+				`,
+				// The stopped container no longer matches the running status of the resource.
+				ExpectNonEmptyPlan: true,
+			},
+			// test case 3
+			{
+				Config: `
+					resource "qnap_container" "actions" {
+					name              = "terraform_test_actions"
+					image             = "nginx:latest"
+					type              = "docker"
+					status            = "running"
+					removeanonvolumes = true
+					}
+
+					action "qnap_container_start" "actions" {
+					config {
+						container_id = qnap_container.actions.id
+					}
+					}
+
+					resource "terraform_data" "start" {
+					input = qnap_container.actions.id
+					lifecycle {
+						action_trigger {
+						events  = [after_create]
+						actions = [action.qnap_container_start.actions]
+						}
+					}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.actions", "status", "running"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,496 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// containerCreateSpec extends the client library container spec with the
+// fields Container Station accepts but qnap-client-lib does not model yet.
+type containerCreateSpec struct {
+	qnap.NewContainerSpec
+	Tmpfs          map[string]string `json:"tmpfs,omitempty"`
+	Project        string            `json:"project,omitempty"`
+	IPv6Address    string            `json:"ipv6Address,omitempty"`
+	NetworkAliases []string          `json:"networkAliases,omitempty"`
+	DNSSearch      []string          `json:"dnsSearch,omitempty"`
+	DNSOptions     []string          `json:"dnsOptions,omitempty"`
+	CPULimit       int32             `json:"cpuLimit,omitempty"`
+	MemLimit       int64             `json:"memLimit,omitempty"`
+	MemSwapLimit   int64             `json:"memSwapLimit,omitempty"`
+	MemSwappiness  *int64            `json:"memSwappiness,omitempty"`
+}
+
+// containerDetails is the inspect response of a container including the
+// fields that qnap-client-lib does not model yet.
+type containerDetails struct {
+	qnap.ContainerInfo
+	Extra containerDetailsExtra
+}
+
+type containerDetailsExtra struct {
+	Data struct {
+		Tmpfs          map[string]string       `json:"tmpfs"`
+		MemLimit       int64                   `json:"memLimit"`
+		MemReservation int64                   `json:"memReservation"`
+		MemSwapLimit   int64                   `json:"memSwapLimit"`
+		MemSwappiness  *int64                  `json:"memSwappiness"`
+		Networks       []containerNetworkExtra `json:"networks"`
+		DNSSearch      []string                `json:"dnsSearch"`
+		DNSOptions     []string                `json:"dnsOptions"`
+	} `json:"data"`
+}
+
+// containerNetworkExtra holds the fields of a container network that qnap-client-lib does not model yet,
+// in the same order as the library networks.
+type containerNetworkExtra struct {
+	IPv6Address string   `json:"ipv6Address"`
+	IPv6Gateway string   `json:"ipv6Gateway"`
+	Aliases     []string `json:"aliases"`
+}
+
+// networkExtra returns the extra fields of the network at the given index of the library networks.
+func (c *containerDetails) networkExtra(i int) containerNetworkExtra {
+	if i < len(c.Extra.Data.Networks) {
+		return c.Extra.Data.Networks[i]
+	}
+	return containerNetworkExtra{}
+}
+
+// containerDetailsWideFields are decoded as int32 by qnap-client-lib although the API
+// returns byte counts that overflow it, they are decoded into the extra fields instead.
+var containerDetailsWideFields = map[string]bool{
+	"data.memLimit":       true,
+	"data.memReservation": true,
+}
+
+// UnmarshalJSON decodes the same response into both the library model and the extra fields.
+func (c *containerDetails) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &c.ContainerInfo); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) || !containerDetailsWideFields[typeErr.Field] {
+			return err
+		}
+	}
+	return json.Unmarshal(b, &c.Extra)
+}
+
+// containerUpdateSpec is the payload of the Container Station container update endpoint.
+type containerUpdateSpec struct {
+	ID                         string                    `json:"id"`
+	Type                       string                    `json:"type"`
+	Runtime                    string                    `json:"runtime"`
+	Name                       string                    `json:"name"`
+	RestartPolicy              containerUpdateRestart    `json:"restartPolicy"`
+	Networks                   []containerUpdateNetwork  `json:"networks"`
+	Privileged                 bool                      `json:"privileged"`
+	Devices                    []qnap.Devices            `json:"devices"`
+	Volumes                    []qnap.Volumes            `json:"volumes"`
+	CPULimit                   int32                     `json:"cpuLimit"`
+	MemLimit                   int64                     `json:"memLimit"`
+	MemReservation             int64                     `json:"memReservation"`
+	IsCPULimited               bool                      `json:"isCpuLimited"`
+	IsMemoryLimited            bool                      `json:"isMemoryLimited"`
+	IsMemoryReservationLimited bool                      `json:"isMemoryReservationLimited"`
+	Cpupin                     containerUpdateCpupin     `json:"cpupin"`
+	Extra                      containerUpdateSpecExtras `json:"extra"`
+}
+
+type containerUpdateRestart struct {
+	Name              string `json:"name"`
+	MaximumRetryCount int32  `json:"maximumRetryCount"`
+}
+
+type containerUpdateNetwork struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	IPAddress   string `json:"ipAddress"`
+	DisplayName string `json:"displayName"`
+	MacAddress  string `json:"macAddress"`
+	Gateway     string `json:"gateway"`
+	NetworkType string `json:"networkType"`
+	IsStaticIP  bool   `json:"isStaticIP"`
+}
+
+type containerUpdateCpupin struct {
+	Type   string `json:"type"`
+	CPUIDs string `json:"cpuIDs"`
+}
+
+type containerUpdateSpecExtras struct {
+	Restart bool `json:"restart"`
+}
+
+// newContainerUpdateSpec builds an update payload that keeps the current settings of the container.
+func newContainerUpdateSpec(container *containerDetails) containerUpdateSpec {
+	data := container.Data
+	spec := containerUpdateSpec{
+		ID:      data.ID,
+		Type:    data.Type,
+		Runtime: data.Runtime,
+		Name:    data.Name,
+		RestartPolicy: containerUpdateRestart{
+			Name:              data.RestartPolicy.Name,
+			MaximumRetryCount: data.RestartPolicy.MaximumRetryCount,
+		},
+		Privileged:                 data.Privileged,
+		CPULimit:                   data.CPULimit,
+		MemLimit:                   container.Extra.Data.MemLimit,
+		MemReservation:             container.Extra.Data.MemReservation,
+		IsCPULimited:               data.CPULimit > 0,
+		IsMemoryLimited:            container.Extra.Data.MemLimit > 0,
+		IsMemoryReservationLimited: container.Extra.Data.MemReservation > 0,
+		Cpupin: containerUpdateCpupin{
+			Type:   data.Cpupin.Type,
+			CPUIDs: data.Cpupin.CPUIDs,
+		},
+		Networks: []containerUpdateNetwork{},
+		Devices:  []qnap.Devices{},
+		Volumes:  []qnap.Volumes{},
+	}
+	for _, network := range data.Networks {
+		spec.Networks = append(spec.Networks, containerUpdateNetwork(network))
+	}
+	for _, device := range data.Devices {
+		spec.Devices = append(spec.Devices, qnap.Devices(device))
+	}
+	for _, volume := range data.Volumes {
+		spec.Volumes = append(spec.Volumes, qnap.Volumes(volume))
+	}
+	return spec
+}
+
+// createContainer creates a new container and returns its inspect details once the creation task is completed.
+func createContainer(ctx context.Context, client *qnap.Client, container containerCreateSpec) (*containerDetails, error) {
+	containersBefore, err := client.GetContainerStationOverview()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, containerBefore := range containersBefore.Data.Container {
+		if containerBefore.Name == container.Name && container.Operation != "recreate" {
+			return nil, errors.New("cannot create container as a container with the same name already exists")
+		}
+	}
+
+	var response qnap.ContainerStationTaskResponse
+	err = apiRequest(ctx, client, http.MethodPost, "/container-station/api/v3/containers", container, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	err = waitForTask(ctx, client, response.Data.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	containersAfter, err := client.GetContainerStationOverview()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, containerAfter := range containersAfter.Data.Container {
+		if containerAfter.Name == container.Name {
+			return inspectContainer(ctx, client, containerAfter.ID, containerAfter.Type)
+		}
+	}
+
+	return nil, errors.New("container is not found after creation. Possible options: QNAP container station needs more time or the container creation failed silently")
+}
+
+// containerStateChange is the payload of the stop and kill operations of containers.
+type containerStateChange struct {
+	Data containerStateChangeData `json:"data"`
+}
+
+type containerStateChangeData struct {
+	Items   []qnap.Item `json:"items"`
+	Timeout *int        `json:"timeout,omitempty"`
+}
+
+// stopContainer stops a container, its processes are killed when they do not exit within the grace period. Zero uses
+// the grace period of Container Station.
+func stopContainer(ctx context.Context, client *qnap.Client, containerID string, containerType string, gracePeriod time.Duration) error {
+	change := containerStateChange{Data: containerStateChangeData{Items: []qnap.Item{{CID: containerID, CType: containerType}}}}
+	if gracePeriod > 0 {
+		seconds := int(gracePeriod.Seconds())
+		change.Data.Timeout = &seconds
+	}
+	return changeContainerState(ctx, client, "stop", change)
+}
+
+// restartContainer restarts a running container.
+func restartContainer(ctx context.Context, client *qnap.Client, containerID string, containerType string) error {
+	change := containerStateChange{Data: containerStateChangeData{Items: []qnap.Item{{CID: containerID, CType: containerType}}}}
+	return changeContainerState(ctx, client, "restart", change)
+}
+
+// killContainer kills the processes of a container immediately.
+func killContainer(ctx context.Context, client *qnap.Client, containerID string, containerType string) error {
+	change := containerStateChange{Data: containerStateChangeData{Items: []qnap.Item{{CID: containerID, CType: containerType}}}}
+	return changeContainerState(ctx, client, "kill", change)
+}
+
+// Statuses of containers the client library has no constant for.
+const (
+	containerStatusStopped = "stopped"
+	containerStatusPaused  = "paused"
+)
+
+// changeContainerStatus moves a container from one status to another: running, stopped or paused. A container that is
+// stopped is stopped gracefully within the grace period, zero uses the grace period of Container Station.
+func changeContainerStatus(ctx context.Context, client *qnap.Client, containerID string, containerType string, from string, to string, gracePeriod time.Duration) error {
+	change := containerStateChange{Data: containerStateChangeData{Items: []qnap.Item{{CID: containerID, CType: containerType}}}}
+	switch {
+	case from == to:
+		return nil
+	case to == containerStatusStopped:
+		return stopContainer(ctx, client, containerID, containerType, gracePeriod)
+	case from == containerStatusPaused:
+		return changeContainerState(ctx, client, "unpause", change)
+	}
+
+	if from != qnap.ContainerStatusRunning {
+		err := changeContainerState(ctx, client, "start", change)
+		if err != nil {
+			return err
+		}
+	}
+	if to == containerStatusPaused {
+		return changeContainerState(ctx, client, "pause", change)
+	}
+	return nil
+}
+
+// changeContainerState runs a state change operation and waits for its task to complete.
+func changeContainerState(ctx context.Context, client *qnap.Client, operation string, change containerStateChange) error {
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodPut, "/container-station/api/v3/containers/"+operation, change, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Data.TaskID == "" {
+		return nil
+	}
+	return waitForTask(ctx, client, response.Data.TaskID)
+}
+
+// containerExecSpec is the payload of a command run in a container.
+type containerExecSpec struct {
+	Cmd        []string `json:"cmd"`
+	User       string   `json:"user,omitempty"`
+	WorkingDir string   `json:"workingDir,omitempty"`
+	Env        []string `json:"env,omitempty"`
+}
+
+// containerExecResult is the result of a command run in a container.
+type containerExecResult struct {
+	Data struct {
+		ExitCode int    `json:"exitCode"`
+		Output   string `json:"output"`
+	} `json:"data"`
+}
+
+// execContainer runs a command in a running container and returns its exit code and output once it exited.
+func execContainer(ctx context.Context, client *qnap.Client, containerID string, containerType string, exec containerExecSpec) (*containerExecResult, error) {
+	var result containerExecResult
+	err := apiRequest(ctx, client, http.MethodPost, fmt.Sprintf("/container-station/api/v3/containers/%s/%s/exec", containerType, containerID), exec, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// containerArchiveSpec is the payload of the Container Station container archive endpoint, a base64 encoded tar
+// archive is extracted into a directory of the container.
+type containerArchiveSpec struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// copyToContainer extracts an archive into a directory of a container and waits for the task to complete.
+func copyToContainer(ctx context.Context, client *qnap.Client, containerID string, containerType string, archive containerArchiveSpec) error {
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodPut, fmt.Sprintf("/container-station/api/v3/containers/%s/%s/archive", containerType, containerID), archive, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Data.TaskID == "" {
+		return nil
+	}
+	return waitForTask(ctx, client, response.Data.TaskID)
+}
+
+// containerLogs returns the log of a container.
+func containerLogs(ctx context.Context, client *qnap.Client, containerID string, containerType string) (string, error) {
+	var response struct {
+		Data struct {
+			Logs string `json:"logs"`
+		} `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, fmt.Sprintf("/container-station/api/v3/containers/%s/%s/logs", containerType, containerID), nil, &response)
+	if err != nil {
+		return "", err
+	}
+	return response.Data.Logs, nil
+}
+
+// containerCommitSpec is the payload of the Container Station container commit endpoint.
+type containerCommitSpec struct {
+	Repo    string `json:"repo"`
+	Tag     string `json:"tag"`
+	Comment string `json:"comment,omitempty"`
+	Author  string `json:"author,omitempty"`
+	Pause   bool   `json:"pause"`
+}
+
+// commitContainer saves the filesystem of a docker container as a new image and returns the image once the task is
+// completed.
+func commitContainer(ctx context.Context, client *qnap.Client, containerID string, commit containerCommitSpec) (*imageInfo, error) {
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodPost, fmt.Sprintf("/container-station/api/v3/containers/docker/%s/commit", containerID), commit, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Data.TaskID != "" {
+		err = waitForTask(ctx, client, response.Data.TaskID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	committed, err := findImageByName(ctx, client, commit.Repo, commit.Tag)
+	if err != nil {
+		return nil, err
+	}
+	if committed == nil {
+		return nil, fmt.Errorf("image %s:%s is not found after the commit. Possible options: QNAP container station needs more time or the commit failed silently", commit.Repo, commit.Tag)
+	}
+	return committed, nil
+}
+
+// stoppedContainers returns the containers that are not running, paused or restarting. The containers of
+// docker-compose apps are left to their app.
+func stoppedContainers(client *qnap.Client) ([]qnap.Container, error) {
+	containers, err := client.GetContainers()
+	if err != nil {
+		return nil, withRequest(err, http.MethodGet, "/container-station/api/v3/containers")
+	}
+
+	stopped := []qnap.Container{}
+	for _, container := range containers {
+		switch container.Status {
+		case qnap.ContainerStatusRunning, containerStatusPaused, "restarting":
+			continue
+		}
+		if container.Project == "" {
+			stopped = append(stopped, container)
+		}
+	}
+	return stopped, nil
+}
+
+// findContainerByName returns the ID and type of the container with the given name, empty when there is none.
+func findContainerByName(_ context.Context, client *qnap.Client, name string) (string, string, error) {
+	containers, err := client.GetContainerStationOverview()
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, container := range containers.Data.Container {
+		if container.Name == name {
+			return container.ID, container.Type, nil
+		}
+	}
+	return "", "", nil
+}
+
+// inspectContainer returns the specifications of a container.
+func inspectContainer(ctx context.Context, client *qnap.Client, containerID string, containerType string) (*containerDetails, error) {
+	path := fmt.Sprintf("/container-station/api/v3/containers/%s?id=%s", containerType, containerID)
+
+	var container containerDetails
+	err := apiRequest(ctx, client, http.MethodGet, path, nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return &container, nil
+}
+
+// updateContainer applies the update payload to an existing container and waits for the task to complete.
+func updateContainer(ctx context.Context, client *qnap.Client, container containerUpdateSpec) error {
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodPost, fmt.Sprintf("/container-station/api/v3/containers/%s/update", container.Type), container, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Data.TaskID == "" {
+		return nil
+	}
+	return waitForTask(ctx, client, response.Data.TaskID)
+}
+
+// renameContainer changes the name of an existing container while keeping its ID and settings.
+func renameContainer(ctx context.Context, client *qnap.Client, containerID string, containerType string, name string) error {
+	containers, err := client.GetContainerStationOverview()
+	if err != nil {
+		return err
+	}
+
+	for _, container := range containers.Data.Container {
+		if container.Name == name && container.ID != containerID {
+			return errors.New("cannot rename container as a container with the same name already exists")
+		}
+	}
+
+	container, err := inspectContainer(ctx, client, containerID, containerType)
+	if err != nil {
+		return err
+	}
+
+	spec := newContainerUpdateSpec(container)
+	spec.Name = name
+	return updateContainer(ctx, client, spec)
+}
+
+// updateContainerLimits changes the CPU and memory limits of a container while it runs, like the resource sliders of
+// the Container Station UI, zero removes a limit.
+func updateContainerLimits(ctx context.Context, client *qnap.Client, containerID string, containerType string, cpuLimit int32, memLimit int64) error {
+	container, err := inspectContainer(ctx, client, containerID, containerType)
+	if err != nil {
+		return err
+	}
+
+	spec := newContainerUpdateSpec(container)
+	spec.CPULimit = cpuLimit
+	spec.IsCPULimited = cpuLimit > 0
+	spec.MemLimit = memLimit
+	spec.IsMemoryLimited = memLimit > 0
+	spec.Extra.Restart = false
+	return updateContainer(ctx, client, spec)
+}
+
+// userNetworkAliases returns the aliases of the network at the given index without the aliases
+// added by docker, the short container ID and the container name.
+func userNetworkAliases(container *containerDetails, i int) []string {
+	aliases := []string{}
+	for _, alias := range container.networkExtra(i).Aliases {
+		if (len(alias) == 12 && strings.HasPrefix(container.Data.ID, alias)) || alias == container.Data.Name {
+			continue
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases
+}
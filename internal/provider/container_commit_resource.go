@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &containerCommitResource{}
+	_ resource.ResourceWithConfigure = &containerCommitResource{}
+)
+
+type ContainerCommitSpecModel struct {
+	ID          basetypes.StringValue `tfsdk:"id"`
+	ContainerID basetypes.StringValue `tfsdk:"container_id"`
+	Image       basetypes.StringValue `tfsdk:"image"`
+	Comment     basetypes.StringValue `tfsdk:"comment"`
+	Author      basetypes.StringValue `tfsdk:"author"`
+	Pause       basetypes.BoolValue   `tfsdk:"pause"`
+	Triggers    basetypes.MapValue    `tfsdk:"triggers"`
+	KeepImage   basetypes.BoolValue   `tfsdk:"keep_image"`
+	LastUpdated basetypes.StringValue `tfsdk:"last_updated"`
+}
+
+// containerCommitResource is the resource implementation.
+type containerCommitResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewContainerCommitResource is a helper function to simplify the provider implementation.
+func NewContainerCommitResource() resource.Resource {
+	return &containerCommitResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *containerCommitResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_commit"
+}
+
+// Schema defines the schema for the resource.
+func (r *containerCommitResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Saves the filesystem of a docker container as a new image on the NAS, for example a snapshot of a container before it is upgraded. The container is committed again when it, the image name or any of the triggers change.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the committed image.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"container_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the docker container to commit (e.g. the id of qnap_container).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"image": schema.StringAttribute{
+				Required:    true,
+				Description: "The repository and tag of the new image (e.g. 'snapshots/app:pre-upgrade'). The tag defaults to latest.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "The commit message of the image.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"author": schema.StringAttribute{
+				Optional:    true,
+				Description: "The author of the image (e.g. 'Ops <ops@example.com>').",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"pause": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to pause the container while it is committed so the filesystem is consistent. Defaults to true.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that commit the container again when changed (e.g. the image of the container before an upgrade).",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"keep_image": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to keep the image on the NAS when the resource is destroyed or replaced, so earlier snapshots stay available for a rollback. Defaults to true.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the commit.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create commits the container.
+func (r *containerCommitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan ContainerCommitSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repo, tag := splitImageReference(plan.Image.ValueString())
+	image, err := commitContainer(ctx, r.client, plan.ContainerID.ValueString(), containerCommitSpec{
+		Repo:    repo,
+		Tag:     tag,
+		Comment: plan.Comment.ValueString(),
+		Author:  plan.Author.ValueString(),
+		Pause:   plan.Pause.IsNull() || plan.Pause.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error committing container",
+			errorDetail("Could not commit container "+plan.ContainerID.ValueString()+" to image "+repo+":"+tag, err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(image.ID)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read removes the commit from state when the image does not exist anymore.
+func (r *containerCommitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state ContainerCommitSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	image, err := findImage(ctx, r.client, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading image "+state.Image.ValueString(), err),
+		)
+		return
+	}
+	if image == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+}
+
+// Update changes keep_image, every other attribute requires replacement.
+func (r *containerCommitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ContainerCommitSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the image unless keep_image is set.
+func (r *containerCommitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	// Retrieve values from state
+	var state ContainerCommitSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.KeepImage.IsNull() || state.KeepImage.ValueBool() {
+		return
+	}
+
+	err := deleteImage(ctx, r.client, state.ID.ValueString())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting image",
+			errorDetail("Could not delete image "+state.Image.ValueString(), err),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *containerCommitResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccContainerCommitResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: `
+					resource "qnap_container" "commit" {
+					name              = "terraform_test_commit"
+					image             = "nginx:latest"
+					type              = "docker"
+					removeanonvolumes = true
+					}
+
+					resource "qnap_container_commit" "snapshot" {
+					container_id = qnap_container.commit.id
+					image        = "terraform_test/snapshot:pre-upgrade"
+					comment      = "before upgrade"
+					keep_image   = false
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("qnap_container_commit.snapshot", "id"),
+					resource.TestCheckResourceAttr("qnap_container_commit.snapshot", "image", "terraform_test/snapshot:pre-upgrade"),
+					resource.TestCheckResourceAttrSet("qnap_container_commit.snapshot", "last_updated"),
+				),
+			},
+			// test case 2
+			{
+				Config: `
+					resource "qnap_container" "commit" {
+					name              = "terraform_test_commit"
+					image             = "nginx:latest"
+					type              = "docker"
+					removeanonvolumes = true
+					}
+
+					resource "qnap_container_commit" "snapshot" {
+					container_id = qnap_container.commit.id
+					image        = "terraform_test/snapshot:pre-upgrade"
+					comment      = "before upgrade"
+					keep_image   = false
+					triggers = {
+						image = qnap_container.commit.image
+					}
+					}
+
+					resource "qnap_container" "restored" {
+					name              = "terraform_test_commit_restored"
+					image             = qnap_container_commit.snapshot.image
+					type              = "docker"
+					removeanonvolumes = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container_commit.snapshot", "triggers.image", "nginx:latest"),
+					resource.TestCheckResourceAttr("qnap_container.restored", "image", "terraform_test/snapshot:pre-upgrade"),
+				),
+			},
+		},
+	})
+}
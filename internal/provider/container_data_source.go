@@ -0,0 +1,434 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &containerDataSource{}
+	_ datasource.DataSourceWithConfigure = &containerDataSource{}
+)
+
+// containerDataSource is the data source implementation.
+type containerDataSource struct {
+	client *qnap.Client
+}
+
+// containerDataSourceModel maps the data source schema data.
+type containerDataSourceModel struct {
+	ID            types.String                  `tfsdk:"id"`
+	Name          types.String                  `tfsdk:"name"`
+	Type          types.String                  `tfsdk:"type"`
+	Image         types.String                  `tfsdk:"image"`
+	ImageDigest   types.String                  `tfsdk:"image_digest"`
+	Status        types.String                  `tfsdk:"status"`
+	Project       types.String                  `tfsdk:"project"`
+	Runtime       types.String                  `tfsdk:"runtime"`
+	Hostname      types.String                  `tfsdk:"hostname"`
+	Created       types.String                  `tfsdk:"created"`
+	Cmd           []types.String                `tfsdk:"cmd"`
+	Entrypoint    []types.String                `tfsdk:"entrypoint"`
+	Env           map[string]types.String       `tfsdk:"env"`
+	Labels        map[string]types.String       `tfsdk:"labels"`
+	Privileged    types.Bool                    `tfsdk:"privileged"`
+	CPULimit      types.Int32                   `tfsdk:"cpu_limit"`
+	MemLimit      types.Int64                   `tfsdk:"mem_limit"`
+	RestartPolicy containerRestartPolicyModel   `tfsdk:"restartpolicy"`
+	PortBindings  []containersPortBindingsModel `tfsdk:"portbindings"`
+	Volumes       []containerVolumeModel        `tfsdk:"volumes"`
+	Networks      []containerNetworkModel       `tfsdk:"networks"`
+}
+
+type containerRestartPolicyModel struct {
+	Name              types.String `tfsdk:"name"`
+	MaximumRetryCount types.Int32  `tfsdk:"maximumretrycount"`
+}
+
+type containerVolumeModel struct {
+	Type        types.String `tfsdk:"type"`
+	Name        types.String `tfsdk:"name"`
+	Source      types.String `tfsdk:"source"`
+	Destination types.String `tfsdk:"destination"`
+	Permission  types.String `tfsdk:"permission"`
+}
+
+type containerNetworkModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	DisplayName types.String `tfsdk:"displayname"`
+	IPAddress   types.String `tfsdk:"ipaddress"`
+	IPAddress6  types.String `tfsdk:"ipaddress6"`
+	MacAddress  types.String `tfsdk:"macaddress"`
+	Gateway     types.String `tfsdk:"gateway"`
+	NetworkType types.String `tfsdk:"networktype"`
+	IsStaticIP  types.Bool   `tfsdk:"isstaticip"`
+}
+
+// NewContainerDataSource is a helper function to simplify the provider implementation.
+func NewContainerDataSource() datasource.DataSource {
+	return &containerDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *containerDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container"
+}
+
+// Schema defines the schema for the data source.
+func (d *containerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a container by name or ID, including containers that are not managed by Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the container to look up.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the container to look up.",
+			},
+			"type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The type of the container (docker, lxd).",
+			},
+			"image": schema.StringAttribute{
+				Computed:    true,
+				Description: "The image of the container.",
+			},
+			"image_digest": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the image the container runs.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the container, for example running or exited.",
+			},
+			"project": schema.StringAttribute{
+				Computed:    true,
+				Description: "The application the container belongs to, empty for standalone containers.",
+			},
+			"runtime": schema.StringAttribute{
+				Computed:    true,
+				Description: "The runtime of the container.",
+			},
+			"hostname": schema.StringAttribute{
+				Computed:    true,
+				Description: "The hostname of the container.",
+			},
+			"created": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the container was created.",
+			},
+			"cmd": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The command of the container.",
+			},
+			"entrypoint": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The entrypoint of the container.",
+			},
+			"env": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The environment variables of the container.",
+			},
+			"labels": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The labels of the container.",
+			},
+			"privileged": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the container runs in privileged mode.",
+			},
+			"cpu_limit": schema.Int32Attribute{
+				Computed:    true,
+				Description: "The CPU limit of the container, 0 when it is not limited.",
+			},
+			"mem_limit": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The memory limit of the container in bytes, 0 when it is not limited.",
+			},
+			"restartpolicy": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The restart policy of the container.",
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "The name of the restart policy.",
+					},
+					"maximumretrycount": schema.Int32Attribute{
+						Computed:    true,
+						Description: "The maximum number of restarts of the onFailure policy.",
+					},
+				},
+			},
+			"portbindings": schema.ListNestedAttribute{
+				Computed:    true,
+				CustomType:  newPortBindingsType(containersPortBindingAttrTypes),
+				Description: "The published ports of the container.",
+				NestedObject: schema.NestedAttributeObject{
+					CustomType: newPortBindingType(containersPortBindingAttrTypes),
+					Attributes: map[string]schema.Attribute{
+						"host": schema.Int32Attribute{
+							Computed:    true,
+							Description: "The port on the NAS.",
+						},
+						"container": schema.Int32Attribute{
+							Computed:    true,
+							Description: "The port in the container.",
+						},
+						"protocol": schema.StringAttribute{
+							Computed:    true,
+							Description: "The protocol of the port (tcp, udp).",
+						},
+						"hostip": schema.StringAttribute{
+							Computed:    true,
+							Description: "The address of the NAS the port is published on.",
+						},
+						"containerip": schema.StringAttribute{
+							Computed:    true,
+							Description: "The address of the container the port is forwarded to.",
+						},
+					},
+				},
+			},
+			"volumes": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The volumes mounted in the container.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the volume (volume, host).",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the volume.",
+						},
+						"source": schema.StringAttribute{
+							Computed:    true,
+							Description: "The path on the NAS of a host volume.",
+						},
+						"destination": schema.StringAttribute{
+							Computed:    true,
+							Description: "The path the volume is mounted at in the container.",
+						},
+						"permission": schema.StringAttribute{
+							Computed:    true,
+							Description: "The permission of the mount (readOnly, writable).",
+						},
+					},
+				},
+			},
+			"networks": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The networks the container is connected to.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the network.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the network.",
+						},
+						"displayname": schema.StringAttribute{
+							Computed:    true,
+							Description: "The display name of the network.",
+						},
+						"ipaddress": schema.StringAttribute{
+							Computed:    true,
+							Description: "The IP address of the container on the network.",
+						},
+						"ipaddress6": schema.StringAttribute{
+							Computed:    true,
+							Description: "The IPv6 address of the container on the network.",
+						},
+						"macaddress": schema.StringAttribute{
+							Computed:    true,
+							Description: "The MAC address of the container on the network.",
+						},
+						"gateway": schema.StringAttribute{
+							Computed:    true,
+							Description: "The gateway of the network.",
+						},
+						"networktype": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the network.",
+						},
+						"isstaticip": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the IP address of the container is static.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *containerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state containerDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	overview, err := d.client.GetContainerStationOverview()
+	err = withRequest(err, http.MethodGet, "/container-station/api/v3/overview")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read QNAP Container",
+			errorDetail("Could not read the container of the NAS", err),
+		)
+		return
+	}
+
+	var containerID, containerType string
+	for _, container := range overview.Data.Container {
+		if container.ID == state.ID.ValueString() || (!state.Name.IsNull() && container.Name == state.Name.ValueString()) {
+			containerID, containerType = container.ID, container.Type
+			break
+		}
+	}
+	if containerID == "" {
+		lookup := state.Name.ValueString()
+		if lookup == "" {
+			lookup = state.ID.ValueString()
+		}
+		resp.Diagnostics.AddError(
+			"Container not found",
+			fmt.Sprintf("No container is named or has the ID %q.", lookup),
+		)
+		return
+	}
+
+	container, err := inspectContainer(ctx, d.client, containerID, containerType)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read QNAP Container",
+			errorDetail("Could not read the container of the NAS", err),
+		)
+		return
+	}
+
+	data := container.Data
+	state.ID = types.StringValue(data.ID)
+	state.Name = types.StringValue(data.Name)
+	state.Type = types.StringValue(data.Type)
+	state.Image = types.StringValue(data.Image)
+	state.ImageDigest = types.StringValue(data.ImageID)
+	state.Status = types.StringValue(data.Status)
+	state.Project = types.StringValue(data.Project)
+	state.Runtime = types.StringValue(data.Runtime)
+	state.Hostname = types.StringValue(data.Hostname)
+	state.Created = types.StringValue(data.Created)
+	state.Privileged = types.BoolValue(data.Privileged)
+	state.CPULimit = types.Int32Value(data.CPULimit)
+	state.MemLimit = types.Int64Value(container.Extra.Data.MemLimit)
+	state.RestartPolicy = containerRestartPolicyModel{
+		Name:              types.StringValue(data.RestartPolicy.Name),
+		MaximumRetryCount: types.Int32Value(data.RestartPolicy.MaximumRetryCount),
+	}
+
+	state.Cmd = []types.String{}
+	for _, arg := range data.Cmd {
+		state.Cmd = append(state.Cmd, types.StringValue(arg))
+	}
+	state.Entrypoint = []types.String{}
+	for _, arg := range data.Entrypoint {
+		state.Entrypoint = append(state.Entrypoint, types.StringValue(arg))
+	}
+	state.Env = map[string]types.String{}
+	for key, value := range data.Env {
+		state.Env[key] = types.StringValue(value)
+	}
+	state.Labels = map[string]types.String{}
+	for key, value := range data.Labels {
+		state.Labels[key] = types.StringValue(value)
+	}
+
+	state.PortBindings = []containersPortBindingsModel{}
+	for _, port := range data.PortBindings {
+		state.PortBindings = append(state.PortBindings, containersPortBindingsModel{
+			Host:        types.Int32Value(port.Host),
+			Container:   types.Int32Value(port.Container),
+			Protocol:    types.StringValue(normalizePortBindingString("protocol", port.Protocol)),
+			HostIP:      types.StringValue(port.HostIP),
+			ContainerIP: types.StringValue(port.ContainerIP),
+		})
+	}
+	state.Volumes = []containerVolumeModel{}
+	for _, volume := range data.Volumes {
+		state.Volumes = append(state.Volumes, containerVolumeModel{
+			Type:        types.StringValue(volume.Type),
+			Name:        types.StringValue(volume.Name),
+			Source:      types.StringValue(volume.Source),
+			Destination: types.StringValue(volume.Destination),
+			Permission:  types.StringValue(volume.Permission),
+		})
+	}
+	state.Networks = []containerNetworkModel{}
+	for i, network := range data.Networks {
+		state.Networks = append(state.Networks, containerNetworkModel{
+			ID:          types.StringValue(network.ID),
+			Name:        types.StringValue(network.Name),
+			DisplayName: types.StringValue(network.DisplayName),
+			IPAddress:   types.StringValue(network.IPAddress),
+			IPAddress6:  types.StringValue(container.networkExtra(i).IPv6Address),
+			MacAddress:  types.StringValue(network.MacAddress),
+			Gateway:     types.StringValue(network.Gateway),
+			NetworkType: types.StringValue(network.NetworkType),
+			IsStaticIP:  types.BoolValue(network.IsStaticIP),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *containerDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qnap.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qnap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccContainerDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+					resource "qnap_container" "lookup" {
+					name              = "terraform_test_lookup"
+					image             = "nginx:latest"
+					type              = "docker"
+					removeanonvolumes = true
+					env = {
+						LOOKUP = "true"
+					}
+					portbindings = [{
+						host      = 8086
+						container = 80
+						protocol  = "tcp"
+						hostip    = "0.0.0.0"
+					}]
+					}
+
+					data "qnap_container" "by_name" {
+					name = qnap_container.lookup.name
+					}
+
+					data "qnap_container" "by_id" {
+					id = qnap_container.lookup.id
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.qnap_container.by_name", "id", "qnap_container.lookup", "id"),
+					resource.TestCheckResourceAttr("data.qnap_container.by_id", "name", "terraform_test_lookup"),
+					resource.TestCheckResourceAttr("data.qnap_container.by_name", "type", "docker"),
+					resource.TestCheckResourceAttr("data.qnap_container.by_name", "status", "running"),
+					resource.TestCheckResourceAttr("data.qnap_container.by_name", "env.LOOKUP", "true"),
+					resource.TestCheckResourceAttr("data.qnap_container.by_name", "portbindings.0.host", "8086"),
+					resource.TestCheckResourceAttrSet("data.qnap_container.by_name", "networks.0.ipaddress"),
+				),
+			},
+			// Unknown container
+			{
+				Config:      providerConfig + `data "qnap_container" "missing" { name = "terraform_test_missing" }`,
+				ExpectError: regexp.MustCompile("Container not found"),
+			},
+		},
+	})
+}
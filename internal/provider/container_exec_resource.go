@@ -0,0 +1,287 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &containerExecResource{}
+	_ resource.ResourceWithConfigure = &containerExecResource{}
+)
+
+type ContainerExecSpecModel struct {
+	ID            basetypes.StringValue `tfsdk:"id"`
+	ContainerID   basetypes.StringValue `tfsdk:"container_id"`
+	ContainerType basetypes.StringValue `tfsdk:"container_type"`
+	Command       basetypes.ListValue   `tfsdk:"command"`
+	User          basetypes.StringValue `tfsdk:"user"`
+	WorkingDir    basetypes.StringValue `tfsdk:"working_dir"`
+	Env           basetypes.MapValue    `tfsdk:"env"`
+	Triggers      basetypes.MapValue    `tfsdk:"triggers"`
+	FailOnError   basetypes.BoolValue   `tfsdk:"fail_on_error"`
+	ExitCode      basetypes.Int32Value  `tfsdk:"exit_code"`
+	Output        basetypes.StringValue `tfsdk:"output"`
+	LastUpdated   basetypes.StringValue `tfsdk:"last_updated"`
+}
+
+// containerExecResource is the resource implementation.
+type containerExecResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewContainerExecResource is a helper function to simplify the provider implementation.
+func NewContainerExecResource() resource.Resource {
+	return &containerExecResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *containerExecResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_exec"
+}
+
+// Schema defines the schema for the resource.
+func (r *containerExecResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs a one-off command in a running container, for example database migrations after a deployment. The command runs when the resource is created and again when the container, the command or any of the triggers change. Destroying the resource does not undo the command.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the run, the container ID and the timestamp of the run.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"container_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the container to run the command in (e.g. the id of qnap_container). The container must be running.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"container_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "The type of the container (docker, lxd). Defaults to docker.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("docker", "lxd"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"command": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The command to run and its arguments (e.g. ['sh', '-c', 'php artisan migrate']).",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				Optional:    true,
+				Description: "The user to run the command as (e.g. 'www-data', '1000:1000'). Defaults to the user of the container.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"working_dir": schema.StringAttribute{
+				Optional:    true,
+				Description: "The directory to run the command in. Defaults to the working directory of the container.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"env": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Environment variables set for the command in addition to the environment of the container.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that run the command again when changed. Use timestamp() to run it on every apply.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"fail_on_error": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to fail the apply when the command exits with a non-zero code. The resource is then not created and the command runs again on the next apply. Defaults to true.",
+			},
+			"exit_code": schema.Int32Attribute{
+				Computed:    true,
+				Description: "The exit code of the command.",
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
+				},
+			},
+			"output": schema.StringAttribute{
+				Computed:    true,
+				Description: "The combined standard output and standard error of the command.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the run.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create runs the command.
+func (r *containerExecResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan ContainerExecSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	containerType := plan.ContainerType.ValueString()
+	if containerType == "" {
+		containerType = "docker"
+	}
+
+	exec := containerExecSpec{
+		User:       plan.User.ValueString(),
+		WorkingDir: plan.WorkingDir.ValueString(),
+	}
+	diags = plan.Command.ElementsAs(ctx, &exec.Cmd, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	env := map[string]string{}
+	diags = plan.Env.ElementsAs(ctx, &env, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for name, value := range env {
+		exec.Env = append(exec.Env, name+"="+value)
+	}
+	sort.Strings(exec.Env)
+
+	// Commands can only run in a running container
+	container, err := inspectContainer(ctx, r.client, plan.ContainerID.ValueString(), containerType)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Container",
+			errorDetail("Could not read container "+plan.ContainerID.ValueString(), err),
+		)
+		return
+	}
+	if container.Data.Status != qnap.ContainerStatusRunning {
+		resp.Diagnostics.AddError(
+			"Container Not Running",
+			"Container "+container.Data.Name+" is "+container.Data.Status+", the command can only run in a running container.",
+		)
+		return
+	}
+
+	result, err := execContainer(ctx, r.client, plan.ContainerID.ValueString(), containerType, exec)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error running command",
+			errorDetail("Could not run the command in container "+container.Data.Name, err),
+		)
+		return
+	}
+
+	if result.Data.ExitCode != 0 && (plan.FailOnError.IsNull() || plan.FailOnError.ValueBool()) {
+		resp.Diagnostics.AddError(
+			"Command failed",
+			"The command exited with code "+strconv.Itoa(result.Data.ExitCode)+" in container "+container.Data.Name+":\n\n"+result.Data.Output,
+		)
+		return
+	}
+
+	now := time.Now()
+	plan.ID = types.StringValue(plan.ContainerID.ValueString() + ":" + now.Format(time.RFC3339))
+	plan.ExitCode = types.Int32Value(int32(result.Data.ExitCode))
+	plan.Output = types.StringValue(result.Data.Output)
+	plan.LastUpdated = types.StringValue(now.Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the result of the last run in state.
+func (r *containerExecResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update changes fail_on_error, every other attribute requires replacement.
+func (r *containerExecResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ContainerExecSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the run from the Terraform state.
+func (r *containerExecResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *containerExecResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
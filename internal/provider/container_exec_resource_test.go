@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+const testAccContainerExecContainer = `
+					resource "qnap_container" "exec" {
+						name = "terraform_test_exec"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+					}
+`
+
+func TestAccContainerExecResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: testAccContainerExecContainer + `
+					resource "qnap_container_exec" "echo" {
+					container_id = qnap_container.exec.id
+					command = ["sh", "-c", "echo $GREETING"]
+					env = {
+						GREETING = "hello"
+					}
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container_exec.echo", "exit_code", "0"),
+					resource.TestMatchResourceAttr("qnap_container_exec.echo", "output", regexp.MustCompile(`hello`)),
+				),
+			},
+			// test case 2 - run again when a trigger changes
+			{
+				Config: testAccContainerExecContainer + `
+					resource "qnap_container_exec" "echo" {
+					container_id = qnap_container.exec.id
+					command = ["sh", "-c", "echo $GREETING"]
+					env = {
+						GREETING = "hello"
+					}
+					triggers = {
+						run = "2"
+					}
+					}
+
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container_exec.echo", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container_exec.echo", "triggers.run", "2"),
+				),
+			},
+			// test case 3 - failed command
+			{
+				Config: testAccContainerExecContainer + `
+					resource "qnap_container_exec" "fail" {
+					container_id = qnap_container.exec.id
+					command = ["sh", "-c", "exit 3"]
+					}
+
+				`,
+				ExpectError: regexp.MustCompile(`exited with code 3`),
+			},
+			// test case 4 - failed command allowed
+			{
+				Config: testAccContainerExecContainer + `
+					resource "qnap_container_exec" "fail" {
+					container_id = qnap_container.exec.id
+					command = ["sh", "-c", "exit 3"]
+					fail_on_error = false
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container_exec.fail", "exit_code", "3"),
+				),
+			},
+		},
+	})
+}
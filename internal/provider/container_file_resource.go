@@ -0,0 +1,358 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	gopath "path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &containerFileResource{}
+	_ resource.ResourceWithConfigure      = &containerFileResource{}
+	_ resource.ResourceWithValidateConfig = &containerFileResource{}
+	_ resource.ResourceWithModifyPlan     = &containerFileResource{}
+)
+
+type ContainerFileSpecModel struct {
+	ID            basetypes.StringValue `tfsdk:"id"`
+	ContainerID   basetypes.StringValue `tfsdk:"container_id"`
+	ContainerType basetypes.StringValue `tfsdk:"container_type"`
+	Volume        basetypes.StringValue `tfsdk:"volume"`
+	Path          basetypes.StringValue `tfsdk:"path"`
+	Content       basetypes.StringValue `tfsdk:"content"`
+	Source        basetypes.StringValue `tfsdk:"source"`
+	Mode          basetypes.StringValue `tfsdk:"mode"`
+	ContentSHA256 basetypes.StringValue `tfsdk:"content_sha256"`
+	LastUpdated   basetypes.StringValue `tfsdk:"last_updated"`
+}
+
+// containerFileResource is the resource implementation.
+type containerFileResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewContainerFileResource is a helper function to simplify the provider implementation.
+func NewContainerFileResource() resource.Resource {
+	return &containerFileResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *containerFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_file"
+}
+
+// Schema defines the schema for the resource.
+func (r *containerFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Uploads a file into a container or a named volume, for example a configuration file. The file is uploaded again when its content or mode change, the SHA-256 of the content is tracked in state. The file is left in place when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the file, the container ID or volume name and the path of the file.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"container_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the container to upload the file into (e.g. the id of qnap_container).",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("volume")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"container_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "The type of the container (docker, lxd). Defaults to docker.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("docker", "lxd"),
+					stringvalidator.AlsoRequires(path.MatchRoot("container_id")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"volume": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the volume to upload the file into (e.g. the name of qnap_volume).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Required:    true,
+				Description: "The path of the file, absolute in a container (e.g. '/etc/nginx/conf.d/default.conf') or relative to the root of a volume (e.g. 'conf.d/default.conf'). Missing directories are created.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`[^/]$`), "Path must be the path of a file, not of a directory."),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Optional:    true,
+				Description: "The content of the file.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("source")),
+				},
+			},
+			"source": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of a file on the machine running Terraform to upload. Changes of the file are detected on plan.",
+			},
+			"mode": schema.StringAttribute{
+				Optional:    true,
+				Description: "The permissions of the file in octal notation (e.g. '0600'). Defaults to 0644.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^0?[0-7]{3}$`), "Mode must be octal permissions (e.g. '0644')."),
+				},
+			},
+			"content_sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "The SHA-256 of the content of the file.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the last upload.",
+			},
+		},
+	}
+}
+
+// Create uploads the file.
+func (r *containerFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan ContainerFileSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upload(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	target := plan.ContainerID.ValueString()
+	if !plan.Volume.IsNull() {
+		target = plan.Volume.ValueString()
+	}
+	plan.ID = types.StringValue(target + ":" + plan.Path.ValueString())
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the last uploaded content in state, the file is not read back from the NAS.
+func (r *containerFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update uploads the file again with the new content or mode.
+func (r *containerFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan ContainerFileSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upload(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the file from the Terraform state, the file itself is kept.
+func (r *containerFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// ValidateConfig checks that the path of a file in a container is absolute.
+func (r *containerFileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var containerID, filePath types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("container_id"), &containerID)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("path"), &filePath)...)
+	if resp.Diagnostics.HasError() || containerID.IsNull() || filePath.IsNull() || filePath.IsUnknown() {
+		return
+	}
+
+	if !strings.HasPrefix(filePath.ValueString(), "/") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("path"),
+			"Invalid Path",
+			"The path of a file in a container must be absolute, got "+filePath.ValueString()+".",
+		)
+	}
+}
+
+// ModifyPlan plans the SHA-256 of the content, so changes of the source file are planned as an update.
+func (r *containerFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ContainerFileSpecModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Content.IsUnknown() || plan.Source.IsUnknown() {
+		return
+	}
+
+	content, diags := fileContent(&plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	hash := types.StringValue(contentSHA256(content))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_sha256"), hash)...)
+
+	// A changed source file is the only change of the plan, the file is uploaded again at a new time
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var state ContainerFileSpecModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !hash.Equal(state.ContentSHA256) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_updated"), types.StringUnknown())...)
+	}
+}
+
+// upload uploads the file of the plan into its container or volume and sets the computed attributes of the plan.
+func (r *containerFileResource) upload(ctx context.Context, plan *ContainerFileSpecModel) diag.Diagnostics {
+	content, diags := fileContent(plan)
+	if diags.HasError() {
+		return diags
+	}
+
+	mode := int64(0o644)
+	if !plan.Mode.IsNull() {
+		mode, _ = strconv.ParseInt(plan.Mode.ValueString(), 8, 64)
+	}
+
+	var err error
+	if !plan.Volume.IsNull() {
+		name := strings.TrimPrefix(gopath.Clean("/"+plan.Path.ValueString()), "/")
+		var archive string
+		archive, err = fileArchive(name, content, mode)
+		if err == nil {
+			err = seedVolume(ctx, r.client, plan.Volume.ValueString(), volumeContentSpec{Content: archive})
+		}
+	} else {
+		containerType := plan.ContainerType.ValueString()
+		if containerType == "" {
+			containerType = "docker"
+		}
+		var archive string
+		archive, err = fileArchive(gopath.Base(plan.Path.ValueString()), content, mode)
+		if err == nil {
+			err = copyToContainer(ctx, r.client, plan.ContainerID.ValueString(), containerType, containerArchiveSpec{
+				Path:    gopath.Dir(plan.Path.ValueString()),
+				Content: archive,
+			})
+		}
+	}
+	if err != nil {
+		diags.AddError(
+			"Error uploading file",
+			errorDetail("Could not upload file "+plan.Path.ValueString(), err),
+		)
+		return diags
+	}
+
+	plan.ContentSHA256 = types.StringValue(contentSHA256(content))
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	return diags
+}
+
+// fileContent returns the content of the file, read from the source file when there is no inline content.
+func fileContent(plan *ContainerFileSpecModel) ([]byte, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if plan.Source.IsNull() {
+		return []byte(plan.Content.ValueString()), diags
+	}
+
+	content, err := os.ReadFile(plan.Source.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("source"),
+			"Unable to Read Source File",
+			errorDetail("Could not read "+plan.Source.ValueString(), err),
+		)
+		return nil, diags
+	}
+	return content, diags
+}
+
+// contentSHA256 returns the hex encoded SHA-256 of the content.
+func contentSHA256(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *containerFileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
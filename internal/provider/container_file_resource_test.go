@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+const testAccContainerFileContainer = `
+					resource "qnap_container" "file" {
+						name = "terraform_test_file"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+					}
+`
+
+func TestAccContainerFileResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: testAccContainerFileContainer + `
+					resource "qnap_container_file" "index" {
+					container_id = qnap_container.file.id
+					path = "/usr/share/nginx/html/index.html"
+					content = "hello"
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container_file.index", "content_sha256", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"),
+					resource.TestCheckResourceAttrSet("qnap_container_file.index", "last_updated"),
+				),
+			},
+			// test case 2 - changed content is uploaded in place
+			{
+				Config: testAccContainerFileContainer + `
+					resource "qnap_container_file" "index" {
+					container_id = qnap_container.file.id
+					path = "/usr/share/nginx/html/index.html"
+					content = "hello again"
+					mode = "0600"
+					}
+
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container_file.index", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container_file.index", "mode", "0600"),
+				),
+			},
+			// test case 3 - relative path in a container
+			{
+				Config: testAccContainerFileContainer + `
+					resource "qnap_container_file" "relative" {
+					container_id = qnap_container.file.id
+					path = "index.html"
+					content = "hello"
+					}
+
+				`,
+				ExpectError: regexp.MustCompile(`must be absolute`),
+			},
+		},
+	})
+}
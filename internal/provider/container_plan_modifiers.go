@@ -0,0 +1,454 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// recreateInPlaceAttributes are the container attributes applied by recreating the container
+// under the same name, which assigns it a new ID: every attribute of the create specification
+// that cannot be changed on the existing container and is not applied by a blue/green replacement,
+// a network reconnection, a limits update or a status change.
+var recreateInPlaceAttributes = []string{
+	"env", "labels", "hostname", "portbindings", "restartpolicy", "autoremove", "cmd", "entrypoint", "tty",
+	"openstdin", "dns", "volumes", "tmpfs", "runtime", "privileged", "devices", "cpupin",
+}
+
+// blueGreenAttributes are the container attributes that require replacing the container, which is done in place by a
+// blue/green replacement when replace_strategy is blue_green.
+var blueGreenAttributes = []string{"image", "image_digest", "dns_search", "dns_options", "memory_swap_limit", "memory_swappiness"}
+
+// replaceStrategyBlueGreen is the replace_strategy that starts the new container before removing the old one.
+const replaceStrategyBlueGreen = "blue_green"
+
+// networkAttributes are the container attributes applied by reconnecting the container to its network.
+var networkAttributes = []string{"network", "networktype", "ipaddress", "ipaddress6"}
+
+// stringUseStateForUnknownUnlessRecreated copies the prior state value into the plan like
+// UseStateForUnknown, unless the update recreates the container in place.
+func stringUseStateForUnknownUnlessRecreated() planmodifier.String {
+	return useStateForUnknownUnlessRecreatedModifier{}
+}
+
+// stringUseStateForUnknownUnlessReconnected is stringUseStateForUnknownUnlessRecreated for values
+// that also change when the container is reconnected to another network.
+func stringUseStateForUnknownUnlessReconnected() planmodifier.String {
+	return useStateForUnknownUnlessRecreatedModifier{reconnect: true}
+}
+
+// listUseStateForUnknownUnlessReconnected is the list variant of stringUseStateForUnknownUnlessReconnected.
+func listUseStateForUnknownUnlessReconnected() planmodifier.List {
+	return useStateForUnknownUnlessRecreatedModifier{reconnect: true}
+}
+
+type useStateForUnknownUnlessRecreatedModifier struct {
+	reconnect bool
+}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m useStateForUnknownUnlessRecreatedModifier) Description(_ context.Context) string {
+	if m.reconnect {
+		return "Once set, the value of this attribute in state will not change unless the container is recreated in place or reconnected to another network."
+	}
+	return "Once set, the value of this attribute in state will not change unless the container is recreated in place."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior.
+func (m useStateForUnknownUnlessRecreatedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements the plan modification logic.
+func (m useStateForUnknownUnlessRecreatedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if m.useState(ctx, req.StateValue, req.PlanValue, req.Plan.Raw.IsNull(), req.Config, req.State, &resp.Diagnostics) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// PlanModifyList implements the plan modification logic.
+func (m useStateForUnknownUnlessRecreatedModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if m.useState(ctx, req.StateValue, req.PlanValue, req.Plan.Raw.IsNull(), req.Config, req.State, &resp.Diagnostics) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// useState reports whether the prior state value should be copied into the plan.
+func (m useStateForUnknownUnlessRecreatedModifier) useState(ctx context.Context, stateValue attr.Value, planValue attr.Value, destroy bool, config attributeGetter, state attributeGetter, diagnostics *diag.Diagnostics) bool {
+	// Do nothing on resource creation, destroy or when the value is already known
+	if stateValue.IsNull() || destroy || !planValue.IsUnknown() {
+		return false
+	}
+
+	recreated, diags := containerRecreatedInPlace(ctx, config, state)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() || recreated {
+		return false
+	}
+	if !m.reconnect {
+		return true
+	}
+
+	reconnected, diags := containerReconnected(ctx, config, state)
+	diagnostics.Append(diags...)
+	return !diagnostics.HasError() && !reconnected
+}
+
+// attributeGetter is implemented by tfsdk.Config, tfsdk.Plan and tfsdk.State.
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, path path.Path, target interface{}) diag.Diagnostics
+}
+
+// containerRecreatedInPlace reports whether the configuration changes any of the attributes
+// that are applied by recreating the container in place.
+func containerRecreatedInPlace(ctx context.Context, config attributeGetter, state attributeGetter) (bool, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+	for _, attribute := range recreateInPlaceAttributes {
+		var configValue, stateValue attr.Value
+		diagnostics.Append(config.GetAttribute(ctx, path.Root(attribute), &configValue)...)
+		diagnostics.Append(state.GetAttribute(ctx, path.Root(attribute), &stateValue)...)
+		if diagnostics.HasError() {
+			return false, diagnostics
+		}
+		if !configMatchesState(configValue, stateValue) {
+			return true, diagnostics
+		}
+	}
+
+	blueGreen, diags := blueGreenConfigured(ctx, config)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() || !blueGreen {
+		return false, diagnostics
+	}
+	for _, attribute := range blueGreenAttributes {
+		var configValue, stateValue attr.Value
+		diagnostics.Append(config.GetAttribute(ctx, path.Root(attribute), &configValue)...)
+		diagnostics.Append(state.GetAttribute(ctx, path.Root(attribute), &stateValue)...)
+		if diagnostics.HasError() {
+			return false, diagnostics
+		}
+
+		// Unset optional computed attributes keep their state value
+		if configValue.IsNull() {
+			continue
+		}
+		if configValue.IsUnknown() || !configValue.Equal(stateValue) {
+			return true, diagnostics
+		}
+	}
+	return false, diagnostics
+}
+
+// configMatchesState reports whether a configured value matches the state value. Unset optional computed attributes,
+// also those nested in lists, maps and objects, keep their state value and match any state value.
+func configMatchesState(configValue attr.Value, stateValue attr.Value) bool {
+	if configValue.IsNull() {
+		return true
+	}
+	if configValue.IsUnknown() || stateValue.IsNull() || stateValue.IsUnknown() {
+		return false
+	}
+
+	switch config := configValue.(type) {
+	case interface{ Attributes() map[string]attr.Value }:
+		state, ok := stateValue.(interface{ Attributes() map[string]attr.Value })
+		if !ok {
+			return false
+		}
+		stateAttributes := state.Attributes()
+		for name, value := range config.Attributes() {
+			stateAttribute, found := stateAttributes[name]
+			if !found || !configMatchesState(value, stateAttribute) {
+				return false
+			}
+		}
+		return true
+	case interface{ Elements() []attr.Value }:
+		state, ok := stateValue.(interface{ Elements() []attr.Value })
+		if !ok {
+			return false
+		}
+		configElements, stateElements := config.Elements(), state.Elements()
+		if len(configElements) != len(stateElements) {
+			return false
+		}
+		for i := range configElements {
+			if !configMatchesState(configElements[i], stateElements[i]) {
+				return false
+			}
+		}
+		return true
+	case interface{ Elements() map[string]attr.Value }:
+		state, ok := stateValue.(interface{ Elements() map[string]attr.Value })
+		if !ok {
+			return false
+		}
+		configElements, stateElements := config.Elements(), state.Elements()
+		if len(configElements) != len(stateElements) {
+			return false
+		}
+		for key, value := range configElements {
+			stateElement, found := stateElements[key]
+			if !found || !configMatchesState(value, stateElement) {
+				return false
+			}
+		}
+		return true
+	}
+	return configValue.Equal(stateValue)
+}
+
+// blueGreenConfigured reports whether replace_strategy is blue_green.
+func blueGreenConfigured(ctx context.Context, config attributeGetter) (bool, diag.Diagnostics) {
+	var strategy types.String
+	diags := config.GetAttribute(ctx, path.Root("replace_strategy"), &strategy)
+	return strategy.ValueString() == replaceStrategyBlueGreen, diags
+}
+
+// planRecreatedInPlace marks the computed attributes that change when the container is recreated as unknown, unless
+// they are set in the configuration.
+func planRecreatedInPlace(ctx context.Context, config attributeGetter, plan *tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, attribute := range []string{"id", "ipaddress", "ipaddress6", "networks", "rendered_spec"} {
+		var configValue, planValue attr.Value
+		diags.Append(config.GetAttribute(ctx, path.Root(attribute), &configValue)...)
+		diags.Append(plan.GetAttribute(ctx, path.Root(attribute), &planValue)...)
+		if diags.HasError() {
+			return diags
+		}
+		if !configValue.IsNull() {
+			continue
+		}
+
+		valueType := planValue.Type(ctx)
+		unknown, err := valueType.ValueFromTerraform(ctx, tftypes.NewValue(valueType.TerraformType(ctx), tftypes.UnknownValue))
+		if err != nil {
+			diags.AddError("Unable to Plan Recreation", err.Error())
+			return diags
+		}
+		diags.Append(plan.SetAttribute(ctx, path.Root(attribute), unknown)...)
+	}
+	return diags
+}
+
+// containerReconnected reports whether the configuration changes the network the container is connected to.
+func containerReconnected(ctx context.Context, config attributeGetter, state attributeGetter) (bool, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+	for _, attribute := range networkAttributes {
+		var configValue, stateValue types.String
+		diagnostics.Append(config.GetAttribute(ctx, path.Root(attribute), &configValue)...)
+		diagnostics.Append(state.GetAttribute(ctx, path.Root(attribute), &stateValue)...)
+		if diagnostics.HasError() {
+			return false, diagnostics
+		}
+
+		// Unset optional computed attributes keep their state value
+		if configValue.IsNull() {
+			continue
+		}
+		if configValue.IsUnknown() || !configValue.Equal(stateValue) {
+			return true, diagnostics
+		}
+	}
+
+	var configAliases, stateAliases types.List
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("network_aliases"), &configAliases)...)
+	diagnostics.Append(state.GetAttribute(ctx, path.Root("network_aliases"), &stateAliases)...)
+	if diagnostics.HasError() || configAliases.IsNull() {
+		return false, diagnostics
+	}
+	return configAliases.IsUnknown() || !configAliases.Equal(stateAliases), diagnostics
+}
+
+// isolatedNetworkTypes are the network types a container cannot be connected to or disconnected from in place.
+var isolatedNetworkTypes = map[string]bool{
+	"host": true,
+	"none": true,
+}
+
+// requiresReplaceForIsolatedNetwork requires the replacement of the container when it is moved to or from
+// the host or none network, any other network change is applied in place.
+func requiresReplaceForIsolatedNetwork() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			var planType, stateType types.String
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("networktype"), &planType)...)
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("networktype"), &stateType)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.RequiresReplace = planType.IsUnknown() || isolatedNetworkTypes[planType.ValueString()] || isolatedNetworkTypes[stateType.ValueString()]
+		},
+		"Moving the container to or from the host or none network requires replacing it.",
+		"Moving the container to or from the host or none network requires replacing it.",
+	)
+}
+
+// requiresReplaceUnlessBlueGreen returns the condition of the RequiresReplaceIf plan modifiers of blueGreenAttributes:
+// a change requires replacing the container unless replace_strategy is blue_green, then it is replaced in place.
+func requiresReplaceUnlessBlueGreen(ctx context.Context, config attributeGetter) (bool, diag.Diagnostics) {
+	blueGreen, diags := blueGreenConfigured(ctx, config)
+	return !blueGreen, diags
+}
+
+// stringRequiresReplaceUnlessBlueGreen is RequiresReplace unless replace_strategy is blue_green.
+func stringRequiresReplaceUnlessBlueGreen() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			var diags diag.Diagnostics
+			resp.RequiresReplace, diags = requiresReplaceUnlessBlueGreen(ctx, req.Config)
+			resp.Diagnostics.Append(diags...)
+		},
+		blueGreenReplaceDescription,
+		blueGreenReplaceDescription,
+	)
+}
+
+// listRequiresReplaceUnlessBlueGreen is the list variant of stringRequiresReplaceUnlessBlueGreen.
+func listRequiresReplaceUnlessBlueGreen() planmodifier.List {
+	return listplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+			var diags diag.Diagnostics
+			resp.RequiresReplace, diags = requiresReplaceUnlessBlueGreen(ctx, req.Config)
+			resp.Diagnostics.Append(diags...)
+		},
+		blueGreenReplaceDescription,
+		blueGreenReplaceDescription,
+	)
+}
+
+// int32RequiresReplaceUnlessBlueGreen is the int32 variant of stringRequiresReplaceUnlessBlueGreen.
+func int32RequiresReplaceUnlessBlueGreen() planmodifier.Int32 {
+	return int32planmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.Int32Request, resp *int32planmodifier.RequiresReplaceIfFuncResponse) {
+			var diags diag.Diagnostics
+			resp.RequiresReplace, diags = requiresReplaceUnlessBlueGreen(ctx, req.Config)
+			resp.Diagnostics.Append(diags...)
+		},
+		blueGreenReplaceDescription,
+		blueGreenReplaceDescription,
+	)
+}
+
+const blueGreenReplaceDescription = "Changing this attribute requires replacing the container, in place when replace_strategy is blue_green."
+
+// The impacts of an update on the running container, from the least to the most disruptive.
+const (
+	updateImpactNone    = "none"
+	updateImpactLive    = "live_update"
+	updateImpactRestart = "restart"
+	updateImpactReplace = "replace"
+)
+
+// restartAttributes are the container attributes applied by restarting, stopping or starting the container.
+var restartAttributes = []string{"restart_triggers", "status"}
+
+// liveUpdateAttributes are the container attributes applied to the running container, in addition to the
+// networkAttributes.
+var liveUpdateAttributes = []string{"name", "cpu_limit", "mem_limit", "auto_update"}
+
+// replaceAttributes are the container attributes that always require replacing the container.
+var replaceAttributes = []string{"type", "project"}
+
+// providerAttributes are the container attributes that only configure the provider or are computed by it, changing
+// them leaves the container as it is. Every other attribute is classified by the impact of changing it.
+var providerAttributes = []string{
+	"id", "networks", "last_updated", "rendered_spec", "update_impact", "removeanonvolumes", "remove_image_on_destroy",
+	"stop_grace_period", "force_destroy", "deletion_protection", "registry_auth", "ignore_image_env",
+	"validate_host_paths", "adopt_recreated", "readiness", "depends_on_containers", "wait_for_healthy",
+	"track_remote_digest", "replace_strategy", "name_suffix_on_replace",
+}
+
+// updateImpactPlanModifier plans the impact of the changes of an apply on the running container.
+func updateImpactPlanModifier() planmodifier.String {
+	return updateImpactModifier{}
+}
+
+type updateImpactModifier struct{}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m updateImpactModifier) Description(_ context.Context) string {
+	return "The value is planned from the attributes the apply changes and kept when nothing changes."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior.
+func (m updateImpactModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements the plan modification logic. The value is only unknown when the plan changes the
+// container, a replacement is planned again without prior state.
+func (m updateImpactModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.Plan.Raw.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+	if req.State.Raw.IsNull() {
+		resp.PlanValue = types.StringValue(updateImpactReplace)
+		return
+	}
+
+	impact, diags := containerUpdateImpact(ctx, req.Config, req.Plan, req.State)
+	resp.Diagnostics.Append(diags...)
+	if !resp.Diagnostics.HasError() {
+		resp.PlanValue = types.StringValue(impact)
+	}
+}
+
+// containerUpdateImpact returns the most disruptive impact of the changes the plan applies in place.
+func containerUpdateImpact(ctx context.Context, config attributeGetter, plan attributeGetter, state attributeGetter) (string, diag.Diagnostics) {
+	recreated, diags := containerRecreatedInPlace(ctx, config, state)
+	if diags.HasError() || recreated {
+		return updateImpactReplace, diags
+	}
+
+	changed := func(attributes []string) bool {
+		for _, attribute := range attributes {
+			var configValue, planValue, stateValue attr.Value
+			diags.Append(config.GetAttribute(ctx, path.Root(attribute), &configValue)...)
+			diags.Append(plan.GetAttribute(ctx, path.Root(attribute), &planValue)...)
+			diags.Append(state.GetAttribute(ctx, path.Root(attribute), &stateValue)...)
+			if diags.HasError() {
+				return false
+			}
+
+			// Unset optional computed attributes keep their state value
+			if planValue.IsUnknown() {
+				if !configValue.IsNull() {
+					return true
+				}
+				continue
+			}
+			if !planValue.Equal(stateValue) {
+				return true
+			}
+		}
+		return false
+	}
+	if changed(replaceAttributes) || changed(blueGreenAttributes) {
+		return updateImpactReplace, diags
+	}
+	if changed(restartAttributes) {
+		return updateImpactRestart, diags
+	}
+	if changed(liveUpdateAttributes) {
+		return updateImpactLive, diags
+	}
+
+	reconnected, reconnectDiags := containerReconnected(ctx, config, state)
+	diags.Append(reconnectDiags...)
+	if reconnected {
+		return updateImpactLive, diags
+	}
+
+	// Only the providerAttributes are left
+	return updateImpactNone, diags
+}
@@ -2,16 +2,24 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
@@ -31,8 +39,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &containerResource{}
-	_ resource.ResourceWithConfigure = &containerResource{}
+	_ resource.Resource                     = &containerResource{}
+	_ resource.ResourceWithConfigure        = &containerResource{}
+	_ resource.ResourceWithValidateConfig   = &containerResource{}
+	_ resource.ResourceWithConfigValidators = &containerResource{}
+	_ resource.ResourceWithModifyPlan       = &containerResource{}
 )
 
 type ContainerSpecModel struct {
@@ -40,37 +51,69 @@ type ContainerSpecModel struct {
 	Type              basetypes.StringValue `tfsdk:"type"`
 	Name              basetypes.StringValue `tfsdk:"name"`
 	Image             basetypes.StringValue `tfsdk:"image"`
+	ImageDigest       basetypes.StringValue `tfsdk:"image_digest"`
+	RegistryAuth      basetypes.ObjectValue `tfsdk:"registry_auth"`
 	IPAddress         basetypes.StringValue `tfsdk:"ipaddress"`
+	IPAddress6        basetypes.StringValue `tfsdk:"ipaddress6"`
 	AutoRemove        basetypes.BoolValue   `tfsdk:"autoremove"`
 	Tty               basetypes.BoolValue   `tfsdk:"tty"`
 	OpenStdin         basetypes.BoolValue   `tfsdk:"openstdin"`
 	Network           basetypes.StringValue `tfsdk:"network"`
+	NetworkAliases    basetypes.ListValue   `tfsdk:"network_aliases"`
 	NetworkType       basetypes.StringValue `tfsdk:"networktype"`
 	Hostname          basetypes.StringValue `tfsdk:"hostname"`
+	Project           basetypes.StringValue `tfsdk:"project"`
 	LastUpdated       types.String          `tfsdk:"last_updated"`
 	Runtime           basetypes.StringValue `tfsdk:"runtime"`
 	Privileged        basetypes.BoolValue   `tfsdk:"privileged"`
 	RemoveAnonVolumes basetypes.BoolValue   `tfsdk:"removeanonvolumes"`
+	RemoveImage       basetypes.BoolValue   `tfsdk:"remove_image_on_destroy"`
+	StopGracePeriod   basetypes.StringValue `tfsdk:"stop_grace_period"`
+	ForceDestroy      basetypes.BoolValue   `tfsdk:"force_destroy"`
+	DeletionProtect   basetypes.BoolValue   `tfsdk:"deletion_protection"`
 	Env               basetypes.MapValue    `tfsdk:"env"`
+	IgnoreImageEnv    basetypes.BoolValue   `tfsdk:"ignore_image_env"`
+	ValidateHostPaths basetypes.BoolValue   `tfsdk:"validate_host_paths"`
+	AdoptRecreated    basetypes.BoolValue   `tfsdk:"adopt_recreated"`
+	RestartTriggers   basetypes.MapValue    `tfsdk:"restart_triggers"`
+	Readiness         *readinessModel       `tfsdk:"readiness"`
+	DependsOn         basetypes.ListValue   `tfsdk:"depends_on_containers"`
+	WaitForHealthy    basetypes.BoolValue   `tfsdk:"wait_for_healthy"`
+	AutoUpdate        *autoUpdateModel      `tfsdk:"auto_update"`
+	TrackRemoteDigest basetypes.BoolValue   `tfsdk:"track_remote_digest"`
+	ReplaceStrategy   basetypes.StringValue `tfsdk:"replace_strategy"`
+	NameSuffix        basetypes.BoolValue   `tfsdk:"name_suffix_on_replace"`
+	RenderedSpec      basetypes.StringValue `tfsdk:"rendered_spec"`
+	UpdateImpact      basetypes.StringValue `tfsdk:"update_impact"`
+	Timeouts          *timeoutsModel        `tfsdk:"timeouts"`
 	Labels            basetypes.MapValue    `tfsdk:"labels"`
 	Devices           basetypes.ListValue   `tfsdk:"devices"`
 	Volumes           basetypes.ListValue   `tfsdk:"volumes"`
-	PortBindings      basetypes.ListValue   `tfsdk:"portbindings"`
+	Tmpfs             basetypes.ListValue   `tfsdk:"tmpfs"`
+	PortBindings      portBindingsValue     `tfsdk:"portbindings"`
 	Networks          basetypes.ListValue   `tfsdk:"networks"`
 	Cpupin            basetypes.ObjectValue `tfsdk:"cpupin"`
 	RestartPolicy     basetypes.ObjectValue `tfsdk:"restartpolicy"`
 	Cmd               types.List            `tfsdk:"cmd"`
 	Entrypoint        basetypes.ListValue   `tfsdk:"entrypoint"`
 	DNS               basetypes.ListValue   `tfsdk:"dns"`
+	DNSSearch         basetypes.ListValue   `tfsdk:"dns_search"`
+	DNSOptions        basetypes.ListValue   `tfsdk:"dns_options"`
 	Status            basetypes.StringValue `tfsdk:"status"`
+	CPULimit          basetypes.Int32Value  `tfsdk:"cpu_limit"`
+	MemLimit          basetypes.Int32Value  `tfsdk:"mem_limit"`
+	MemSwapLimit      basetypes.Int32Value  `tfsdk:"memory_swap_limit"`
+	MemSwappiness     basetypes.Int32Value  `tfsdk:"memory_swappiness"`
 }
 type NetworkModel struct {
 	ID          basetypes.StringValue `tfsdk:"id"`
 	Name        basetypes.StringValue `tfsdk:"name"`
 	IPAddress   basetypes.StringValue `tfsdk:"ipaddress"`
+	IPAddress6  basetypes.StringValue `tfsdk:"ipaddress6"`
 	DisplayName basetypes.StringValue `tfsdk:"displayname"`
 	MACAddress  basetypes.StringValue `tfsdk:"macaddress"`
 	Gateway     basetypes.StringValue `tfsdk:"gateway"`
+	Gateway6    basetypes.StringValue `tfsdk:"gateway6"`
 	NetworkType basetypes.StringValue `tfsdk:"networktype"`
 	IsStaticIP  basetypes.BoolValue   `tfsdk:"isstaticip"`
 }
@@ -96,6 +139,11 @@ type VolumesModel struct {
 	Destination basetypes.StringValue `tfsdk:"destination"`
 	Permission  basetypes.StringValue `tfsdk:"permission"`
 }
+type TmpfsModel struct {
+	Destination basetypes.StringValue `tfsdk:"destination"`
+	Size        basetypes.StringValue `tfsdk:"size"`
+	Mode        basetypes.StringValue `tfsdk:"mode"`
+}
 type DevicesModel struct {
 	Name       basetypes.StringValue `tfsdk:"name"`
 	Permission basetypes.StringValue `tfsdk:"permission"`
@@ -103,7 +151,9 @@ type DevicesModel struct {
 
 // containerResource is the resource implementation.
 type containerResource struct {
-	client *qnap.Client
+	client   *qnap.Client
+	timeouts operationTimeouts
+	notifier *notifier
 }
 
 // NewContainerResource is a helper function to simplify the provider implementation.
@@ -119,16 +169,33 @@ func (r *containerResource) Metadata(_ context.Context, req resource.MetadataReq
 // Schema defines the schema for the resource.
 func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 		Attributes: map[string]schema.Attribute{
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
 				Description: "The last updated timestamp of the container.",
 			},
+			"rendered_spec": schema.StringAttribute{
+				Computed:    true,
+				Description: "The JSON specification submitted to the NAS when the container was last created or recreated, to archive the deployed manifest or diff it outside of Terraform.",
+				PlanModifiers: []planmodifier.String{
+					stringUseStateForUnknownUnlessRecreated(),
+				},
+			},
+			"update_impact": schema.StringAttribute{
+				Computed:    true,
+				Description: "The impact of the last planned change on the running container, shown in the plan so the downtime of an apply is known in advance: none when only provider settings change, live_update when the change is applied to the running container (name, limits, auto_update, network), restart when the container is restarted, stopped or started (restart_triggers, status) and replace for every other change, which creates a new container (replaced, recreated in place or created for the first time).",
+				PlanModifiers: []planmodifier.String{
+					updateImpactPlanModifier(),
+				},
+			},
 			"status": schema.StringAttribute{
 				Required:    true,
-				Description: "The state of the container (running, stopped).",
+				Description: "The state of the container (running, stopped, paused). A paused container keeps its memory but its processes are frozen until it is running again.",
 				Validators: []validator.String{
-					stringvalidator.OneOf("running", "stopped"),
+					stringvalidator.OneOf("running", "stopped", "paused"),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -141,11 +208,30 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"remove_image_on_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to remove the image of the container when it is destroyed, unless another container still uses it. Defaults to false.",
+			},
+			"stop_grace_period": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long to wait for the container to exit when it is stopped before it is destroyed, after which it is killed (e.g. '30s', '2m'). Defaults to the grace period of Container Station.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^([0-9]+(h|m|s))+$`), "Stop grace period must be a duration (e.g. '10s', '2m')."),
+				},
+			},
+			"force_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to kill the container immediately when it is destroyed instead of stopping it gracefully. Defaults to false.",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to refuse to destroy or replace the container until this is set to false and applied. Defaults to false.",
+			},
 			"id": schema.StringAttribute{
 				Computed:    true,
-				Description: "The ID of the container.",
+				Description: "The ID of the container. Changes when an attribute of the container specification that cannot be changed on the existing container is updated (e.g. env, labels, portbindings, volumes, cmd), as the container is recreated in place.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					stringUseStateForUnknownUnlessRecreated(),
 				},
 			},
 			"ipaddress": schema.StringAttribute{
@@ -156,7 +242,18 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					stringvalidator.RegexMatches(regexp.MustCompile(`^(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$`), "IP Address must be in a valid format (e.g. 0.0.0.0')."),
 				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					stringUseStateForUnknownUnlessReconnected(),
+				},
+			},
+			"ipaddress6": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: "The IPv6 address assigned to the container, the network must have IPv6 enabled.",
+				Validators: []validator.String{
+					ipv6Address(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringUseStateForUnknownUnlessReconnected(),
 				},
 			},
 			"type": schema.StringAttribute{
@@ -172,7 +269,7 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
-				Description: "The name of the container.",
+				Description: "The name of the container. Changing the name renames the container in place.",
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(1, 64),
 					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{1,63}$`), "Container name must be between 2 and 64 characters, starts with a letter or number. Valid characters: letters (A-Z, a-z), numbers (0-9), hyphen (-), period (.), underscore (_)"),
@@ -182,20 +279,60 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Required:    true,
 				Description: "The image of the container.",
 				Validators: []validator.String{
-					stringvalidator.RegexMatches(regexp.MustCompile(`^(?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)?[a-z0-9]+(?:[._-][a-z0-9]+)*(?::[a-z0-9]+(?:[._-][a-z0-9]+)*)?$`), "Image name must be in a valid format (e.g. 'nginx:latest', 'myregistry.local:5000/nginx:latest')."),
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(?:[a-z0-9.-]+(?::[0-9]+)?/)?[a-z0-9]+(?:[._-][a-z0-9]+)*(?:/[a-z0-9]+(?:[._-][a-z0-9]+)*)*(?::[A-Za-z0-9_][A-Za-z0-9_.-]{0,127})?$`), "Image name must be in a valid format (e.g. 'nginx:latest', 'myregistry.local:5000/nginx:latest', 'ghcr.io/owner/app:v1')."),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplaceUnlessBlueGreen(),
+				},
+			},
+			"image_digest": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The digest the container image is pinned to, either the image ID or the repository digest of the image (e.g. the id or digest of qnap_image). When set, the container is replaced if the image it runs does not match the digest anymore. Defaults to the image ID of the running container.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^sha256:[a-f0-9]{64}$`), "Image digest must be in the format 'sha256:<64 hex characters>'."),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringUseStateForUnknownUnlessRecreated(),
+					stringRequiresReplaceUnlessBlueGreen(),
+				},
+			},
+			"track_remote_digest": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to replace the container when the tag of its image points to a new image in the registry, so an apply upgrades it. The digest of the tag is resolved from the machine running Terraform on refresh, with the credentials of registry_auth, and the image is pulled again before the container is created. Conflicts with image_digest. Defaults to false.",
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("image_digest")),
+				},
+			},
+			"registry_auth": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "The credentials used to pull the image from a private registry before the container is created. The password is stored in state as a sensitive value.",
+				Attributes: map[string]schema.Attribute{
+					"server_address": schema.StringAttribute{
+						Optional:    true,
+						Description: "The address of the registry (e.g. 'ghcr.io'). Defaults to Docker Hub.",
+					},
+					"username": schema.StringAttribute{
+						Required:    true,
+						Description: "The username for the registry.",
+					},
+					"password": schema.StringAttribute{
+						Required:    true,
+						Sensitive:   true,
+						Description: "The password or access token for the registry.",
+					},
 				},
 			},
 			"portbindings": schema.ListNestedAttribute{
-				Optional: true,
-				Computed: true,
+				Optional:   true,
+				Computed:   true,
+				CustomType: newPortBindingsType(portBindingAttrTypes),
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
 				},
 				NestedObject: schema.NestedAttributeObject{
+					CustomType: newPortBindingType(portBindingAttrTypes),
 					Attributes: map[string]schema.Attribute{
 						"host": schema.Int32Attribute{
 							Optional:    true,
@@ -233,9 +370,9 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 						"hostip": schema.StringAttribute{
 							Optional:    true,
 							Computed:    true,
-							Description: "The host IP address.",
+							Description: "The host IP address, either IPv4 (e.g. 0.0.0.0) or IPv6 (e.g. ::).",
 							Validators: []validator.String{
-								stringvalidator.RegexMatches(regexp.MustCompile(`^(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$`), "IP Address must be in a valid format (e.g. 0.0.0.0')."),
+								ipAddress(),
 							},
 							PlanModifiers: []planmodifier.String{
 								stringplanmodifier.UseStateForUnknown(),
@@ -259,17 +396,20 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					"maximumretrycount": schema.Int32Attribute{
 						Optional:    true,
 						Computed:    true,
-						Description: "The maximum number of retries for the restart policy.",
+						Description: "The maximum number of retries for the restart policy. Only used by the onFailure policy.",
 						Validators: []validator.Int32{
 							int32validator.Between(0, 1000),
 						},
 					},
 				},
+				Validators: []validator.Object{
+					restartPolicyRetryValidator{},
+				},
 			},
 			"autoremove": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "Whether to automatically remove the container when it exits.",
+				Description: "Whether to automatically remove the container when it exits. Requires the no restart policy.",
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.UseStateForUnknown(),
 				},
@@ -310,21 +450,36 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			},
 			"network": schema.StringAttribute{
 				Required:    true,
-				Description: "The network to connect the container to. Examples of network/networktype compinations: default(the NAT network)/bridge, host/default, bridge/ethx (ethx for the ethernet adaptor you are connecting to when selecting bridge).",
+				Description: "The network to connect the container to. Examples of network/networktype compinations: default(the NAT network)/bridge, host/default, bridge/ethx (ethx for the ethernet adaptor you are connecting to when selecting bridge). Changing it reconnects the container in place, except when moving to or from the host or none network which replaces the container.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceForIsolatedNetwork(),
 				},
 			},
 			"networktype": schema.StringAttribute{
 				Required:    true,
-				Description: "The type of the network. Examples of network/networktype compinations: default(the NAT network)/bridge, host/default, bridge/ethx (ethx for the ethernet adaptor you are connecting to when selecting bridge).",
+				Description: "The type of the network. Examples of network/networktype compinations: default(the NAT network)/bridge, host/default, bridge/ethx (ethx for the ethernet adaptor you are connecting to when selecting bridge). Changing it reconnects the container in place, except when moving to or from the host or none network which replaces the container.",
 				Validators: []validator.String{
 					stringvalidator.OneOf("bridge", "host", "none", "ipvlan", "default"),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceForIsolatedNetwork(),
+				},
+			},
+			"network_aliases": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "The DNS aliases of the container on its network, so other containers on the network can reach it by stable names after it is recreated. Changing it reconnects the container in place. Not supported on the host and none networks.",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]{0,62})$`), "Network alias must be up to 63 characters, starts with a letter or number. Valid characters: letters (A-Z, a-z), numbers (0-9), hyphen (-), period (.), underscore (_)"),
+					),
+					listvalidator.UniqueValues(),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"hostname": schema.StringAttribute{
@@ -338,6 +493,18 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"project": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The project the container is grouped under in Container Station. Standalone containers are not part of a project when empty.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^([a-zA-Z0-9](?:[a-zA-Z0-9_-]{0,30}[a-zA-Z0-9])?)?$`), "Project name must be up to 32 characters, Valid characters: letters (a-z), numbers (0-9), hyphen (-), underscore (_)"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"dns": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
@@ -347,20 +514,95 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					listplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"dns_search": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "The DNS search domains for the container (e.g. corp.example.com).",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.)*[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.?$`), "DNS search domain must be a valid domain name (e.g. corp.example.com)."),
+					),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+					listRequiresReplaceUnlessBlueGreen(),
+				},
+			},
+			"dns_options": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "The resolver options for the container, written to the options line of resolv.conf (e.g. ndots:2, timeout:1, rotate).",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z][a-z0-9-]*(:[0-9]+)?$`), "DNS option must be a resolver option with an optional value (e.g. ndots:2, rotate)."),
+					),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+					listRequiresReplaceUnlessBlueGreen(),
+				},
+			},
 			"env": schema.MapAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Computed:    true,
-				Description: "The environment variables for the container.",
+				Description: "The environment variables for the container. Changes are applied by recreating the container in place under the same name.",
 				PlanModifiers: []planmodifier.Map{
 					mapplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"ignore_image_env": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to ignore environment variables injected by the image (e.g. PATH) and only track the keys declared in env. Defaults to false.",
+			},
+			"validate_host_paths": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to check that the source of every host volume exists on the NAS before the container is created, instead of letting docker create an empty directory owned by root. The check uses the File Station API. Defaults to false.",
+			},
+			"adopt_recreated": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to manage the container that replaced this one when it was deleted and recreated with the same name outside of Terraform, for example in the Container Station UI. By default such a container is replaced to match the configuration. Defaults to false.",
+			},
+			"readiness":   readinessAttribute(),
+			"auto_update": autoUpdateAttribute(),
+			"depends_on_containers": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the containers this container depends on (e.g. the id of another qnap_container), like depends_on of docker compose. Before the container is created, recreated or started the apply waits up to 5m until they are running, and healthy with wait_for_healthy, and fails with the pending containers otherwise.",
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+				},
+			},
+			"wait_for_healthy": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether the containers of depends_on_containers that have a health check must also be healthy, like the service_healthy condition of docker compose. Defaults to false.",
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("depends_on_containers")),
+				},
+			},
+			"replace_strategy": schema.StringAttribute{
+				Optional:    true,
+				Description: "How the container is replaced when image, image_digest, dns_search, dns_options, memory_swap_limit or memory_swappiness change, and when the attributes that otherwise recreate the container in place change (e.g. env, labels, portbindings, volumes, cmd). recreate removes the container and creates the new one. blue_green creates the new container next to the old one under a temporary name, waits until it runs and matches the log_pattern of readiness, then swaps their names, stops the old container and removes it last, so a single-instance service is only down while they are swapped. Host ports cannot be published by both containers, a container that publishes host ports is created without them and recreated with them right after the old container is stopped. Containers left under the temporary names by a failed replacement are removed before the next one. The ID of the container changes. Defaults to recreate.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("recreate", replaceStrategyBlueGreen),
+				},
+			},
+			"name_suffix_on_replace": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to create the container under a generated name (e.g. 'web-replace-1a2b3c') when a container with its name already exists, so it can be replaced with lifecycle create_before_destroy. The container is renamed to its name when the old container is destroyed, only the replacement created for that container is renamed. Defaults to false.",
+			},
+			"restart_triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that restart the container in place when they change, for example the hash of a configuration file in a bind mount. The container is not recreated and only restarted when it is running.",
+			},
 			"labels": schema.MapAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Computed:    true,
-				Description: "The labels for the container.",
+				Description: "The labels for the container. Changes are applied by recreating the container in place under the same name.",
 				PlanModifiers: []planmodifier.Map{
 					mapplanmodifier.UseStateForUnknown(),
 				},
@@ -433,6 +675,46 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					},
 				},
 			},
+			"tmpfs": schema.ListNestedAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"destination": schema.StringAttribute{
+							Required:    true,
+							Description: "The path inside the container where the tmpfs is mounted.",
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(regexp.MustCompile(`^\/(?:[^\/\0]+\/)*[^\/\0]+$`), "Path must be an absolute path (e.g. '/run' or '/tmp/cache')."),
+							},
+						},
+						"size": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "The size limit of the tmpfs mount (e.g. '64m', '1g'). Unlimited when empty.",
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(regexp.MustCompile(`^([0-9]+[kmg]?)?$`), "Size must be a number of bytes optionally followed by k, m or g (e.g. '64m')."),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"mode": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "The file mode of the tmpfs mount in octal (e.g. '1777').",
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(regexp.MustCompile(`^([0-7]{3,4})?$`), "Mode must be an octal file mode (e.g. '1777')."),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+					},
+				},
+			},
 			"runtime": schema.StringAttribute{
 				Optional:    true,
 				Computed:    true,
@@ -444,6 +726,52 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"cpu_limit": schema.Int32Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The CPU limit of the container, as set with the CPU slider of Container Station. 0 means unlimited. Changes are applied to the running container without restarting it.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
+				},
+			},
+			"mem_limit": schema.Int32Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The memory limit of the container in MB. 0 means unlimited. Changes are applied to the running container without restarting it.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
+				},
+			},
+			"memory_swap_limit": schema.Int32Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The total memory plus swap the container may use in MB, must be greater than or equal to mem_limit. -1 allows unlimited swap, 0 leaves the docker default.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(-1),
+				},
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
+					int32RequiresReplaceUnlessBlueGreen(),
+				},
+			},
+			"memory_swappiness": schema.Int32Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The tendency of the kernel to swap out anonymous pages of the container (0-100).",
+				Validators: []validator.Int32{
+					int32validator.Between(0, 100),
+				},
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
+					int32RequiresReplaceUnlessBlueGreen(),
+				},
+			},
 			"privileged": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
@@ -489,19 +817,28 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					"cpuids": schema.StringAttribute{
 						Optional:    true,
 						Computed:    true,
-						Description: "The CPU IDs for the container.",
+						Description: "The CPU IDs the container is pinned to as a comma separated list of IDs and ranges (e.g. 0,2-3). The IDs are checked against the CPU count of the NAS on apply. Required with the dedicated type and not allowed with the shared type.",
+						Validators: []validator.String{
+							cpuSet(),
+						},
 					},
 					"type": schema.StringAttribute{
 						Optional:    true,
 						Computed:    true,
-						Description: "The type of CPU pinning.",
+						Description: "The type of CPU pinning (shared, dedicated). Defaults to dedicated when cpuids is set.",
+						Validators: []validator.String{
+							stringvalidator.OneOf(cpupinTypeShared, cpupinTypeDedicated),
+						},
 					},
 				},
+				Validators: []validator.Object{
+					cpupinValidator{},
+				},
 			},
 			"networks": schema.ListNestedAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.List{
-					listplanmodifier.UseStateForUnknown(),
+					listUseStateForUnknownUnlessReconnected(),
 				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -526,6 +863,13 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 								stringplanmodifier.UseStateForUnknown(),
 							},
 						},
+						"ipaddress6": schema.StringAttribute{
+							Computed:    true,
+							Description: "The IPv6 address assigned to the network.",
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
 						"displayname": schema.StringAttribute{
 							Computed:    true,
 							Description: "The display name of the network.",
@@ -547,6 +891,13 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 								stringplanmodifier.UseStateForUnknown(),
 							},
 						},
+						"gateway6": schema.StringAttribute{
+							Computed:    true,
+							Description: "The IPv6 gateway of the network.",
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
 						"networktype": schema.StringAttribute{
 							Computed:    true,
 							Description: "The type of the network.",
@@ -568,12 +919,103 @@ func (d *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 	}
 }
 
+// ValidateConfig validates the combination of attributes in the configuration.
+func (r *containerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ContainerSpecModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Aliases are resolved by the embedded DNS of the network, which the host and none networks do not have
+	if len(config.NetworkAliases.Elements()) > 0 && isolatedNetworkTypes[config.NetworkType.ValueString()] {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("network_aliases"),
+			"Invalid network aliases",
+			"network_aliases cannot be set when networktype is "+config.NetworkType.ValueString()+".",
+		)
+	}
+
+	// Validate swap against the memory limit, docker requires a memory limit to set the swap limit
+	if !config.MemSwapLimit.IsNull() && !config.MemSwapLimit.IsUnknown() && config.MemSwapLimit.ValueInt32() > 0 {
+		if config.MemLimit.IsNull() || (!config.MemLimit.IsUnknown() && config.MemLimit.ValueInt32() == 0) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("memory_swap_limit"),
+				"Missing memory limit",
+				"memory_swap_limit can only be set together with a mem_limit greater than 0.",
+			)
+		} else if !config.MemLimit.IsUnknown() && config.MemSwapLimit.ValueInt32() < config.MemLimit.ValueInt32() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("memory_swap_limit"),
+				"Invalid memory swap limit",
+				fmt.Sprintf("memory_swap_limit (%d MB) must be greater than or equal to mem_limit (%d MB) as it includes the memory limit.", config.MemSwapLimit.ValueInt32(), config.MemLimit.ValueInt32()),
+			)
+		}
+	}
+
+	if config.NameSuffix.ValueBool() && len(config.Name.ValueString()) > 64-len(replacementSuffix)-6 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Container name too long",
+			fmt.Sprintf("name must be at most %d characters with name_suffix_on_replace, the replacement container is created as <name>%s<6 hex digits> while the old one exists.", 64-len(replacementSuffix)-6, replacementSuffix),
+		)
+	}
+
+	// An auto-updated container runs whatever image the tag points to, which cannot be pinned
+	if config.AutoUpdate != nil && !config.ImageDigest.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("image_digest"),
+			"Pinned image with auto-update",
+			"image_digest cannot be set together with auto_update as the NAS updates the container to the newest image of the tag.",
+		)
+	}
+
+	// The new container of a blue/green replacement runs next to the old one under a longer name
+	if config.ReplaceStrategy.ValueString() == replaceStrategyBlueGreen {
+		if len(config.Name.ValueString()) > 64-len(blueGreenSuffix) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Container name too long",
+				fmt.Sprintf("name must be at most %d characters with the blue_green replace_strategy, the new container is created as <name>%s while the old one runs.", 64-len(blueGreenSuffix), blueGreenSuffix),
+			)
+		}
+		for _, attribute := range []string{"ipaddress", "ipaddress6"} {
+			var address types.String
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &address)...)
+			if !address.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attribute),
+					"Static address with blue/green replacement",
+					attribute+" cannot be set with the blue_green replace_strategy as the old and new containers cannot have the same address.",
+				)
+			}
+		}
+	}
+}
+
+// ConfigValidators returns the validators of the combinations of attributes.
+func (r *containerResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		staticIPNetworkTypeValidator{},
+		hostIPValidator{},
+		autoRemoveRestartPolicyValidator{},
+	}
+}
+
 // Create a new resource.
 func (r *containerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	timeouts, diags := resourceTimeouts(ctx, r.timeouts, req.Plan.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, timeouts.Create)
+	defer cancel()
 
 	// Retrieve values from plan
 	var plan ContainerSpecModel
-	diags := req.Plan.Get(ctx, &plan)
+	diags = req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -586,16 +1028,80 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	diags = r.checkCPUTopology(ctx, newContainer.Cpupin.CPUIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.checkHostIPs(ctx, newContainer.PortBindings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ValidateHostPaths.ValueBool() {
+		diags = r.checkHostPaths(ctx, newContainer.Volumes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Pull the image with the registry credentials as Container Station pulls anonymously on create
+	diags = r.pullWithRegistryAuth(ctx, plan.Image.ValueString(), plan.RegistryAuth, plan.TrackRemoteDigest.ValueBool())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The containers it depends on must be ready before the container starts
+	if plan.Status.ValueString() == qnap.ContainerStatusRunning {
+		resp.Diagnostics.Append(r.waitForDependencies(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// With create_before_destroy the old container still holds the name, create the new one under a generated name
+	if plan.NameSuffix.ValueBool() {
+		existingID, _, err := findContainerByName(ctx, r.client, newContainer.Name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Resource",
+				errorDetail("An error occurred while looking up container "+newContainer.Name, err),
+			)
+			return
+		}
+		if existingID != "" {
+			newContainer.Name = replacementName(newContainer.Name, existingID)
+			tflog.Info(ctx, "Creating the replacement container under a generated name", map[string]interface{}{
+				"container": plan.Name.ValueString(),
+				"name":      newContainer.Name,
+			})
+		}
+	}
+
 	// Create new container
-	container, err := r.client.CreateContainer(newContainer, &r.client.Token)
+	container, err := createContainer(ctx, r.client, newContainer)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating container",
-			"Could not create container, unexpected error: "+err.Error(),
+			errorDetail("Could not create container", err),
 		)
 		return
 	}
 
+	// The created container is kept in state when its status cannot be changed, it would be orphaned otherwise
+	var statusDiags diag.Diagnostics
+	if container.Data.Status != plan.Status.ValueString() {
+		var changed *containerDetails
+		changed, statusDiags = r.changeStatus(ctx, &plan, container.Data.ID, container.Data.Status)
+		if changed != nil {
+			container = changed
+		}
+	}
+
 	//Comprehend the new container specs and populate the plan with the new values
 	state, diags := WriteState(ctx, container)
 	resp.Diagnostics.Append(diags...)
@@ -604,45 +1110,122 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 	}
 	// special case for RemoveAnonVolumes as its static to the plan and is used only during destroy
 	state.RemoveAnonVolumes = plan.RemoveAnonVolumes
+	state.RemoveImage = plan.RemoveImage
+	state.StopGracePeriod = plan.StopGracePeriod
+	state.ForceDestroy = plan.ForceDestroy
+	state.DeletionProtect = plan.DeletionProtect
+	state.RegistryAuth = plan.RegistryAuth
 	// special case for network name as it requires side call to qnap to compare the returned name vs the plan name
 	state.Network = plan.Network
-
-	// Set state to fully populated data
-	diags = resp.State.Set(ctx, state)
+	// special case for IgnoreImageEnv as it only changes how env is compared
+	state.IgnoreImageEnv = plan.IgnoreImageEnv
+	state.ValidateHostPaths = plan.ValidateHostPaths
+	state.AdoptRecreated = plan.AdoptRecreated
+	state.RestartTriggers = plan.RestartTriggers
+	state.Readiness = plan.Readiness
+	state.DependsOn = plan.DependsOn
+	state.WaitForHealthy = plan.WaitForHealthy
+	state.AutoUpdate = plan.AutoUpdate
+	state.TrackRemoteDigest = plan.TrackRemoteDigest
+	state.ReplaceStrategy = plan.ReplaceStrategy
+	state.NameSuffix = plan.NameSuffix
+	state.RenderedSpec = renderSpec(newContainer)
+	state.UpdateImpact = plan.UpdateImpact
+	// A replacement created under a generated name is renamed by the destroy of the container it replaces, which
+	// create_before_destroy runs right after this create. A rename that fails is read back as a change of name.
+	state.Name = plan.Name
+	state.Timeouts = plan.Timeouts
+
+	state, diags = CompareStates(ctx, &plan, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-}
-
-// Read refreshes the Terraform state with the latest data.
-func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	// Get current state
-	var state *ContainerSpecModel
-	var finalState ContainerSpecModel
 
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	// Get refreshed order value from QNAP
-	containerState, err := r.client.InspectContainer(state.ID.ValueString(), state.Type.ValueString(), &r.client.Token)
+	digestMatches, err := r.resolveImageDigest(ctx, plan.ImageDigest, &state)
 	if err != nil {
-		// Handle errors, such as resource not found
-		if isNotFound(err) {
-			resp.State.RemoveResource(ctx)
-			return
-		}
 		resp.Diagnostics.AddError(
 			"Unable to Read Resource",
-			"An error occurred while reading the resource: "+err.Error(),
+			errorDetail("An error occurred while reading the image of the container", err),
 		)
 		return
 	}
 
-	newState, diags := WriteState(ctx, containerState)
-	resp.Diagnostics.Append(diags...)
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(statusDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The container is kept in state so it is replaced on the next apply
+	if plan.AutoUpdate != nil {
+		resp.Diagnostics.Append(r.applyAutoUpdate(ctx, &plan, state.ID.ValueString(), state.Type.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if plan.Readiness != nil && plan.Status.ValueString() == qnap.ContainerStatusRunning {
+		err = waitForReadiness(ctx, r.client, state.ID.ValueString(), state.Type.ValueString(), plan.Readiness)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Container is not ready",
+				errorDetail("Container "+plan.Name.ValueString()+" was created but is not ready", err),
+			)
+		}
+	}
+	if !digestMatches {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("image_digest"),
+			"Image digest mismatch",
+			"The container was created from image "+state.ImageDigest.ValueString()+" but image_digest is "+plan.ImageDigest.ValueString()+". The tag "+plan.Image.ValueString()+" points to a different image on the NAS.",
+		)
+	}
+	r.notifier.notify(ctx, &resp.Diagnostics, "Container "+plan.Name.ValueString()+" was created from image "+plan.Image.ValueString()+".")
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	timeouts, diags := resourceTimeouts(ctx, r.timeouts, req.State.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, timeouts.Read)
+	defer cancel()
+
+	// Get current state
+	var state *ContainerSpecModel
+	var finalState ContainerSpecModel
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Get refreshed container value from QNAP
+	containerState, err := inspectContainer(ctx, r.client, state.ID.ValueString(), state.Type.ValueString())
+	if err != nil && isNotFound(err) {
+		// The container may have been deleted and recreated with the same name outside of Terraform
+		containerState, err = r.readRecreated(ctx, state, resp)
+		if err == nil && containerState == nil {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	} else if err == nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, recreatedPrivateKey, nil)...)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the resource", err),
+		)
+		return
+	}
+
+	newState, diags := WriteState(ctx, containerState)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -655,8 +1238,57 @@ func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	// special case for the last updated field and RemoveAnonVolumes
 	finalState.RemoveAnonVolumes = state.RemoveAnonVolumes
+	finalState.RemoveImage = state.RemoveImage
+	finalState.StopGracePeriod = state.StopGracePeriod
+	finalState.ForceDestroy = state.ForceDestroy
+	finalState.DeletionProtect = state.DeletionProtect
+	finalState.RegistryAuth = state.RegistryAuth
 	// special case for network name as it requires side call to qnap to compare the returned name vs the plan name
 	finalState.Network = state.Network
+	finalState.IgnoreImageEnv = state.IgnoreImageEnv
+	finalState.ValidateHostPaths = state.ValidateHostPaths
+	finalState.AdoptRecreated = state.AdoptRecreated
+	finalState.RestartTriggers = state.RestartTriggers
+	finalState.Readiness = state.Readiness
+	finalState.DependsOn = state.DependsOn
+	finalState.WaitForHealthy = state.WaitForHealthy
+	finalState.AutoUpdate = state.AutoUpdate
+	finalState.TrackRemoteDigest = state.TrackRemoteDigest
+	finalState.ReplaceStrategy = state.ReplaceStrategy
+	finalState.NameSuffix = state.NameSuffix
+	finalState.RenderedSpec = state.RenderedSpec
+	finalState.UpdateImpact = state.UpdateImpact
+	finalState.Timeouts = state.Timeouts
+
+	// A mismatch keeps the running image ID in state so the drift is planned as a replacement
+	_, err = r.resolveImageDigest(ctx, state.ImageDigest, &finalState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the image of the container", err),
+		)
+		return
+	}
+
+	// Only a configured auto-update is read back so NAS without the endpoint keep working
+	if state.AutoUpdate != nil {
+		settings, err := getContainerAutoUpdate(ctx, r.client, finalState.ID.ValueString(), finalState.Type.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Resource",
+				errorDetail("An error occurred while reading the auto-update of the container", err),
+			)
+			return
+		}
+		finalState.AutoUpdate = autoUpdateState(settings, state.AutoUpdate)
+	}
+
+	if state.TrackRemoteDigest.ValueBool() {
+		resp.Diagnostics.Append(r.checkRemoteDigest(ctx, state, containerState, resp)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, finalState)
@@ -666,32 +1298,856 @@ func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 }
 
+// recreatedPrivateKey is the private state key that records that the container was deleted and recreated with the
+// same name outside of Terraform.
+const recreatedPrivateKey = "recreated"
+
+// readRecreated returns the container with the name of the container in state when that container was deleted,
+// nil when there is none. Unless adopt_recreated is set, the replacement is recorded in the private state so that
+// ModifyPlan plans to replace the container.
+func (r *containerResource) readRecreated(ctx context.Context, state *ContainerSpecModel, resp *resource.ReadResponse) (*containerDetails, error) {
+	containerID, containerType, err := findContainerByName(ctx, r.client, state.Name.ValueString())
+	if err != nil || containerID == "" {
+		return nil, err
+	}
+	container, err := inspectContainer(ctx, r.client, containerID, containerType)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.AdoptRecreated.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Container Adopted",
+			fmt.Sprintf("The container %s was deleted outside of Terraform and the container %s with the same name is managed instead.", state.ID.ValueString(), containerID),
+		)
+		return container, nil
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, recreatedPrivateKey, []byte(`true`))...)
+	resp.Diagnostics.AddWarning(
+		"Container Replaced Outside of Terraform",
+		fmt.Sprintf("The container %s was deleted and recreated with the same name outside of Terraform as the container %s, which will be replaced to match the configuration. Set adopt_recreated to true to manage it instead.", state.ID.ValueString(), containerID),
+	)
+	return container, nil
+}
+
+// outdatedPrivateKey is the private state key that records the digest the tag of the image points to in the registry
+// when the container runs another image.
+const outdatedPrivateKey = "outdated"
+
+// checkRemoteDigest records in the private state whether the tag of the image of the container points to another
+// image in the registry. The check is skipped with a warning when the registry cannot be reached.
+func (r *containerResource) checkRemoteDigest(ctx context.Context, state *ContainerSpecModel, container *containerDetails, resp *resource.ReadResponse) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	var auth *imageRegistryAuth
+	if !state.RegistryAuth.IsNull() && !state.RegistryAuth.IsUnknown() {
+		var registryAuth RegistryAuthModel
+		diagnostics.Append(state.RegistryAuth.As(ctx, &registryAuth, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: false, UnhandledUnknownAsEmpty: false})...)
+		if diagnostics.HasError() {
+			return diagnostics
+		}
+		auth = &imageRegistryAuth{
+			ServerAddress: registryAuth.ServerAddress.ValueString(),
+			Username:      registryAuth.Username.ValueString(),
+			Password:      registryAuth.Password.ValueString(),
+		}
+	}
+
+	remote, err := remoteImageDigest(ctx, state.Image.ValueString(), auth)
+	if err != nil {
+		diagnostics.AddWarning(
+			"Unable to Resolve Remote Image Digest",
+			errorDetail("Could not resolve the digest of "+state.Image.ValueString()+" in its registry, the container is not checked for a newer image", err),
+		)
+		return diagnostics
+	}
+
+	image, err := findImage(ctx, r.client, container.Data.ImageID)
+	if err != nil {
+		diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the image of the container", err),
+		)
+		return diagnostics
+	}
+
+	var outdated []byte
+	if image == nil || !strings.HasSuffix(image.Digest, remote) {
+		outdated, _ = json.Marshal(remote)
+	}
+	diagnostics.Append(resp.Private.SetKey(ctx, outdatedPrivateKey, outdated)...)
+	return diagnostics
+}
+
+// ModifyPlan plans to replace a container that was deleted and recreated with the same name outside of Terraform,
+// or whose image tag points to a newer image in the registry when track_remote_digest is set. With the blue_green
+// replace_strategy the newer image is rolled out in place.
+func (r *containerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var track types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("track_remote_digest"), &track)...)
+	outdated, diags := req.Private.GetKey(ctx, outdatedPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if track.ValueBool() && len(outdated) > 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("image_digest"), types.StringUnknown())...)
+		blueGreen, diags := blueGreenConfigured(ctx, req.Config)
+		resp.Diagnostics.Append(diags...)
+		if blueGreen {
+			resp.Diagnostics.Append(planRecreatedInPlace(ctx, req.Config, &resp.Plan)...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("update_impact"), types.StringValue(updateImpactReplace))...)
+		} else {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("image_digest"))
+		}
+	}
+
+	recreated, diags := req.Private.GetKey(ctx, recreatedPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if len(recreated) == 0 {
+		return
+	}
+
+	var adopt types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("adopt_recreated"), &adopt)...)
+	if resp.Diagnostics.HasError() || adopt.ValueBool() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("id"))
+}
+
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *containerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// TODO: Implement the update logic
-	// QNAP-client-lib is also missing the update logic
-	//{"id":"2b4bd83659817408381d948e6599c830498826f70777b610a1af2b68cafa9758","type":"docker","runtime":"runc","name":"bazarr-10","restartPolicy":{"name":"always","maximumRetryCount":0},"networks":[{"id":"c7d58f09271f0c49b2d4e6ee578dc96e3276e88ac1d45d93a6cabca6cc069b4f","name":"bridge","ipAddress":"10.0.3.13","displayName":"Container Network (lxcbr0) (10.0.3.1)","macAddress":"02:42:0a:00:03:0d","gateway":"10.0.3.1","networkType":"default","isStaticIP":false}],"privileged":false,"devices":[],"volumes":[{"type":"volume","name":"volume_1","container":"","source":"/ZFS530_DATA/.qpkg/container-station/docker/volumes/volume_1/_data","destination":"/config","permission":"writable"}],"cpuLimit":1,"memLimit":1073741824,"memReservation":1073741824,"isCpuLimited":true,"isMemoryLimited":true,"isMemoryReservationLimited":true,"isCpuLimitedOld":true,"isMemoryLimitedOld":true,"isMemoryReservationLimitedOld":true,"hasDefaultWebUrlPort":false,"isCpuPinSupported":false,"cpupin":{"type":"shared","cpuIDs":"0"},"extra":{"restart":false}}
-	///container-station/api/v3/containers/docker/update | POST
+	timeouts, diags := resourceTimeouts(ctx, r.timeouts, req.Plan.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, timeouts.Update)
+	defer cancel()
+
+	// Retrieve values from plan and prior state
+	var plan, state ContainerSpecModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	containerID := state.ID.ValueString()
+	recreated := false
+	renderedSpec := state.RenderedSpec
+
+	// Rename the container in place, the container is tracked by its ID
+	if !plan.Name.Equal(state.Name) {
+		err := renameContainer(ctx, r.client, state.ID.ValueString(), state.Type.ValueString(), plan.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error renaming container",
+				errorDetail("Could not rename container "+state.Name.ValueString()+" to "+plan.Name.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	// The containers it depends on must be ready before the container is recreated or started
+	blueGreen := plan.ReplaceStrategy.ValueString() == replaceStrategyBlueGreen && blueGreenChanged(&plan, &state)
+	recreating := blueGreen || recreateInPlaceChanged(&plan, &state)
+	starting := plan.Status.ValueString() == qnap.ContainerStatusRunning && state.Status.ValueString() != qnap.ContainerStatusRunning
+	if recreating || starting {
+		resp.Diagnostics.Append(r.waitForDependencies(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Changes that require a new container are rolled out next to the old one with the blue_green strategy
+	if blueGreen {
+		var diags diag.Diagnostics
+		var submitted containerCreateSpec
+		containerID, submitted, diags = r.blueGreenReplace(ctx, &plan, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			// The new container replaced the old one, it is kept in state and refreshed by the next read
+			if containerID != "" {
+				state.ID = types.StringValue(containerID)
+				resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			}
+			return
+		}
+		recreated = true
+		renderedSpec = renderSpec(submitted)
+	} else if recreateInPlaceChanged(&plan, &state) {
+		// The create specification cannot be changed on an existing container, recreate it in place under the
+		// same name which keeps its volumes. The recreated container gets a new ID that is tracked in state.
+		newContainer, diags := ReadStateOrPlan(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		newContainer.Operation = "recreate"
+
+		diags = r.checkCPUTopology(ctx, newContainer.Cpupin.CPUIDs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		diags = r.checkHostIPs(ctx, newContainer.PortBindings)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if plan.ValidateHostPaths.ValueBool() {
+			diags = r.checkHostPaths(ctx, newContainer.Volumes)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		diags = r.pullWithRegistryAuth(ctx, plan.Image.ValueString(), plan.RegistryAuth, plan.TrackRemoteDigest.ValueBool())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		container, err := createContainer(ctx, r.client, newContainer)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error recreating container",
+				errorDetail("Could not apply the changes to container "+plan.Name.ValueString(), err),
+			)
+			return
+		}
+		containerID = container.Data.ID
+		recreated = true
+		renderedSpec = renderSpec(newContainer)
+	} else if !plan.Network.Equal(state.Network) || !plan.NetworkType.Equal(state.NetworkType) || (!plan.IPAddress.IsUnknown() && !plan.IPAddress.Equal(state.IPAddress)) || (!plan.IPAddress6.IsUnknown() && !plan.IPAddress6.Equal(state.IPAddress6)) || (!plan.NetworkAliases.IsUnknown() && !plan.NetworkAliases.Equal(state.NetworkAliases)) {
+		// Move the container to the new network in place, a recreated container is already created on it
+		connection := containerNetworkSpec{
+			Network:     plan.Network.ValueString(),
+			NetworkType: plan.NetworkType.ValueString(),
+		}
+		if !plan.IPAddress.IsUnknown() {
+			connection.IPAddress = plan.IPAddress.ValueString()
+		}
+		if !plan.IPAddress6.IsUnknown() {
+			connection.IPv6Address = plan.IPAddress6.ValueString()
+		}
+		diags = plan.NetworkAliases.ElementsAs(ctx, &connection.Aliases, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		err := reconnectContainer(ctx, r.client, containerID, state.Type.ValueString(), connection)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reconnecting container",
+				errorDetail("Could not move container "+plan.Name.ValueString()+" to network "+plan.Network.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	// Limits are changed on the running container, a recreated container is already created with them
+	if !recreated && (!plan.CPULimit.Equal(state.CPULimit) || !plan.MemLimit.Equal(state.MemLimit)) {
+		err := updateContainerLimits(ctx, r.client, containerID, state.Type.ValueString(), plan.CPULimit.ValueInt32(), int64(plan.MemLimit.ValueInt32())*bytesPerMB)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating container limits",
+				errorDetail("Could not update the limits of container "+plan.Name.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	// Restart the container in place when a restart trigger changed, a recreated container is already restarted
+	restarted := false
+	if !recreated && !plan.RestartTriggers.Equal(state.RestartTriggers) && state.Status.ValueString() == qnap.ContainerStatusRunning {
+		restarted = true
+		err := restartContainer(ctx, r.client, containerID, state.Type.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error restarting container",
+				errorDetail("Could not restart container "+plan.Name.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	// A new container starts without the auto-update of the old one
+	if !autoUpdateEqual(plan.AutoUpdate, state.AutoUpdate) || (recreated && plan.AutoUpdate != nil) {
+		resp.Diagnostics.Append(r.applyAutoUpdate(ctx, &plan, containerID, state.Type.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Get refreshed container value from QNAP
+	container, err := inspectContainer(ctx, r.client, containerID, state.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the resource after update", err),
+		)
+		return
+	}
+
+	// The rename or recreation already applied is kept in state when the status cannot be changed
+	var statusDiags diag.Diagnostics
+	if container.Data.Status != plan.Status.ValueString() {
+		var changed *containerDetails
+		changed, statusDiags = r.changeStatus(ctx, &plan, containerID, container.Data.Status)
+		if changed != nil {
+			container = changed
+		}
+	}
+
+	newState, diags := WriteState(ctx, container)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	newState.RemoveAnonVolumes = plan.RemoveAnonVolumes
+	newState.RemoveImage = plan.RemoveImage
+	newState.StopGracePeriod = plan.StopGracePeriod
+	newState.ForceDestroy = plan.ForceDestroy
+	newState.DeletionProtect = plan.DeletionProtect
+	newState.RegistryAuth = plan.RegistryAuth
+	newState.Network = plan.Network
+	newState.IgnoreImageEnv = plan.IgnoreImageEnv
+	newState.ValidateHostPaths = plan.ValidateHostPaths
+	newState.AdoptRecreated = plan.AdoptRecreated
+	newState.RestartTriggers = plan.RestartTriggers
+	newState.Readiness = plan.Readiness
+	newState.DependsOn = plan.DependsOn
+	newState.WaitForHealthy = plan.WaitForHealthy
+	newState.AutoUpdate = plan.AutoUpdate
+	newState.TrackRemoteDigest = plan.TrackRemoteDigest
+	newState.ReplaceStrategy = plan.ReplaceStrategy
+	newState.NameSuffix = plan.NameSuffix
+	newState.RenderedSpec = renderedSpec
+	newState.UpdateImpact = plan.UpdateImpact
+	newState.Timeouts = plan.Timeouts
+
+	newState, diags = CompareStates(ctx, &plan, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	digestMatches, err := r.resolveImageDigest(ctx, plan.ImageDigest, &newState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the image of the container", err),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(statusDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if (recreated || restarted) && plan.Readiness != nil && plan.Status.ValueString() == qnap.ContainerStatusRunning {
+		err = waitForReadiness(ctx, r.client, containerID, state.Type.ValueString(), plan.Readiness)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Container is not ready",
+				errorDetail("Container "+plan.Name.ValueString()+" was updated but is not ready", err),
+			)
+		}
+	}
+	if !digestMatches {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("image_digest"),
+			"Image digest mismatch",
+			"The container was recreated from image "+newState.ImageDigest.ValueString()+" but image_digest is "+plan.ImageDigest.ValueString()+". The tag "+plan.Image.ValueString()+" points to a different image on the NAS.",
+		)
+	}
+	if recreated {
+		r.notifier.notify(ctx, &resp.Diagnostics, "Container "+plan.Name.ValueString()+" was recreated from image "+plan.Image.ValueString()+".")
+	} else {
+		r.notifier.notify(ctx, &resp.Diagnostics, "Container "+plan.Name.ValueString()+" was updated.")
+	}
 }
 
 func (r *containerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	timeouts, diags := resourceTimeouts(ctx, r.timeouts, req.State.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, timeouts.Delete)
+	defer cancel()
+
 	// Retrieve values from state
 	var state ContainerSpecModel
-	diags := req.State.Get(ctx, &state)
+	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Delete existing order
-	_, err := r.client.DeleteContainer(state.ID.ValueString(), state.Type.ValueString(), state.RemoveAnonVolumes.ValueBool(), &r.client.Token)
+	if state.DeletionProtect.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Deletion Protection Enabled",
+			"Container "+state.Name.ValueString()+" has deletion_protection enabled and cannot be destroyed or replaced. "+
+				"Set deletion_protection to false and apply the change before destroying it.",
+		)
+		return
+	}
+
+	// The image ID is read before deletion as image_digest may hold the repository digest
+	container, err := inspectContainer(ctx, r.client, state.ID.ValueString(), state.Type.ValueString())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the container", err),
+		)
+		return
+	}
+	var imageID string
+	if container != nil && state.RemoveImage.ValueBool() {
+		imageID = container.Data.ImageID
+	}
+
+	// Container Station may reject deleting a running or paused container, stop it first
+	if container != nil && (container.Data.Status == qnap.ContainerStatusRunning || container.Data.Status == containerStatusPaused) {
+		diags = r.stopForDestroy(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Delete existing container
+	_, err = r.client.DeleteContainer(state.ID.ValueString(), state.Type.ValueString(), state.RemoveAnonVolumes.ValueBool(), requestToken(r.client))
+	err = withRequest(err, http.MethodDelete, "/container-station/api/v3/containers")
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Deleting HashiCups Order",
-			"Could not delete order, unexpected error: "+err.Error(),
+			"Error Deleting Container",
+			errorDetail("Could not delete container "+state.Name.ValueString(), err),
 		)
 		return
 	}
+
+	// Give the name to the replacement created under a generated name with create_before_destroy
+	r.renameReplacement(ctx, state.Name.ValueString(), state.ID.ValueString(), &resp.Diagnostics)
+
+	// The container is already removed, failing to remove the image does not fail the destroy
+	if imageID != "" {
+		err = removeUnusedImages(ctx, r.client, []string{imageID})
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Unable to remove image",
+				errorDetail("The container was removed but its image could not be removed", err),
+			)
+		}
+	}
+	r.notifier.notify(ctx, &resp.Diagnostics, "Container "+state.Name.ValueString()+" was destroyed.")
+}
+
+// stopForDestroy stops a running container gracefully and kills it when it cannot be stopped, or kills it right away
+// when force_destroy is set.
+func (r *containerResource) stopForDestroy(ctx context.Context, state *ContainerSpecModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	containerID, containerType := state.ID.ValueString(), state.Type.ValueString()
+
+	if !state.ForceDestroy.ValueBool() {
+		var gracePeriod time.Duration
+		if !state.StopGracePeriod.IsNull() {
+			var err error
+			gracePeriod, err = time.ParseDuration(state.StopGracePeriod.ValueString())
+			if err != nil {
+				diags.AddAttributeError(path.Root("stop_grace_period"), "Invalid Stop Grace Period", err.Error())
+				return diags
+			}
+		}
+
+		err := stopContainer(ctx, r.client, containerID, containerType, gracePeriod)
+		if err == nil || isNotFound(err) {
+			return diags
+		}
+		tflog.Warn(ctx, "Unable to stop the container gracefully, killing it", map[string]interface{}{
+			"container": state.Name.ValueString(),
+			"error":     err.Error(),
+		})
+	}
+
+	err := killContainer(ctx, r.client, containerID, containerType)
+	if err != nil && !isNotFound(err) {
+		diags.AddError(
+			"Error Stopping Container",
+			errorDetail("Could not stop container "+state.Name.ValueString()+" before deleting it", err),
+		)
+	}
+	return diags
+}
+
+// changeStatus moves the container to the status of the plan and returns its inspect details.
+func (r *containerResource) changeStatus(ctx context.Context, plan *ContainerSpecModel, containerID string, status string) (*containerDetails, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	containerType := plan.Type.ValueString()
+
+	var gracePeriod time.Duration
+	if !plan.StopGracePeriod.IsNull() {
+		var err error
+		gracePeriod, err = time.ParseDuration(plan.StopGracePeriod.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("stop_grace_period"), "Invalid Stop Grace Period", err.Error())
+			return nil, diags
+		}
+	}
+
+	err := changeContainerStatus(ctx, r.client, containerID, containerType, status, plan.Status.ValueString(), gracePeriod)
+	if err != nil {
+		diags.AddError(
+			"Error changing container status",
+			errorDetail("Could not change the status of container "+plan.Name.ValueString()+" from "+status+" to "+plan.Status.ValueString(), err),
+		)
+		return nil, diags
+	}
+
+	container, err := inspectContainer(ctx, r.client, containerID, containerType)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the container after changing its status", err),
+		)
+		return nil, diags
+	}
+	return container, diags
+}
+
+// blueGreenChanged reports whether the plan changes an attribute that requires a new container: one of
+// blueGreenAttributes or recreateInPlaceAttributes.
+func blueGreenChanged(plan *ContainerSpecModel, state *ContainerSpecModel) bool {
+	return !plan.Image.Equal(state.Image) || !plan.ImageDigest.Equal(state.ImageDigest) ||
+		!plan.DNSSearch.Equal(state.DNSSearch) || !plan.DNSOptions.Equal(state.DNSOptions) ||
+		!plan.MemSwapLimit.Equal(state.MemSwapLimit) || !plan.MemSwappiness.Equal(state.MemSwappiness) ||
+		recreateInPlaceChanged(plan, state)
+}
+
+// recreateInPlaceChanged reports whether the plan changes one of recreateInPlaceAttributes.
+func recreateInPlaceChanged(plan *ContainerSpecModel, state *ContainerSpecModel) bool {
+	return !plan.Env.Equal(state.Env) || !plan.Labels.Equal(state.Labels) ||
+		!plan.Hostname.Equal(state.Hostname) || !plan.PortBindings.Equal(state.PortBindings) ||
+		!plan.RestartPolicy.Equal(state.RestartPolicy) || !plan.AutoRemove.Equal(state.AutoRemove) ||
+		!plan.Cmd.Equal(state.Cmd) || !plan.Entrypoint.Equal(state.Entrypoint) ||
+		!plan.Tty.Equal(state.Tty) || !plan.OpenStdin.Equal(state.OpenStdin) ||
+		!plan.DNS.Equal(state.DNS) || !plan.Volumes.Equal(state.Volumes) || !plan.Tmpfs.Equal(state.Tmpfs) ||
+		!plan.Runtime.Equal(state.Runtime) || !plan.Privileged.Equal(state.Privileged) ||
+		!plan.Devices.Equal(state.Devices) || !plan.Cpupin.Equal(state.Cpupin)
+}
+
+// blueGreenSuffix is appended to the name of the new container while it runs next to the old one.
+const blueGreenSuffix = "-green"
+
+// blueGreenOldSuffix is appended to the name of the old container once the new one takes its name, until it is removed.
+const blueGreenOldSuffix = "-blue"
+
+// blueGreenReplace replaces the container by creating the new one next to it under a temporary name and waiting until
+// it runs and matches the log_pattern of readiness, the old container is left untouched when it does not. Only then do
+// the containers swap names, the old container is stopped and removed last. Host ports cannot be published by both
+// containers, a new container that publishes host ports is created without them and recreated with them once the old
+// container is stopped. It returns the ID of the new container and the last specification submitted for it. Once the
+// new container has the name of the old one its ID is returned with any error, so it is kept in state.
+func (r *containerResource) blueGreenReplace(ctx context.Context, plan *ContainerSpecModel, state *ContainerSpecModel) (string, containerCreateSpec, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if state.DeletionProtect.ValueBool() {
+		diags.AddError(
+			"Deletion Protection Enabled",
+			"Container "+state.Name.ValueString()+" has deletion_protection enabled and cannot be destroyed or replaced. "+
+				"Set deletion_protection to false and apply the change before replacing it.",
+		)
+		return "", containerCreateSpec{}, diags
+	}
+
+	newContainer, d := ReadStateOrPlan(ctx, plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return "", containerCreateSpec{}, diags
+	}
+	diags.Append(r.checkCPUTopology(ctx, newContainer.Cpupin.CPUIDs)...)
+	diags.Append(r.checkHostIPs(ctx, newContainer.PortBindings)...)
+	if plan.ValidateHostPaths.ValueBool() {
+		diags.Append(r.checkHostPaths(ctx, newContainer.Volumes)...)
+	}
+	if diags.HasError() {
+		return "", containerCreateSpec{}, diags
+	}
+	diags.Append(r.pullWithRegistryAuth(ctx, plan.Image.ValueString(), plan.RegistryAuth, plan.TrackRemoteDigest.ValueBool())...)
+	if diags.HasError() {
+		return "", containerCreateSpec{}, diags
+	}
+
+	// A failed replacement may have left containers under the temporary names
+	newName, oldName := plan.Name.ValueString()+blueGreenSuffix, plan.Name.ValueString()+blueGreenOldSuffix
+	for _, name := range []string{newName, oldName} {
+		r.removeLeftoverContainer(ctx, name, state.ID.ValueString(), &diags)
+	}
+	if diags.HasError() {
+		return "", containerCreateSpec{}, diags
+	}
+
+	// Start the new container next to the old one
+	portBindings := newContainer.PortBindings
+	newContainer.Name = newName
+	newContainer.PortBindings = []qnap.PortBindings{}
+	green, err := createContainer(ctx, r.client, newContainer)
+	if err != nil {
+		diags.AddError(
+			"Error creating container",
+			errorDetail("Could not create the new container "+newContainer.Name+" next to container "+state.Name.ValueString(), err),
+		)
+		return "", containerCreateSpec{}, diags
+	}
+
+	if plan.Status.ValueString() == qnap.ContainerStatusRunning {
+		err = nil
+		if green.Data.Status != qnap.ContainerStatusRunning {
+			err = fmt.Errorf("container is %s", green.Data.Status)
+		} else if plan.Readiness != nil && !plan.Readiness.LogPattern.IsNull() {
+			// The ports of the new container are only published after the swap, tcp_port is checked afterwards
+			readiness := *plan.Readiness
+			readiness.TCPPort = types.Int32Null()
+			err = waitForReadiness(ctx, r.client, green.Data.ID, green.Data.Type, &readiness)
+		}
+		if err != nil {
+			diags.AddError(
+				"Container is not ready",
+				errorDetail("The new container "+newContainer.Name+" is not ready, container "+state.Name.ValueString()+" was left unchanged", err),
+			)
+			if err := killContainer(ctx, r.client, green.Data.ID, green.Data.Type); err != nil && !isNotFound(err) {
+				tflog.Warn(ctx, "Unable to kill the new container", map[string]interface{}{
+					"container": newContainer.Name,
+					"error":     err.Error(),
+				})
+			}
+			r.removeContainer(ctx, green.Data.ID, green.Data.Type, newContainer.Name, true, &diags)
+			return "", containerCreateSpec{}, diags
+		}
+	}
+
+	// Swap the names, the old container keeps running until the new one has its name
+	old, err := inspectContainer(ctx, r.client, state.ID.ValueString(), state.Type.ValueString())
+	if err != nil && !isNotFound(err) {
+		diags.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the container", err),
+		)
+		return "", containerCreateSpec{}, diags
+	}
+	if old != nil {
+		err = renameContainer(ctx, r.client, state.ID.ValueString(), state.Type.ValueString(), oldName)
+		if err != nil {
+			diags.AddError(
+				"Error renaming container",
+				errorDetail("Could not rename container "+state.Name.ValueString()+" to "+oldName+", it was left unchanged", err),
+			)
+			return "", containerCreateSpec{}, diags
+		}
+	}
+	err = renameContainer(ctx, r.client, green.Data.ID, green.Data.Type, plan.Name.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error renaming container",
+			errorDetail("Could not rename the new container "+newName+" to "+plan.Name.ValueString(), err),
+		)
+		if old != nil {
+			if err := renameContainer(ctx, r.client, state.ID.ValueString(), state.Type.ValueString(), state.Name.ValueString()); err != nil {
+				diags.AddError(
+					"Error renaming container",
+					errorDetail("Could not rename container "+oldName+" back to "+state.Name.ValueString(), err),
+				)
+			}
+		}
+		return "", containerCreateSpec{}, diags
+	}
+	containerID := green.Data.ID
+
+	// The old container is stopped before the new one publishes its host ports
+	if old != nil && (old.Data.Status == qnap.ContainerStatusRunning || old.Data.Status == containerStatusPaused) {
+		diags.Append(r.stopForDestroy(ctx, state)...)
+		if diags.HasError() {
+			return containerID, newContainer, diags
+		}
+	}
+	if len(portBindings) > 0 {
+		newContainer.Name = plan.Name.ValueString()
+		newContainer.PortBindings = portBindings
+		newContainer.Operation = "recreate"
+		container, err := createContainer(ctx, r.client, newContainer)
+		if err != nil {
+			diags.AddError(
+				"Error recreating container",
+				errorDetail("Could not publish the ports of the new container "+plan.Name.ValueString(), err),
+			)
+			return containerID, newContainer, diags
+		}
+		containerID = container.Data.ID
+	}
+
+	// The new container is in place, the old one is removed at the next replacement when it cannot be removed now
+	if old != nil {
+		var removeDiags diag.Diagnostics
+		r.removeContainer(ctx, state.ID.ValueString(), state.Type.ValueString(), oldName, state.RemoveAnonVolumes.ValueBool(), &removeDiags)
+		if removeDiags.HasError() {
+			diags.AddWarning(
+				"Unable to remove the old container",
+				"Container "+plan.Name.ValueString()+" was replaced but the old container "+oldName+" could not be removed: "+removeDiags[0].Detail(),
+			)
+			return containerID, newContainer, diags
+		}
+	}
+
+	// The old container is removed, failing to remove its image does not fail the update
+	if old != nil && state.RemoveImage.ValueBool() && old.Data.ImageID != "" {
+		err = removeUnusedImages(ctx, r.client, []string{old.Data.ImageID})
+		if err != nil {
+			diags.AddWarning(
+				"Unable to remove image",
+				errorDetail("The old container was removed but its image could not be removed", err),
+			)
+		}
+	}
+	return containerID, newContainer, diags
+}
+
+// removeLeftoverContainer kills and removes the container with the given name left by a failed replacement, unless it
+// is the container of the resource.
+func (r *containerResource) removeLeftoverContainer(ctx context.Context, name string, stateID string, diags *diag.Diagnostics) {
+	containerID, containerType, err := findContainerByName(ctx, r.client, name)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while looking up container "+name, err),
+		)
+		return
+	}
+	if containerID == "" || containerID == stateID {
+		return
+	}
+
+	tflog.Info(ctx, "Removing the container left by a failed replacement", map[string]interface{}{
+		"container": name,
+	})
+	if err := killContainer(ctx, r.client, containerID, containerType); err != nil && !isNotFound(err) {
+		tflog.Warn(ctx, "Unable to kill the container left by a failed replacement", map[string]interface{}{
+			"container": name,
+			"error":     err.Error(),
+		})
+	}
+	r.removeContainer(ctx, containerID, containerType, name, true, diags)
+}
+
+// removeContainer deletes a stopped container and adds an error to the diagnostics when it cannot be deleted.
+func (r *containerResource) removeContainer(ctx context.Context, containerID string, containerType string, name string, removeAnonVolumes bool, diags *diag.Diagnostics) {
+	_, err := r.client.DeleteContainer(containerID, containerType, removeAnonVolumes, requestToken(r.client))
+	err = withRequest(err, http.MethodDelete, "/container-station/api/v3/containers")
+	if err != nil && !isNotFound(err) {
+		diags.AddError(
+			"Error Deleting Container",
+			errorDetail("Could not delete container "+name, err),
+		)
+	}
+}
+
+// waitForDependencies waits until the containers of depends_on_containers of the plan are ready.
+func (r *containerResource) waitForDependencies(ctx context.Context, plan *ContainerSpecModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var containerIDs []string
+	diags.Append(plan.DependsOn.ElementsAs(ctx, &containerIDs, false)...)
+	if diags.HasError() || len(containerIDs) == 0 {
+		return diags
+	}
+
+	err := waitForDependencies(ctx, r.client, containerIDs, plan.WaitForHealthy.ValueBool())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("depends_on_containers"),
+			"Dependencies are not ready",
+			errorDetail("Container "+plan.Name.ValueString()+" was not created or started as the containers it depends on are not ready", err),
+		)
+	}
+	return diags
+}
+
+// applyAutoUpdate configures the image auto-update of a container to the auto_update of the plan, disabling it when
+// auto_update is not set.
+func (r *containerResource) applyAutoUpdate(ctx context.Context, plan *ContainerSpecModel, containerID string, containerType string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	settings, err := plan.AutoUpdate.spec()
+	if err == nil {
+		err = setContainerAutoUpdate(ctx, r.client, containerID, containerType, settings)
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("auto_update"),
+			"Error configuring auto-update",
+			errorDetail("Could not configure the image auto-update of container "+plan.Name.ValueString(), err),
+		)
+	}
+	return diags
+}
+
+// renderSpec returns the indented JSON of a specification submitted to the NAS, null when it cannot be encoded.
+func renderSpec(spec any) types.String {
+	rendered, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(string(rendered))
+}
+
+// replacementSuffix separates the name of a container from the generated part of the name of its replacement.
+const replacementSuffix = "-replace-"
+
+// replacementName returns the name of a container created while the container with the given name and ID still
+// exists. The name is derived from the ID of the replaced container so its destroy, which cannot read the state of the
+// replacement, renames that replacement and no other container.
+func replacementName(name string, replacedID string) string {
+	sum := sha256.Sum256([]byte(replacedID))
+	return name + replacementSuffix + hex.EncodeToString(sum[:3])
+}
+
+// renameReplacement renames the container created under a generated name to replace the removed container to its
+// name. A failure is a warning as the replacement is renamed by its next update.
+func (r *containerResource) renameReplacement(ctx context.Context, name string, containerID string, diags *diag.Diagnostics) {
+	replacement := replacementName(name, containerID)
+	replacementID, replacementType, err := findContainerByName(ctx, r.client, replacement)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to rename replacement container",
+			errorDetail("Could not look up the replacement of container "+name, withRequest(err, http.MethodGet, "/container-station/api/v3/overview")),
+		)
+		return
+	}
+	if replacementID == "" {
+		return
+	}
+
+	err = renameContainer(ctx, r.client, replacementID, replacementType, name)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to rename replacement container",
+			errorDetail("Could not rename container "+replacement+" to "+name+", it is renamed by its next apply", err),
+		)
+	}
 }
 
 // Configure adds the provider configured client to the resource.
@@ -702,17 +2158,187 @@ func (r *containerResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*qnap.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *qnap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.timeouts = data.timeouts
+	r.notifier = data.notifier
+}
+
+// pullWithRegistryAuth pulls the image with the given registry credentials. Without credentials it only pulls the image
+// anonymously when alwaysPull is set, otherwise Container Station pulls a missing image on create.
+func (r *containerResource) pullWithRegistryAuth(ctx context.Context, image string, registryAuth basetypes.ObjectValue, alwaysPull bool) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	name, tag := splitImageReference(image)
+	pullSpec := imagePullSpec{
+		Name: name,
+		Tag:  tag,
+	}
+
+	if registryAuth.IsNull() || registryAuth.IsUnknown() {
+		if !alwaysPull {
+			return diagnostics
+		}
+		_, err := pullImage(ctx, r.client, pullSpec, defaultImageOperationOptions())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("image"),
+				"Error pulling image",
+				errorDetail("Could not pull image "+image, err),
+			)
+		}
+		return diagnostics
+	}
+
+	var auth RegistryAuthModel
+	diagnostics.Append(registryAuth.As(ctx, &auth, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: false, UnhandledUnknownAsEmpty: false})...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	pullSpec.Auth = &imageRegistryAuth{
+		ServerAddress: auth.ServerAddress.ValueString(),
+		Username:      auth.Username.ValueString(),
+		Password:      auth.Password.ValueString(),
+	}
+	_, err := pullImage(ctx, r.client, pullSpec, defaultImageOperationOptions())
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("registry_auth"),
+			"Error pulling image",
+			errorDetail("Could not pull image "+image+" with the registry credentials", err),
+		)
+	}
+	return diagnostics
+}
+
+// checkCPUTopology checks that the pinned CPU IDs exist on the NAS. The check is skipped
+// with a warning when the CPU count of the NAS cannot be read.
+func (r *containerResource) checkCPUTopology(ctx context.Context, cpuIDs string) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	if cpuIDs == "" {
+		return diagnostics
+	}
+
+	highest, err := parseCPUSet(cpuIDs)
+	if err != nil {
+		return diagnostics
+	}
+
+	system, err := getSystemInfo(ctx, r.client)
+	if err != nil || system.CPUCores == 0 {
+		tflog.Warn(ctx, "Skipping the check of the pinned CPU IDs as the CPU count of the NAS is not available", map[string]interface{}{
+			"cpuids": cpuIDs,
+			"error":  fmt.Sprint(err),
+		})
+		return diagnostics
+	}
+
+	if highest >= system.CPUCores {
+		diagnostics.AddAttributeError(
+			path.Root("cpupin").AtName("cpuids"),
+			"Invalid CPU IDs",
+			fmt.Sprintf("cpuids %s pins CPU %d but the NAS has %d CPUs, the CPU IDs must be between 0 and %d.", cpuIDs, highest, system.CPUCores, system.CPUCores-1),
+		)
+	}
+	return diagnostics
+}
+
+// checkHostIPs checks that the hostip of every port binding is an address of the NAS. The check is skipped
+// with a warning when the addresses of the NAS cannot be read.
+func (r *containerResource) checkHostIPs(ctx context.Context, portBindings []qnap.PortBindings) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	var hostIPs []string
+	for _, binding := range portBindings {
+		if ip := net.ParseIP(binding.HostIP); ip != nil && !ip.IsUnspecified() && !ip.IsLoopback() {
+			hostIPs = append(hostIPs, binding.HostIP)
+		}
+	}
+	if len(hostIPs) == 0 {
+		return diagnostics
+	}
+
+	addresses, err := listHostAddresses(ctx, r.client)
+	if err != nil {
+		tflog.Warn(ctx, "Skipping the check of the host IP addresses as the addresses of the NAS are not available", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return diagnostics
+	}
+
+	for _, hostIP := range hostIPs {
+		found := false
+		for _, address := range addresses {
+			if net.ParseIP(hostIP).Equal(net.ParseIP(address)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diagnostics.AddAttributeError(
+				path.Root("portbindings"),
+				"Invalid Host IP Address",
+				fmt.Sprintf("hostip %s is not an address of the NAS, which has the addresses %s. Use one of them or 0.0.0.0 to listen on every address.", hostIP, strings.Join(addresses, ", ")),
+			)
+		}
+	}
+	return diagnostics
+}
+
+// checkHostPaths checks that the source of every host volume exists on the NAS.
+func (r *containerResource) checkHostPaths(ctx context.Context, volumes []qnap.Volumes) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	for _, volume := range volumes {
+		if volume.Type != "host" {
+			continue
+		}
+
+		exists, err := pathExists(ctx, r.client, volume.Source)
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("volumes"),
+				"Unable to check host path",
+				errorDetail("Could not check that "+volume.Source+" exists on the NAS", err),
+			)
+			continue
+		}
+		if !exists {
+			diagnostics.AddAttributeError(
+				path.Root("volumes"),
+				"Missing host path",
+				"The source "+volume.Source+" of the volume mounted at "+volume.Destination+" does not exist on the NAS. Create the directory on a shared folder or set validate_host_paths to false to let docker create it.",
+			)
+		}
+	}
+	return diagnostics
+}
+
+// resolveImageDigest keeps the pinned digest in state when it identifies the image the container runs,
+// either by image ID or by repository digest, and reports whether it does. Otherwise the state keeps
+// the image ID returned by the API.
+func (r *containerResource) resolveImageDigest(ctx context.Context, pinned basetypes.StringValue, state *ContainerSpecModel) (bool, error) {
+	if pinned.IsNull() || pinned.IsUnknown() || pinned.Equal(state.ImageDigest) {
+		return true, nil
+	}
+
+	image, err := findImage(ctx, r.client, state.ImageDigest.ValueString())
+	if err != nil {
+		return false, err
+	}
+	if image == nil || image.Digest != pinned.ValueString() {
+		return false, nil
+	}
+
+	state.ImageDigest = pinned
+	return true, nil
 }
 
 // isNotFound checks if the error.
@@ -720,14 +2346,12 @@ func isNotFound(mess error) bool {
 	var status int
 	_, err := fmt.Sscanf(mess.Error(), "status: %d,", &status)
 	if err != nil {
-		fmt.Println("Error parsing status:", err)
 		return false
 	}
 
 	// Step 2: Extract the JSON part from the input string
 	start := strings.Index(mess.Error(), "body: {")
 	if start == -1 {
-		fmt.Println("Error: Could not find the body")
 		return false
 	}
 	jsonStr := mess.Error()[start+6:]
@@ -741,25 +2365,35 @@ func isNotFound(mess error) bool {
 	var resp Response
 	err = json.Unmarshal([]byte(jsonStr), &resp)
 	if err != nil {
-		fmt.Println("Error unmarshaling JSON:", err)
 		return false
 	}
 
-	// Output the extracted values
-	fmt.Println("Status:", status)
-	fmt.Println("Code:", resp.Code)
-	fmt.Println("Message:", resp.Message)
-	if status == 404 && resp.Code == 1009 && strings.Split(resp.Message, ": ")[1] == "No such container" {
-		return true
-	}
-	return false
+	return status == 404 && resp.Code == 1009 && strings.HasSuffix(resp.Message, "No such container")
 }
 
 // ReadStateOrPlan reads the state or plan and returns a new container spec.
-func ReadStateOrPlan(ctx context.Context, plan *ContainerSpecModel) (qnap.NewContainerSpec, diag.Diagnostics) {
+func ReadStateOrPlan(ctx context.Context, plan *ContainerSpecModel) (containerCreateSpec, diag.Diagnostics) {
 	// Retrieve values from plan
 	diagnostics := diag.Diagnostics{}
-	newContainer := qnap.NewContainerSpec{
+	newContainer := containerCreateSpec{}
+	newContainer.Project = plan.Project.ValueString()
+	newContainer.IPv6Address = plan.IPAddress6.ValueString()
+	for _, item := range plan.NetworkAliases.Elements() {
+		if alias, ok := item.(types.String); ok {
+			newContainer.NetworkAliases = append(newContainer.NetworkAliases, alias.ValueString())
+		}
+	}
+	newContainer.CPULimit = plan.CPULimit.ValueInt32()
+	newContainer.MemLimit = int64(plan.MemLimit.ValueInt32()) * bytesPerMB
+	newContainer.MemSwapLimit = int64(plan.MemSwapLimit.ValueInt32()) * bytesPerMB
+	if plan.MemSwapLimit.ValueInt32() == -1 {
+		newContainer.MemSwapLimit = -1
+	}
+	if !plan.MemSwappiness.IsNull() && !plan.MemSwappiness.IsUnknown() {
+		swappiness := int64(plan.MemSwappiness.ValueInt32())
+		newContainer.MemSwappiness = &swappiness
+	}
+	newContainer.NewContainerSpec = qnap.NewContainerSpec{
 		Type:        plan.Type.ValueString(),
 		Name:        plan.Name.ValueString(),
 		Image:       plan.Image.ValueString(),
@@ -780,7 +2414,7 @@ func ReadStateOrPlan(ctx context.Context, plan *ContainerSpecModel) (qnap.NewCon
 		diags := plan.Devices.ElementsAs(ctx, &planDevices, false)
 		diagnostics.Append(diags...)
 		if diagnostics.HasError() {
-			return qnap.NewContainerSpec{}, diagnostics
+			return containerCreateSpec{}, diagnostics
 		}
 		for _, device := range planDevices {
 			// Check if device_key is unknown or null
@@ -819,7 +2453,7 @@ func ReadStateOrPlan(ctx context.Context, plan *ContainerSpecModel) (qnap.NewCon
 		diags := plan.Volumes.ElementsAs(ctx, &planVolumes, false)
 		diagnostics.Append(diags...)
 		if diagnostics.HasError() {
-			return qnap.NewContainerSpec{}, diagnostics
+			return containerCreateSpec{}, diagnostics
 		}
 
 		for _, volume := range planVolumes {
@@ -845,13 +2479,32 @@ func ReadStateOrPlan(ctx context.Context, plan *ContainerSpecModel) (qnap.NewCon
 		}
 	}
 
+	// Handle Tmpfs
+	if !plan.Tmpfs.IsNull() && !plan.Tmpfs.IsUnknown() {
+		var planTmpfs []TmpfsModel
+		diags := plan.Tmpfs.ElementsAs(ctx, &planTmpfs, false)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return containerCreateSpec{}, diagnostics
+		}
+
+		newContainer.Tmpfs = make(map[string]string, len(planTmpfs))
+		for _, tmpfs := range planTmpfs {
+			if tmpfs.Destination.IsUnknown() || tmpfs.Destination.IsNull() {
+				diagnostics.AddWarning("Tmpfs destination is unknown or null", "Skipping processing of a tmpfs mount because its destination is unknown or null.")
+				continue
+			}
+			newContainer.Tmpfs[tmpfs.Destination.ValueString()] = formatTmpfsOptions(tmpfs.Size.ValueString(), tmpfs.Mode.ValueString())
+		}
+	}
+
 	// Handle PortBindings
 	if !plan.PortBindings.IsNull() && !plan.PortBindings.IsUnknown() {
 		var planPortBindings []PortBindingsModel
 		diags := plan.PortBindings.ElementsAs(ctx, &planPortBindings, false)
 		diagnostics.Append(diags...)
 		if diagnostics.HasError() {
-			return qnap.NewContainerSpec{}, diagnostics
+			return containerCreateSpec{}, diagnostics
 		}
 		for _, portBinding := range planPortBindings {
 			if portBinding.Host.IsUnknown() || portBinding.Host.IsNull() ||
@@ -877,7 +2530,7 @@ func ReadStateOrPlan(ctx context.Context, plan *ContainerSpecModel) (qnap.NewCon
 		diags := plan.RestartPolicy.As(ctx, &planRestartPolicy, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: false, UnhandledUnknownAsEmpty: false})
 		diagnostics.Append(diags...)
 		if diagnostics.HasError() {
-			return qnap.NewContainerSpec{}, diagnostics
+			return containerCreateSpec{}, diagnostics
 		}
 		if planRestartPolicy.Name.IsUnknown() || planRestartPolicy.Name.IsNull() ||
 			planRestartPolicy.MaximumRetryCount.IsUnknown() || planRestartPolicy.MaximumRetryCount.IsNull() {
@@ -896,11 +2549,15 @@ func ReadStateOrPlan(ctx context.Context, plan *ContainerSpecModel) (qnap.NewCon
 		diags := plan.Cpupin.As(ctx, &planCpupin, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: false, UnhandledUnknownAsEmpty: false})
 		diagnostics.Append(diags...)
 		if diagnostics.HasError() {
-			return qnap.NewContainerSpec{}, diagnostics
+			return containerCreateSpec{}, diagnostics
+		}
+		// CPU IDs without a type pin the container to them
+		if (planCpupin.Type.IsUnknown() || planCpupin.Type.IsNull()) && planCpupin.CPUIDs.ValueString() != "" {
+			planCpupin.Type = types.StringValue(cpupinTypeDedicated)
 		}
 		if planCpupin.CPUIDs.IsUnknown() || planCpupin.CPUIDs.IsNull() ||
 			planCpupin.Type.IsUnknown() || planCpupin.Type.IsNull() {
-			diagnostics.AddWarning("Port binding attributes are unknown or null", "Skipping processing of a port binding because one or more attributes are unknown or null.")
+			diagnostics.AddWarning("CPU pinning attributes are unknown or null", "Skipping processing of the CPU pinning because one or more attributes are unknown or null.")
 		} else {
 			newContainer.Cpupin = qnap.Cpupin{
 				CPUIDs: planCpupin.CPUIDs.ValueString(),
@@ -915,39 +2572,49 @@ func ReadStateOrPlan(ctx context.Context, plan *ContainerSpecModel) (qnap.NewCon
 	newContainer.Labels = make(map[string]string, len(plan.Labels.Elements()))
 	_ = plan.Labels.ElementsAs(ctx, newContainer.Labels, false)
 
-	for _, item := range plan.Cmd.Elements() {
-		newContainer.Cmd = append(newContainer.Cmd, strings.Replace(item.String(), `"`, ``, -1))
-		tflog.Debug(ctx, fmt.Sprintf("CMD: %s", strings.Replace(item.String(), `"`, ``, -1)))
-	}
-	for _, item := range plan.Entrypoint.Elements() {
-		newContainer.Entrypoint = append(newContainer.Entrypoint, strings.Replace(item.String(), `"`, ``, -1))
-		tflog.Debug(ctx, fmt.Sprintf("Entrypoint: %s", strings.Replace(item.String(), `"`, ``, -1)))
-	}
-	for _, item := range plan.DNS.Elements() {
-		newContainer.DNS = append(newContainer.DNS, strings.Replace(item.String(), `"`, ``, -1))
-	}
+	// Unknown lists are left empty so the API applies the image defaults
+	diagnostics.Append(plan.Cmd.ElementsAs(ctx, &newContainer.Cmd, true)...)
+	tflog.Debug(ctx, fmt.Sprintf("CMD: %q", newContainer.Cmd))
+	diagnostics.Append(plan.Entrypoint.ElementsAs(ctx, &newContainer.Entrypoint, true)...)
+	tflog.Debug(ctx, fmt.Sprintf("Entrypoint: %q", newContainer.Entrypoint))
+	diagnostics.Append(plan.DNS.ElementsAs(ctx, &newContainer.DNS, true)...)
+	diagnostics.Append(plan.DNSSearch.ElementsAs(ctx, &newContainer.DNSSearch, true)...)
+	diagnostics.Append(plan.DNSOptions.ElementsAs(ctx, &newContainer.DNSOptions, true)...)
 	return newContainer, diagnostics
 }
 
 // WriteState populates the plan with the new values.
-func WriteState(ctx context.Context, container *qnap.ContainerInfo) (ContainerSpecModel, diag.Diagnostics) {
+func WriteState(ctx context.Context, container *containerDetails) (ContainerSpecModel, diag.Diagnostics) {
 	// Map response body to schema and populate Computed attribute values
 	plan := ContainerSpecModel{}
 	diagnostics := diag.Diagnostics{}
 
 	plan.ID = types.StringValue(container.Data.ID)
 	plan.AutoRemove = types.BoolValue(container.Data.AutoRemove)
-	plan.Cmd, _ = types.ListValueFrom(ctx, types.StringType, container.Data.Cmd)
+	plan.Cmd, _ = types.ListValueFrom(ctx, types.StringType, append([]string{}, container.Data.Cmd...))
 	plan.Tty = types.BoolValue(container.Data.Tty)
 	plan.OpenStdin = types.BoolValue(container.Data.OpenStdin)
 	plan.Hostname = types.StringValue(container.Data.Hostname)
+	plan.Project = types.StringValue(container.Data.Project)
+	plan.CPULimit = types.Int32Value(container.Data.CPULimit)
+	plan.MemLimit = types.Int32Value(int32(container.Extra.Data.MemLimit / bytesPerMB))
+	plan.MemSwapLimit = types.Int32Value(int32(container.Extra.Data.MemSwapLimit / bytesPerMB))
+	if container.Extra.Data.MemSwapLimit == -1 {
+		plan.MemSwapLimit = types.Int32Value(-1)
+	}
+	plan.MemSwappiness = types.Int32Value(0)
+	if container.Extra.Data.MemSwappiness != nil {
+		plan.MemSwappiness = types.Int32Value(int32(*container.Extra.Data.MemSwappiness))
+	}
 	plan.Runtime = types.StringValue(container.Data.Runtime)
 	plan.Privileged = types.BoolValue(container.Data.Privileged)
 	plan.Name = types.StringValue(container.Data.Name)
 	plan.Image = types.StringValue(container.Data.Image)
+	plan.ImageDigest = types.StringValue(container.Data.ImageID)
 	plan.Type = types.StringValue(container.Data.Type)
 	plan.Status = types.StringValue(container.Data.Status)
 	plan.NetworkType = types.StringValue(container.Data.Networks[0].NetworkType)
+	plan.NetworkAliases, _ = types.ListValueFrom(ctx, types.StringType, userNetworkAliases(container, 0))
 
 	// Populate Entrypoint attribute
 	elements := []attr.Value{}
@@ -963,6 +2630,13 @@ func WriteState(ctx context.Context, container *qnap.ContainerInfo) (ContainerSp
 	}
 	plan.DNS, _ = types.ListValue(types.StringType, elements)
 
+	// Populate DNS search domains and resolver options
+	dnsSearch, dnsOptions := []string{}, []string{}
+	dnsSearch = append(dnsSearch, container.Extra.Data.DNSSearch...)
+	dnsOptions = append(dnsOptions, container.Extra.Data.DNSOptions...)
+	plan.DNSSearch, _ = types.ListValueFrom(ctx, types.StringType, dnsSearch)
+	plan.DNSOptions, _ = types.ListValueFrom(ctx, types.StringType, dnsOptions)
+
 	// Populate Env attribute
 	values := map[string]attr.Value{}
 	for envKey, envValue := range container.Data.Env {
@@ -984,21 +2658,27 @@ func WriteState(ctx context.Context, container *qnap.ContainerInfo) (ContainerSp
 		"id":          types.StringType,
 		"name":        types.StringType,
 		"ipaddress":   types.StringType,
+		"ipaddress6":  types.StringType,
 		"displayname": types.StringType,
 		"macaddress":  types.StringType,
 		"gateway":     types.StringType,
+		"gateway6":    types.StringType,
 		"networktype": types.StringType,
 		"isstaticip":  types.BoolType,
 	}
-	for _, network := range container.Data.Networks {
+	for i, network := range container.Data.Networks {
+		// The IPv6 fields are decoded separately in the same order
+		ipv6 := container.networkExtra(i)
 		// Map the attributes' values
 		networkMap := map[string]attr.Value{
 			"id":          types.StringValue(network.ID),
 			"name":        types.StringValue(network.Name),
 			"ipaddress":   types.StringValue(network.IPAddress),
+			"ipaddress6":  types.StringValue(ipv6.IPv6Address),
 			"displayname": types.StringValue(network.DisplayName),
 			"macaddress":  types.StringValue(network.MacAddress),
 			"gateway":     types.StringValue(network.Gateway),
+			"gateway6":    types.StringValue(ipv6.IPv6Gateway),
 			"networktype": types.StringValue(network.NetworkType),
 			"isstaticip":  types.BoolValue(network.IsStaticIP),
 		}
@@ -1019,6 +2699,12 @@ func WriteState(ctx context.Context, container *qnap.ContainerInfo) (ContainerSp
 			plan.IPAddress = types.StringValue("")
 		}
 	}
+	if plan.IPAddress6.IsNull() || plan.IPAddress6.IsUnknown() {
+		plan.IPAddress6 = types.StringValue("")
+		if len(container.Data.Networks) == 1 {
+			plan.IPAddress6 = types.StringValue(container.networkExtra(0).IPv6Address)
+		}
+	}
 
 	// Convert []Volumes to basetypes.ListValue
 	var volumeListElements []attr.Value
@@ -1052,6 +2738,36 @@ func WriteState(ctx context.Context, container *qnap.ContainerInfo) (ContainerSp
 	}
 	plan.Volumes = basetypes.NewListValueMust(types.ObjectType{AttrTypes: volumeAttrTypes}, volumeListElements)
 
+	// Convert tmpfs mounts to basetypes.ListValue
+	var tmpfsListElements []attr.Value
+	tmpfsAttrTypes := map[string]attr.Type{
+		"destination": types.StringType,
+		"size":        types.StringType,
+		"mode":        types.StringType,
+	}
+	tmpfsDestinations := make([]string, 0, len(container.Extra.Data.Tmpfs))
+	for destination := range container.Extra.Data.Tmpfs {
+		tmpfsDestinations = append(tmpfsDestinations, destination)
+	}
+	sort.Strings(tmpfsDestinations)
+	for _, destination := range tmpfsDestinations {
+		size, mode := parseTmpfsOptions(container.Extra.Data.Tmpfs[destination])
+		tmpfsMap := map[string]attr.Value{
+			"destination": types.StringValue(destination),
+			"size":        types.StringValue(size),
+			"mode":        types.StringValue(mode),
+		}
+
+		tmpfsObject, diags := types.ObjectValue(tmpfsAttrTypes, tmpfsMap)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return ContainerSpecModel{}, diagnostics
+		}
+
+		tmpfsListElements = append(tmpfsListElements, tmpfsObject)
+	}
+	plan.Tmpfs = basetypes.NewListValueMust(types.ObjectType{AttrTypes: tmpfsAttrTypes}, tmpfsListElements)
+
 	// Convert []Devices to basetypes.ListValue
 	var deviceListElements []attr.Value
 	deviceAttrTypes := map[string]attr.Type{
@@ -1074,19 +2790,13 @@ func WriteState(ctx context.Context, container *qnap.ContainerInfo) (ContainerSp
 	}
 	plan.Devices = basetypes.NewListValueMust(types.ObjectType{AttrTypes: deviceAttrTypes}, deviceListElements)
 
-	// Convert []PortBindings to basetypes.ListValue
+	// Convert []PortBindings to portBindingsValue
 	var portBindingListElements []attr.Value
-	portBindingAttrTypes := map[string]attr.Type{
-		"host":      types.Int32Type,
-		"container": types.Int32Type,
-		"protocol":  types.StringType,
-		"hostip":    types.StringType,
-	}
 	for _, portBinding := range container.Data.PortBindings {
 		portBindingMap := map[string]attr.Value{
 			"host":      types.Int32Value(portBinding.Host),
 			"container": types.Int32Value(portBinding.Container),
-			"protocol":  types.StringValue(strings.ToLower(portBinding.Protocol)),
+			"protocol":  types.StringValue(normalizePortBindingString("protocol", portBinding.Protocol)),
 			"hostip":    types.StringValue(portBinding.HostIP),
 		}
 
@@ -1099,7 +2809,7 @@ func WriteState(ctx context.Context, container *qnap.ContainerInfo) (ContainerSp
 		portBindingListElements = append(portBindingListElements, portBindingObject)
 	}
 
-	plan.PortBindings = basetypes.NewListValueMust(types.ObjectType{AttrTypes: portBindingAttrTypes}, portBindingListElements)
+	plan.PortBindings = portBindingsValue{ListValue: basetypes.NewListValueMust(types.ObjectType{AttrTypes: portBindingAttrTypes}, portBindingListElements)}
 
 	// Convert RestartPolicy to basetypes.MapValue
 	restartPolicyAttrTypes := map[string]attr.Type{
@@ -1143,5 +2853,72 @@ func WriteState(ctx context.Context, container *qnap.ContainerInfo) (ContainerSp
 // CompareStates compares the plan and state and returns the state.
 func CompareStates(ctx context.Context, plan *ContainerSpecModel, state *ContainerSpecModel) (ContainerSpecModel, diag.Diagnostics) {
 	// TODO: Compare only the fields that can be updated
-	return *state, diag.Diagnostics{}
+	finalState := *state
+
+	// Only track the environment variables declared by the user when the image injected ones are ignored
+	if plan.IgnoreImageEnv.ValueBool() {
+		finalState.Env = filterDeclaredEnv(plan.Env, state.Env)
+	}
+
+	// WriteState reads missing lists as empty, keep them null when the plan has them null
+	finalState.Cmd = emptyListAs(ctx, plan.Cmd, state.Cmd)
+	finalState.Entrypoint = emptyListAs(ctx, plan.Entrypoint, state.Entrypoint)
+	finalState.DNS = emptyListAs(ctx, plan.DNS, state.DNS)
+	finalState.DNSSearch = emptyListAs(ctx, plan.DNSSearch, state.DNSSearch)
+	finalState.DNSOptions = emptyListAs(ctx, plan.DNSOptions, state.DNSOptions)
+	finalState.NetworkAliases = emptyListAs(ctx, plan.NetworkAliases, state.NetworkAliases)
+	return finalState, diag.Diagnostics{}
+}
+
+// emptyListAs returns the actual list, or when it has no elements, a list that is null or empty like declared.
+func emptyListAs(ctx context.Context, declared basetypes.ListValue, actual basetypes.ListValue) basetypes.ListValue {
+	if actual.IsUnknown() || len(actual.Elements()) > 0 {
+		return actual
+	}
+	if declared.IsNull() {
+		return types.ListNull(actual.ElementType(ctx))
+	}
+	return types.ListValueMust(actual.ElementType(ctx), []attr.Value{})
+}
+
+// filterDeclaredEnv returns the actual environment variables restricted to the keys present in declared.
+func filterDeclaredEnv(declared basetypes.MapValue, actual basetypes.MapValue) basetypes.MapValue {
+	actualValues := actual.Elements()
+	values := map[string]attr.Value{}
+	for envKey := range declared.Elements() {
+		if envValue, ok := actualValues[envKey]; ok {
+			values[envKey] = envValue
+		}
+	}
+	return types.MapValueMust(types.StringType, values)
+}
+
+// bytesPerMB converts the MB based memory attributes to the byte counts used by the API.
+const bytesPerMB int64 = 1024 * 1024
+
+// formatTmpfsOptions builds the docker tmpfs option string (e.g. "size=64m,mode=1777").
+func formatTmpfsOptions(size string, mode string) string {
+	var options []string
+	if size != "" {
+		options = append(options, "size="+size)
+	}
+	if mode != "" {
+		options = append(options, "mode="+mode)
+	}
+	return strings.Join(options, ",")
+}
+
+// parseTmpfsOptions extracts the size and mode from a docker tmpfs option string.
+func parseTmpfsOptions(options string) (string, string) {
+	var size, mode string
+	for _, option := range strings.Split(options, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "size":
+			size = value
+		case "mode":
+			mode = value
+		}
+	}
+	return size, mode
 }
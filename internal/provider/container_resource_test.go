@@ -1,9 +1,21 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestAccContainerResource(t *testing.T) {
@@ -22,6 +34,7 @@ func TestAccContainerResource(t *testing.T) {
 						networktype = "bridge"
 						type = "docker"
 						removeanonvolumes = true
+						ignore_image_env = true
 					}
 				`,
 				Check: resource.ComposeTestCheckFunc(
@@ -32,6 +45,57 @@ func TestAccContainerResource(t *testing.T) {
 					resource.TestCheckResourceAttr("qnap_container.min_coverage", "networktype", "bridge"),
 					resource.TestCheckResourceAttr("qnap_container.min_coverage", "type", "docker"),
 					resource.TestCheckResourceAttr("qnap_container.min_coverage", "removeanonvolumes", "true"),
+					resource.TestCheckResourceAttr("qnap_container.min_coverage", "ignore_image_env", "true"),
+					resource.TestCheckResourceAttr("qnap_container.min_coverage", "env.%", "0"),
+				),
+			},
+			// test case 1 - rename in place
+			{
+				Config: `
+					resource "qnap_container" "min_coverage" {
+						name = "terraform_test_min_coverage_renamed"
+						image = "nginx:latest"
+						network = "eth0"
+						status = "running"
+						networktype = "bridge"
+						type = "docker"
+						removeanonvolumes = true
+						ignore_image_env = true
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container.min_coverage", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.min_coverage", "name", "terraform_test_min_coverage_renamed"),
+				),
+			},
+			// test case 1 - update labels without replace
+			{
+				Config: `
+					resource "qnap_container" "min_coverage" {
+						name = "terraform_test_min_coverage_renamed"
+						image = "nginx:latest"
+						network = "eth0"
+						status = "running"
+						networktype = "bridge"
+						type = "docker"
+						removeanonvolumes = true
+						ignore_image_env = true
+						labels = {
+							"tier" = "test"
+						}
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container.min_coverage", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.min_coverage", "labels.tier", "test"),
 				),
 			},
 			// test case 2
@@ -40,13 +104,18 @@ func TestAccContainerResource(t *testing.T) {
 					resource "qnap_container" "full_coverage_1" {
 						status             = "running"
 						removeanonvolumes  = true
+						remove_image_on_destroy = true
 						type               = "docker"
 						name               = "terraform_test_full_coverage_1"
 						image              = "nginx:1.26.2"
 						network            = "bridge"
 						networktype        = "default"
 						hostname           = "my-hostname"
+						project            = "terraform_test"
 						privileged         = false
+						mem_limit          = 512
+						memory_swap_limit  = 1024
+						memory_swappiness  = 10
 						portbindings = [
 							{
 								host      = 49123,
@@ -84,7 +153,16 @@ func TestAccContainerResource(t *testing.T) {
 								},
 							]
 
+						tmpfs = [
+							{
+								destination = "/run/cache",
+								size        = "64m",
+								mode        = "1777",
+							}
+						]
 						dns = ["8.8.8.8", "8.8.4.4"]
+						dns_search = ["corp.example.com"]
+						dns_options = ["ndots:2", "rotate"]
 						env = {
 							"NGINX_VERSION" = "1.26.2"
 							"NJS_VERSION" = "0.8.5"
@@ -98,14 +176,20 @@ func TestAccContainerResource(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "status", "running"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "removeanonvolumes", "true"),
+					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "remove_image_on_destroy", "true"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "type", "docker"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "name", "terraform_test_full_coverage_1"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "image", "nginx:1.26.2"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "network", "bridge"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "networktype", "default"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "hostname", "my-hostname"),
+					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "project", "terraform_test"),
+					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "mem_limit", "512"),
+					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "memory_swap_limit", "1024"),
+					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "memory_swappiness", "10"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "privileged", "false"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "portbindings.0.host", "49123"),
+					resource.TestMatchResourceAttr("qnap_container.full_coverage_1", "rendered_spec", regexp.MustCompile(`"name": "terraform_test_full_coverage_1"`)),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "portbindings.0.container", "80"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "portbindings.0.protocol", "tcp"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "portbindings.0.hostip", "0.0.0.0"),
@@ -121,6 +205,9 @@ func TestAccContainerResource(t *testing.T) {
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "runtime", "runc"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "dns.0", "8.8.8.8"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "dns.1", "8.8.4.4"),
+					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "dns_search.0", "corp.example.com"),
+					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "dns_options.#", "2"),
+					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "dns_options.0", "ndots:2"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "env.DYNPKG_RELEASE", "2~bookworm"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "env.PKG_RELEASE", "1~bookworm"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "env.NGINX_VERSION", "1.26.2"),
@@ -140,6 +227,10 @@ func TestAccContainerResource(t *testing.T) {
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "volumes.1.permission", "writable"),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "volumes.1.container", ""),
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "volumes.1.name", ""),
+					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "tmpfs.#", "1"),
+					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "tmpfs.0.destination", "/run/cache"),
+					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "tmpfs.0.size", "64m"),
+					resource.TestCheckResourceAttr("qnap_container.full_coverage_1", "tmpfs.0.mode", "1777"),
 				),
 			},
 			// test case 3
@@ -245,6 +336,1171 @@ func TestAccContainerResource(t *testing.T) {
 					resource.TestCheckResourceAttr("qnap_container.full_coverage_2", "networks.0.isstaticip", "true"),
 				),
 			},
+			// test case 4 - swap limit lower than the memory limit
+			{
+				Config: `
+					resource "qnap_container" "invalid_swap" {
+						name = "terraform_test_invalid_swap"
+						image = "nginx:latest"
+						network = "eth0"
+						status = "running"
+						networktype = "bridge"
+						type = "docker"
+						removeanonvolumes = true
+						mem_limit = 1024
+						memory_swap_limit = 512
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid memory swap limit`),
+			},
+			// test case 5 - cpu range that ends before it starts
+			{
+				Config: `
+					resource "qnap_container" "invalid_cpupin" {
+						name = "terraform_test_invalid_cpupin"
+						image = "nginx:latest"
+						network = "eth0"
+						status = "running"
+						networktype = "bridge"
+						type = "docker"
+						removeanonvolumes = true
+						cpupin = {
+							cpuids = "0,3-1"
+							type = "dedicated"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid CPU IDs`),
+			},
+			// test case 6 - cpu that the NAS does not have
+			{
+				Config: `
+					resource "qnap_container" "invalid_cpupin" {
+						name = "terraform_test_invalid_cpupin"
+						image = "nginx:latest"
+						network = "eth0"
+						status = "running"
+						networktype = "bridge"
+						type = "docker"
+						removeanonvolumes = true
+						cpupin = {
+							cpuids = "0,1023"
+							type = "dedicated"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`but the NAS has [0-9]+ CPUs`),
+			},
+			// test case 7 - host volume that does not exist
+			{
+				Config: `
+					resource "qnap_container" "missing_host_path" {
+						name = "terraform_test_missing_host_path"
+						image = "nginx:latest"
+						network = "eth0"
+						status = "running"
+						networktype = "bridge"
+						type = "docker"
+						removeanonvolumes = true
+						validate_host_paths = true
+						volumes = [
+							{
+								type = "host",
+								name = "",
+								container = "",
+								source = "/Container/terraform_test_does_not_exist",
+								destination = "/data",
+								permission = "writable",
+							}
+						]
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Missing host path`),
+			},
+			// test case 8 - static ip address on the host network
+			{
+				Config: `
+					resource "qnap_container" "invalid_static_ip" {
+						name = "terraform_test_invalid_static_ip"
+						image = "nginx:latest"
+						network = "host"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						ipaddress = "192.168.1.50"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Static IP Address`),
+			},
+			// test case 9 - host ip that is not an address of the NAS
+			{
+				Config: `
+					resource "qnap_container" "invalid_host_ip" {
+						name = "terraform_test_invalid_host_ip"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						portbindings = [
+							{
+								host = 8080,
+								container = 80,
+								protocol = "tcp",
+								hostip = "203.0.113.7",
+							}
+						]
+					}
+				`,
+				ExpectError: regexp.MustCompile(`is not an address of the NAS`),
+			},
+			// test case 10 - stop grace period that is not a duration
+			{
+				Config: `
+					resource "qnap_container" "invalid_grace_period" {
+						name = "terraform_test_invalid_grace_period"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						stop_grace_period = "later"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Stop grace period must be a duration`),
+			},
+			// test case 11 - running container stopped gracefully on destroy
+			{
+				Config: `
+					resource "qnap_container" "graceful" {
+						name = "terraform_test_graceful"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						stop_grace_period = "20s"
+						force_destroy = false
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.graceful", "stop_grace_period", "20s"),
+					resource.TestCheckResourceAttr("qnap_container.graceful", "force_destroy", "false"),
+				),
+			},
+			// test case 12 - retry count without the onFailure restart policy
+			{
+				Config: `
+					resource "qnap_container" "invalid_retries" {
+						name = "terraform_test_invalid_retries"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						restartpolicy = {
+							name = "always"
+							maximumretrycount = 5
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`maximumretrycount can only be set when the restart policy is onFailure`),
+			},
+			// test case 13 - autoremove with a restart policy
+			{
+				Config: `
+					resource "qnap_container" "invalid_autoremove" {
+						name = "terraform_test_invalid_autoremove"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						autoremove = true
+						restartpolicy = {
+							name = "always"
+							maximumretrycount = 0
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`autoremove cannot be enabled with the always restart policy`),
+			},
+			// test case 14 - dedicated cpu pinning without cpu ids
+			{
+				Config: `
+					resource "qnap_container" "invalid_cpupin" {
+						name = "terraform_test_invalid_cpupin"
+						image = "nginx:latest"
+						network = "eth0"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						cpupin = {
+							cpuids = ""
+							type = "dedicated"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Missing CPU IDs`),
+			},
+			// test case 15 - shared cpu pinning with cpu ids
+			{
+				Config: `
+					resource "qnap_container" "invalid_cpupin" {
+						name = "terraform_test_invalid_cpupin"
+						image = "nginx:latest"
+						network = "eth0"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						cpupin = {
+							cpuids = "0-1"
+							type = "shared"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`cpuids cannot be set when the CPU pinning type is shared`),
+			},
+			// test case 16 - protected container is not destroyed
+			{
+				Config: `
+					resource "qnap_container" "protected" {
+						name = "terraform_test_protected"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						deletion_protection = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.protected", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config: `
+					resource "qnap_container" "protected" {
+						name = "terraform_test_protected"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						deletion_protection = true
+					}
+				`,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			// test case 17 - container is destroyed once the protection is disabled
+			{
+				Config: `
+					resource "qnap_container" "protected" {
+						name = "terraform_test_protected"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						deletion_protection = false
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container.protected", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.protected", "deletion_protection", "false"),
+				),
+			},
+			// test case 18 - changed restart trigger restarts the container in place
+			{
+				Config: `
+					resource "qnap_container" "restarted" {
+						name = "terraform_test_restarted"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						restart_triggers = {
+							config = "v1"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.restarted", "restart_triggers.config", "v1"),
+				),
+			},
+			{
+				Config: `
+					resource "qnap_container" "restarted" {
+						name = "terraform_test_restarted"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						restart_triggers = {
+							config = "v2"
+						}
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container.restarted", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.restarted", "restart_triggers.config", "v2"),
+					resource.TestCheckResourceAttr("qnap_container.restarted", "status", "running"),
+				),
+			},
+			// test case 19 - wait for the published port and the log of the container
+			{
+				Config: `
+					resource "qnap_container" "ready" {
+						name = "terraform_test_ready"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						portbindings = [
+							{
+								host      = 49124,
+								container = 80,
+								protocol  = "tcp",
+								hostip    = "0.0.0.0",
+							}
+						]
+						readiness = {
+							tcp_port = 49124
+							log_pattern = "start worker process"
+							timeout = "2m"
+							interval = "2s"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.ready", "readiness.tcp_port", "49124"),
+				),
+			},
+			// test case 20 - invalid log pattern
+			{
+				Config: `
+					resource "qnap_container" "ready" {
+						name = "terraform_test_ready"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						readiness = {
+							log_pattern = "ready ("
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Regular Expression`),
+			},
+			// test case 21 - tag tracked in the registry
+			{
+				Config: `
+					resource "qnap_container" "tracked" {
+						name = "terraform_test_tracked"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						track_remote_digest = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.tracked", "track_remote_digest", "true"),
+					resource.TestCheckResourceAttrSet("qnap_container.tracked", "image_digest"),
+				),
+			},
+			{
+				Config: `
+					resource "qnap_container" "tracked" {
+						name = "terraform_test_tracked"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						track_remote_digest = true
+					}
+				`,
+				PlanOnly: true,
+			},
+			// test case 22 - pause and unpause a container in place
+			{
+				Config: `
+					resource "qnap_container" "paused" {
+						name = "terraform_test_paused"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.paused", "status", "running"),
+				),
+			},
+			{
+				Config: `
+					resource "qnap_container" "paused" {
+						name = "terraform_test_paused"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "paused"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container.paused", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.paused", "status", "paused"),
+				),
+			},
+			{
+				Config: `
+					resource "qnap_container" "paused" {
+						name = "terraform_test_paused"
+						image = "nginx:latest"
+						network = "bridge"
+						status = "running"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container.paused", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.paused", "status", "running"),
+				),
+			},
+			// test case 23 - replace a container with the blue/green strategy
+			{
+				Config: `
+					resource "qnap_container" "blue_green" {
+						name = "terraform_test_blue_green"
+						image = "nginx:1.26"
+						network = "bridge"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						replace_strategy = "blue_green"
+						portbindings = [
+							{
+								host      = 49125,
+								container = 80,
+								protocol  = "tcp",
+								hostip    = "0.0.0.0",
+							}
+						]
+						readiness = {
+							tcp_port = 49125
+							log_pattern = "start worker process"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.blue_green", "name", "terraform_test_blue_green"),
+					resource.TestCheckResourceAttr("qnap_container.blue_green", "image", "nginx:1.26"),
+					resource.TestCheckResourceAttr("qnap_container.blue_green", "portbindings.0.host", "49125"),
+				),
+			},
+			{
+				Config: `
+					resource "qnap_container" "blue_green" {
+						name = "terraform_test_blue_green"
+						image = "nginx:1.27"
+						network = "bridge"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						replace_strategy = "blue_green"
+						portbindings = [
+							{
+								host      = 49125,
+								container = 80,
+								protocol  = "tcp",
+								hostip    = "0.0.0.0",
+							}
+						]
+						readiness = {
+							tcp_port = 49125
+							log_pattern = "start worker process"
+						}
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container.blue_green", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("qnap_container.blue_green", tfjsonpath.New("id")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.blue_green", "name", "terraform_test_blue_green"),
+					resource.TestCheckResourceAttr("qnap_container.blue_green", "image", "nginx:1.27"),
+					resource.TestMatchResourceAttr("qnap_container.blue_green", "rendered_spec", regexp.MustCompile(`"image": "nginx:1.27"`)),
+					resource.TestCheckResourceAttr("qnap_container.blue_green", "portbindings.0.host", "49125"),
+				),
+			},
+			// test case 24 - replace a container with create_before_destroy
+			{
+				Config: `
+					resource "qnap_container" "suffixed" {
+						name = "terraform_test_suffixed"
+						image = "nginx:1.26"
+						network = "bridge"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						name_suffix_on_replace = true
+
+						lifecycle {
+							create_before_destroy = true
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.suffixed", "name", "terraform_test_suffixed"),
+					resource.TestCheckResourceAttr("qnap_container.suffixed", "image", "nginx:1.26"),
+				),
+			},
+			{
+				Config: `
+					resource "qnap_container" "suffixed" {
+						name = "terraform_test_suffixed"
+						image = "nginx:1.27"
+						network = "bridge"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						name_suffix_on_replace = true
+
+						lifecycle {
+							create_before_destroy = true
+						}
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container.suffixed", plancheck.ResourceActionCreateBeforeDestroy),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.suffixed", "name", "terraform_test_suffixed"),
+					resource.TestCheckResourceAttr("qnap_container.suffixed", "image", "nginx:1.27"),
+				),
+			},
+			// test case 25 - image auto-update of Container Station
+			{
+				Config: `
+					resource "qnap_container" "auto_update" {
+						name = "terraform_test_auto_update"
+						image = "nginx:latest"
+						network = "bridge"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						adopt_recreated = true
+						auto_update = {
+							interval = "6h"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.auto_update", "auto_update.interval", "6h"),
+					resource.TestCheckNoResourceAttr("qnap_container.auto_update", "auto_update.window_start"),
+				),
+			},
+			{
+				Config: `
+					resource "qnap_container" "auto_update" {
+						name = "terraform_test_auto_update"
+						image = "nginx:latest"
+						network = "bridge"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						adopt_recreated = true
+						auto_update = {
+							interval = "24h"
+							window_start = "02:00"
+							window_end = "05:00"
+						}
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container.auto_update", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.auto_update", "auto_update.window_start", "02:00"),
+					resource.TestCheckResourceAttr("qnap_container.auto_update", "auto_update.window_end", "05:00"),
+				),
+			},
+			// test case 26 - limits changed on the running container
+			{
+				Config: `
+					resource "qnap_container" "limits" {
+						name = "terraform_test_limits"
+						image = "nginx:latest"
+						network = "bridge"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						cpu_limit = 50
+						mem_limit = 256
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.limits", "cpu_limit", "50"),
+					resource.TestCheckResourceAttr("qnap_container.limits", "mem_limit", "256"),
+					resource.TestCheckResourceAttr("qnap_container.limits", "update_impact", "replace"),
+				),
+			},
+			{
+				Config: `
+					resource "qnap_container" "limits" {
+						name = "terraform_test_limits"
+						image = "nginx:latest"
+						network = "bridge"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						cpu_limit = 100
+						mem_limit = 512
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container.limits", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.limits", "cpu_limit", "100"),
+					resource.TestCheckResourceAttr("qnap_container.limits", "mem_limit", "512"),
+					resource.TestCheckResourceAttr("qnap_container.limits", "status", "running"),
+					resource.TestCheckResourceAttr("qnap_container.limits", "update_impact", "live_update"),
+				),
+			},
+			// test case 27 - started after the container it depends on is running and healthy
+			{
+				Config: `
+					resource "qnap_container" "database" {
+						name = "terraform_test_database"
+						image = "postgres:16"
+						network = "bridge"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						env = {
+							POSTGRES_PASSWORD = "terraform"
+						}
+					}
+
+					resource "qnap_container" "web" {
+						name = "terraform_test_web"
+						image = "nginx:latest"
+						network = "bridge"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						depends_on_containers = [qnap_container.database.id]
+						wait_for_healthy = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("qnap_container.web", "depends_on_containers.0", "qnap_container.database", "id"),
+					resource.TestCheckResourceAttr("qnap_container.web", "wait_for_healthy", "true"),
+					resource.TestCheckResourceAttr("qnap_container.web", "status", "running"),
+				),
+			},
+			// test case 28 - port bindings changed on an existing container
+			{
+				Config: `
+					resource "qnap_container" "ports" {
+						name = "terraform_test_ports"
+						image = "nginx:latest"
+						network = "bridge"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						portbindings = [
+							{
+								host = 49124
+								container = 80
+								protocol = "tcp"
+							},
+						]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.ports", "portbindings.0.host", "49124"),
+				),
+			},
+			{
+				Config: `
+					resource "qnap_container" "ports" {
+						name = "terraform_test_ports"
+						image = "nginx:latest"
+						network = "bridge"
+						networktype = "default"
+						type = "docker"
+						removeanonvolumes = true
+						portbindings = [
+							{
+								host = 49125
+								container = 80
+								protocol = "tcp"
+							},
+						]
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container.ports", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.ports", "portbindings.0.host", "49125"),
+					resource.TestCheckResourceAttr("qnap_container.ports", "portbindings.0.container", "80"),
+					resource.TestCheckResourceAttr("qnap_container.ports", "status", "running"),
+				),
+			},
 		},
 	})
 }
+
+func TestEmptyListAs(t *testing.T) {
+	ctx := context.Background()
+	empty := types.ListValueMust(types.StringType, []attr.Value{})
+	null := types.ListNull(types.StringType)
+	unknown := types.ListUnknown(types.StringType)
+	values := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("8.8.8.8")})
+
+	testCases := map[string]struct {
+		declared types.List
+		actual   types.List
+		expected types.List
+	}{
+		"null declared, empty actual":     {declared: null, actual: empty, expected: null},
+		"null declared, null actual":      {declared: null, actual: null, expected: null},
+		"empty declared, empty actual":    {declared: empty, actual: empty, expected: empty},
+		"empty declared, null actual":     {declared: empty, actual: null, expected: empty},
+		"unknown declared, empty actual":  {declared: unknown, actual: empty, expected: empty},
+		"unknown declared, null actual":   {declared: unknown, actual: null, expected: empty},
+		"null declared, values actual":    {declared: null, actual: values, expected: values},
+		"values declared, empty actual":   {declared: values, actual: empty, expected: empty},
+		"empty declared, unknown actual":  {declared: empty, actual: unknown, expected: unknown},
+		"values declared, values actual":  {declared: values, actual: values, expected: values},
+		"unknown declared, values actual": {declared: unknown, actual: values, expected: values},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := emptyListAs(ctx, testCase.declared, testCase.actual)
+			if !got.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestCompareStatesEmptyLists(t *testing.T) {
+	ctx := context.Background()
+	empty := types.ListValueMust(types.StringType, []attr.Value{})
+	null := types.ListNull(types.StringType)
+
+	plan := ContainerSpecModel{Cmd: null, Entrypoint: empty, DNS: null, DNSSearch: empty, DNSOptions: null, NetworkAliases: empty}
+	state := ContainerSpecModel{Cmd: empty, Entrypoint: empty, DNS: empty, DNSSearch: empty, DNSOptions: empty, NetworkAliases: empty}
+
+	got, diags := CompareStates(ctx, &plan, &state)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	for name, lists := range map[string][2]types.List{
+		"cmd":             {got.Cmd, null},
+		"entrypoint":      {got.Entrypoint, empty},
+		"dns":             {got.DNS, null},
+		"dns_search":      {got.DNSSearch, empty},
+		"dns_options":     {got.DNSOptions, null},
+		"network_aliases": {got.NetworkAliases, empty},
+	} {
+		if !lists[0].Equal(lists[1]) {
+			t.Errorf("%s: expected %s, got %s", name, lists[1], lists[0])
+		}
+	}
+}
+
+func TestConfigMatchesState(t *testing.T) {
+	bindingTypes := map[string]attr.Type{"host": types.Int32Type, "hostip": types.StringType}
+	binding := func(host attr.Value, hostIP attr.Value) attr.Value {
+		return types.ObjectValueMust(bindingTypes, map[string]attr.Value{"host": host, "hostip": hostIP})
+	}
+	bindings := func(elements ...attr.Value) attr.Value {
+		return types.ListValueMust(types.ObjectType{AttrTypes: bindingTypes}, elements)
+	}
+	state := bindings(binding(types.Int32Value(8080), types.StringValue("0.0.0.0")))
+
+	testCases := map[string]struct {
+		config   attr.Value
+		expected bool
+	}{
+		"unset":                 {types.ListNull(types.ObjectType{AttrTypes: bindingTypes}), true},
+		"unset nested computed": {bindings(binding(types.Int32Value(8080), types.StringNull())), true},
+		"same":                  {bindings(binding(types.Int32Value(8080), types.StringValue("0.0.0.0"))), true},
+		"changed nested":        {bindings(binding(types.Int32Value(9090), types.StringNull())), false},
+		"added element":         {bindings(binding(types.Int32Value(8080), types.StringNull()), binding(types.Int32Value(9090), types.StringNull())), false},
+		"unknown":               {types.ListUnknown(types.ObjectType{AttrTypes: bindingTypes}), false},
+	}
+	for name, testCase := range testCases {
+		if got := configMatchesState(testCase.config, state); got != testCase.expected {
+			t.Errorf("%s: expected %t, got %t", name, testCase.expected, got)
+		}
+	}
+}
+
+func TestReadStateOrPlanStringLists(t *testing.T) {
+	ctx := context.Background()
+	tricky := []string{
+		`{"key": "value", "list": [1, 2]}`,
+		`echo "hello world"`,
+		`C:\path\to\file`,
+		`line\nbreak`,
+		`it's`,
+		`"`,
+		``,
+		`héllo ☃`,
+	}
+	elements := []attr.Value{}
+	for _, value := range tricky {
+		elements = append(elements, types.StringValue(value))
+	}
+	list := types.ListValueMust(types.StringType, elements)
+	unknown := types.ListUnknown(types.StringType)
+
+	plan := readStateOrPlanTestModel()
+	plan.Cmd, plan.Entrypoint, plan.DNS = list, list, list
+	spec, diags := ReadStateOrPlan(ctx, &plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	for name, got := range map[string][]string{"cmd": spec.Cmd, "entrypoint": spec.Entrypoint, "dns": spec.DNS} {
+		if !cmp.Equal(got, tricky) {
+			t.Errorf("%s: %s", name, cmp.Diff(tricky, got))
+		}
+	}
+
+	plan = readStateOrPlanTestModel()
+	plan.Cmd, plan.Entrypoint, plan.DNS = unknown, unknown, unknown
+	spec, diags = ReadStateOrPlan(ctx, &plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(spec.Cmd) != 0 || len(spec.Entrypoint) != 0 || len(spec.DNS) != 0 {
+		t.Errorf("expected unknown lists to be empty, got cmd %q, entrypoint %q, dns %q", spec.Cmd, spec.Entrypoint, spec.DNS)
+	}
+}
+
+// readStateOrPlanTestModel returns a container model with every attribute null.
+func readStateOrPlanTestModel() ContainerSpecModel {
+	ctx := context.Background()
+	var schemaResp fwresource.SchemaResponse
+	NewContainerResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+
+	var model ContainerSpecModel
+	plan.Get(ctx, &model)
+	return model
+}
+
+func TestSplitRegistryReference(t *testing.T) {
+	testCases := map[string][3]string{
+		"nginx":                            {dockerHubRegistry, "library/nginx", "latest"},
+		"nginx:1.27":                       {dockerHubRegistry, "library/nginx", "1.27"},
+		"grafana/grafana:11.0.0":           {dockerHubRegistry, "grafana/grafana", "11.0.0"},
+		"ghcr.io/owner/app:v1":             {"ghcr.io", "owner/app", "v1"},
+		"myregistry.local:5000/team/nginx": {"myregistry.local:5000", "team/nginx", "latest"},
+		"localhost/app:dev":                {"localhost", "app", "dev"},
+	}
+	for image, expected := range testCases {
+		t.Run(image, func(t *testing.T) {
+			registry, repository, tag := splitRegistryReference(image)
+			if got := [3]string{registry, repository, tag}; got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestAutoUpdateState(t *testing.T) {
+	settings := &containerAutoUpdateSpec{Enabled: true, Interval: 86400}
+	if got := autoUpdateState(settings, nil); got.Interval.ValueString() != "24h" || !got.WindowStart.IsNull() {
+		t.Errorf("expected interval 24h without window, got %v", got)
+	}
+	// The default interval is kept unset and equal durations keep their prior format
+	if got := autoUpdateState(settings, &autoUpdateModel{Interval: types.StringNull()}); !got.Interval.IsNull() {
+		t.Errorf("expected the unset interval to be kept, got %v", got.Interval)
+	}
+	if got := autoUpdateState(settings, &autoUpdateModel{Interval: types.StringValue("1440m")}); got.Interval.ValueString() != "1440m" {
+		t.Errorf("expected interval 1440m, got %v", got.Interval)
+	}
+	settings = &containerAutoUpdateSpec{Enabled: true, Interval: 5400, WindowStart: "22:00", WindowEnd: "04:00"}
+	if got := autoUpdateState(settings, &autoUpdateModel{Interval: types.StringValue("6h")}); got.Interval.ValueString() != "1h30m" || got.WindowEnd.ValueString() != "04:00" {
+		t.Errorf("expected interval 1h30m until 04:00, got %v", got)
+	}
+	if got := autoUpdateState(&containerAutoUpdateSpec{}, &autoUpdateModel{}); got != nil {
+		t.Errorf("expected a disabled auto-update to be nil, got %v", got)
+	}
+}
+
+func TestReplacementName(t *testing.T) {
+	name := replacementName("web", "3f6a9c0e1b2d")
+	if !regexp.MustCompile(`^web-replace-[0-9a-f]{6}$`).MatchString(name) {
+		t.Errorf("unexpected replacement name %s", name)
+	}
+	if again := replacementName("web", "3f6a9c0e1b2d"); again != name {
+		t.Errorf("expected the same name for the same container, got %s and %s", name, again)
+	}
+	if other := replacementName("web", "8d7e6f5a4b3c"); other == name {
+		t.Errorf("expected a different name for another container, got %s", other)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"no such container": {
+			err:      errors.New(`status: 404, body: {"code":1009,"message":"get container: No such container"}`),
+			expected: true,
+		},
+		"no separator": {
+			err:      errors.New(`status: 404, body: {"code":1009,"message":"No such container"}`),
+			expected: true,
+		},
+		"other message without separator": {
+			err:      errors.New(`status: 404, body: {"code":1009,"message":"not found"}`),
+			expected: false,
+		},
+		"api error": {
+			err:      &apiError{Method: http.MethodGet, Path: "/container-station/api/v3/containers/docker", Status: 404, Body: `{"code":1009,"message":"inspect: No such container"}`},
+			expected: true,
+		},
+		"other code": {
+			err:      errors.New(`status: 404, body: {"code":1000,"message":"get container: No such container"}`),
+			expected: false,
+		},
+		"other status": {
+			err:      errors.New(`status: 500, body: {"code":1009,"message":"get container: No such container"}`),
+			expected: false,
+		},
+		"not json": {
+			err:      errors.New(`status: 404, body: {not found`),
+			expected: false,
+		},
+		"not an api error": {
+			err:      errors.New("connection refused"),
+			expected: false,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := isNotFound(testCase.err); got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestContainerUpdateImpact(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp fwresource.SchemaResponse
+	NewContainerResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	// raw returns a container with the given attributes set and every other attribute null
+	raw := func(set map[string]tftypes.Value) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for name, attributeType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+		for name, value := range set {
+			values[name] = value
+		}
+		return tftypes.NewValue(objectType, values)
+	}
+	env := func(value string) tftypes.Value {
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{"MODE": tftypes.NewValue(tftypes.String, value)})
+	}
+	cmd := func(value string) tftypes.Value {
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, value)})
+	}
+	// nested returns a list nested attribute with one element with the given attributes set
+	nested := func(name string, set map[string]tftypes.Value) tftypes.Value {
+		listType := objectType.AttributeTypes[name].(tftypes.List)
+		elementType := listType.ElementType.(tftypes.Object)
+		values := map[string]tftypes.Value{}
+		for attribute, attributeType := range elementType.AttributeTypes {
+			values[attribute] = tftypes.NewValue(attributeType, nil)
+		}
+		for attribute, value := range set {
+			values[attribute] = value
+		}
+		return tftypes.NewValue(listType, []tftypes.Value{tftypes.NewValue(elementType, values)})
+	}
+	prior := map[string]tftypes.Value{
+		"name":         tftypes.NewValue(tftypes.String, "web"),
+		"status":       tftypes.NewValue(tftypes.String, "running"),
+		"cpu_limit":    tftypes.NewValue(tftypes.Number, 50),
+		"env":          env("blue"),
+		"cmd":          cmd("serve"),
+		"portbindings": nested("portbindings", map[string]tftypes.Value{"host": tftypes.NewValue(tftypes.Number, 8080)}),
+		"volumes":      nested("volumes", map[string]tftypes.Value{"destination": tftypes.NewValue(tftypes.String, "/data")}),
+		"tmpfs":        nested("tmpfs", map[string]tftypes.Value{"destination": tftypes.NewValue(tftypes.String, "/run")}),
+	}
+
+	testCases := map[string]struct {
+		config   map[string]tftypes.Value
+		plan     map[string]tftypes.Value
+		expected string
+	}{
+		"provider setting": {
+			config:   map[string]tftypes.Value{"deletion_protection": tftypes.NewValue(tftypes.Bool, true)},
+			expected: updateImpactNone,
+		},
+		"unset computed limit": {
+			config:   map[string]tftypes.Value{"cpu_limit": tftypes.NewValue(tftypes.Number, nil)},
+			plan:     map[string]tftypes.Value{"cpu_limit": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)},
+			expected: updateImpactNone,
+		},
+		"limit": {
+			config:   map[string]tftypes.Value{"cpu_limit": tftypes.NewValue(tftypes.Number, 100)},
+			expected: updateImpactLive,
+		},
+		"status": {
+			config:   map[string]tftypes.Value{"status": tftypes.NewValue(tftypes.String, "stopped")},
+			expected: updateImpactRestart,
+		},
+		"env": {
+			config:   map[string]tftypes.Value{"env": env("green"), "status": tftypes.NewValue(tftypes.String, "stopped")},
+			expected: updateImpactReplace,
+		},
+		"portbindings": {
+			config:   map[string]tftypes.Value{"portbindings": nested("portbindings", map[string]tftypes.Value{"host": tftypes.NewValue(tftypes.Number, 8081)})},
+			expected: updateImpactReplace,
+		},
+		"volumes": {
+			config:   map[string]tftypes.Value{"volumes": nested("volumes", map[string]tftypes.Value{"destination": tftypes.NewValue(tftypes.String, "/srv")})},
+			expected: updateImpactReplace,
+		},
+		"cmd": {
+			config:   map[string]tftypes.Value{"cmd": cmd("debug")},
+			expected: updateImpactReplace,
+		},
+		"tmpfs": {
+			config:   map[string]tftypes.Value{"tmpfs": nested("tmpfs", map[string]tftypes.Value{"destination": tftypes.NewValue(tftypes.String, "/cache")})},
+			expected: updateImpactReplace,
+		},
+		"project": {
+			config:   map[string]tftypes.Value{"project": tftypes.NewValue(tftypes.String, "shop")},
+			expected: updateImpactReplace,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config, plan := map[string]tftypes.Value{}, map[string]tftypes.Value{}
+			for attribute, value := range prior {
+				config[attribute], plan[attribute] = value, value
+			}
+			for attribute, value := range testCase.config {
+				config[attribute], plan[attribute] = value, value
+			}
+			for attribute, value := range testCase.plan {
+				plan[attribute] = value
+			}
+
+			got, diags := containerUpdateImpact(ctx,
+				tfsdk.Config{Schema: schemaResp.Schema, Raw: raw(config)},
+				tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw(plan)},
+				tfsdk.State{Schema: schemaResp.Schema, Raw: raw(prior)},
+			)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestContainerUpdateImpactClassifiesEveryAttribute(t *testing.T) {
+	var schemaResp fwresource.SchemaResponse
+	NewContainerResource().Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+
+	classified := map[string]int{"network_aliases": 1}
+	for _, attributes := range [][]string{
+		recreateInPlaceAttributes, blueGreenAttributes, networkAttributes, restartAttributes, liveUpdateAttributes,
+		replaceAttributes, providerAttributes,
+	} {
+		for _, attribute := range attributes {
+			classified[attribute]++
+		}
+	}
+	for attribute := range schemaResp.Schema.Attributes {
+		if classified[attribute] != 1 {
+			t.Errorf("attribute %s is classified %d times, expected once", attribute, classified[attribute])
+		}
+	}
+	for attribute := range classified {
+		if _, ok := schemaResp.Schema.Attributes[attribute]; !ok {
+			t.Errorf("classified attribute %s is not in the schema", attribute)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &containerScheduleResource{}
+	_ resource.ResourceWithConfigure = &containerScheduleResource{}
+)
+
+// cronPattern matches a cron expression of five fields: minute, hour, day of month, month and day of week.
+var cronPattern = regexp.MustCompile(`^\s*([0-9*/,-]+\s+){4}[0-9*/,-]+\s*$`)
+
+type ContainerScheduleSpecModel struct {
+	ID            basetypes.StringValue `tfsdk:"id"`
+	Name          basetypes.StringValue `tfsdk:"name"`
+	ContainerID   basetypes.StringValue `tfsdk:"container_id"`
+	ContainerType basetypes.StringValue `tfsdk:"container_type"`
+	Action        basetypes.StringValue `tfsdk:"action"`
+	Schedule      basetypes.StringValue `tfsdk:"schedule"`
+	Enabled       basetypes.BoolValue   `tfsdk:"enabled"`
+	LastUpdated   basetypes.StringValue `tfsdk:"last_updated"`
+}
+
+// containerScheduleResource is the resource implementation.
+type containerScheduleResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewContainerScheduleResource is a helper function to simplify the provider implementation.
+func NewContainerScheduleResource() resource.Resource {
+	return &containerScheduleResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *containerScheduleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_schedule"
+}
+
+// Schema defines the schema for the resource.
+func (r *containerScheduleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a schedule of Container Station that restarts, stops or starts a container at the times of a cron expression, for example a nightly restart of a container that degrades over time. The NAS runs the schedule, Terraform does not need to run at those times.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the schedule.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the schedule, unique on the NAS.",
+			},
+			"container_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the container the schedule acts on (e.g. the id of qnap_container).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"container_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "The type of the container (docker, lxd). Defaults to docker.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("docker", "lxd"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"action": schema.StringAttribute{
+				Required:    true,
+				Description: "The action run on the container (restart, stop, start).",
+				Validators: []validator.String{
+					stringvalidator.OneOf("restart", "stop", "start"),
+				},
+			},
+			"schedule": schema.StringAttribute{
+				Required:    true,
+				Description: "When to run the action, a cron expression of five fields in the time zone of the NAS (e.g. '0 3 * * *' every night at 3:00, '30 2 * * 1' every Monday at 2:30).",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(cronPattern, "Must be a cron expression of five fields: minute, hour, day of month, month and day of week."),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether the NAS runs the schedule. Defaults to true.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the last Terraform update of the schedule.",
+			},
+		},
+	}
+}
+
+// Create a new resource.
+func (r *containerScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan ContainerScheduleSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := createSchedule(ctx, r.client, scheduleFromPlan(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating schedule",
+			errorDetail("Could not create schedule "+plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	state := writeScheduleState(schedule, plan)
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *containerScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state ContainerScheduleSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := findSchedule(ctx, r.client, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading schedule "+state.Name.ValueString(), err),
+		)
+		return
+	}
+	if schedule == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	finalState := writeScheduleState(schedule, state)
+	finalState.LastUpdated = state.LastUpdated
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, finalState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update changes the name, action, schedule and enabled attributes in place.
+func (r *containerScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	// Retrieve values from plan and state
+	var plan, state ContainerScheduleSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule := scheduleFromPlan(plan)
+	schedule.ID = state.ID.ValueString()
+	err := updateSchedule(ctx, r.client, schedule)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating schedule",
+			errorDetail("Could not update schedule "+plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	newState := writeScheduleState(&schedule, plan)
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the schedule.
+func (r *containerScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	// Retrieve values from state
+	var state ContainerScheduleSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := deleteSchedule(ctx, r.client, state.ID.ValueString())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting schedule",
+			errorDetail("Could not delete schedule "+state.Name.ValueString(), err),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *containerScheduleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+// scheduleFromPlan maps the resource model to the payload of the schedule endpoints.
+func scheduleFromPlan(plan ContainerScheduleSpecModel) containerScheduleSpec {
+	containerType := plan.ContainerType.ValueString()
+	if containerType == "" {
+		containerType = "docker"
+	}
+	return containerScheduleSpec{
+		Name:    plan.Name.ValueString(),
+		Action:  plan.Action.ValueString(),
+		Cron:    plan.Schedule.ValueString(),
+		Enabled: plan.Enabled.IsNull() || plan.Enabled.ValueBool(),
+		Items:   []qnap.Item{{CID: plan.ContainerID.ValueString(), CType: containerType}},
+	}
+}
+
+// writeScheduleState maps a schedule returned by the API to the resource model. Optional attributes that are unset in
+// the prior model stay null while the NAS reports their default.
+func writeScheduleState(schedule *containerScheduleSpec, prior ContainerScheduleSpecModel) ContainerScheduleSpecModel {
+	state := ContainerScheduleSpecModel{
+		ID:            types.StringValue(schedule.ID),
+		Name:          types.StringValue(schedule.Name),
+		ContainerID:   prior.ContainerID,
+		ContainerType: prior.ContainerType,
+		Action:        types.StringValue(schedule.Action),
+		Schedule:      prior.Schedule,
+		Enabled:       types.BoolValue(schedule.Enabled),
+		LastUpdated:   types.StringValue(time.Now().Format(time.RFC850)),
+	}
+	if len(schedule.Items) > 0 {
+		state.ContainerID = types.StringValue(schedule.Items[0].CID)
+		if !prior.ContainerType.IsNull() || schedule.Items[0].CType != "docker" {
+			state.ContainerType = types.StringValue(schedule.Items[0].CType)
+		}
+	}
+	// The NAS may normalize the whitespace of the expression
+	if !cronEqual(prior.Schedule.ValueString(), schedule.Cron) {
+		state.Schedule = types.StringValue(schedule.Cron)
+	}
+	if prior.Enabled.IsNull() && schedule.Enabled {
+		state.Enabled = types.BoolNull()
+	}
+	return state
+}
+
+// cronEqual reports whether two cron expressions have the same fields.
+func cronEqual(a string, b string) bool {
+	return strings.Join(strings.Fields(a), " ") == strings.Join(strings.Fields(b), " ")
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccContainerScheduleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: `
+					resource "qnap_container" "schedule" {
+					name              = "terraform_test_schedule"
+					image             = "nginx:latest"
+					type              = "docker"
+					removeanonvolumes = true
+					}
+
+					resource "qnap_container_schedule" "restart" {
+					name         = "terraform_test_nightly_restart"
+					container_id = qnap_container.schedule.id
+					action       = "restart"
+					schedule     = "0 3 * * *"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("qnap_container_schedule.restart", "id"),
+					resource.TestCheckResourceAttr("qnap_container_schedule.restart", "action", "restart"),
+					resource.TestCheckResourceAttr("qnap_container_schedule.restart", "schedule", "0 3 * * *"),
+					resource.TestCheckNoResourceAttr("qnap_container_schedule.restart", "enabled"),
+				),
+			},
+			// test case 2
+			{
+				Config: `
+					resource "qnap_container" "schedule" {
+					name              = "terraform_test_schedule"
+					image             = "nginx:latest"
+					type              = "docker"
+					removeanonvolumes = true
+					}
+
+					resource "qnap_container_schedule" "restart" {
+					name         = "terraform_test_nightly_restart"
+					container_id = qnap_container.schedule.id
+					action       = "stop"
+					schedule     = "30 2 * * 1"
+					enabled      = false
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container_schedule.restart", "action", "stop"),
+					resource.TestCheckResourceAttr("qnap_container_schedule.restart", "schedule", "30 2 * * 1"),
+					resource.TestCheckResourceAttr("qnap_container_schedule.restart", "enabled", "false"),
+				),
+			},
+			// test case 3
+			{
+				Config: `
+					resource "qnap_container_schedule" "restart" {
+					name         = "terraform_test_nightly_restart"
+					container_id = "0123456789ab"
+					action       = "restart"
+					schedule     = "every night"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`cron expression of five fields`),
+			},
+		},
+	})
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ConfigValidator = staticIPNetworkTypeValidator{}
+	_ resource.ConfigValidator = hostIPValidator{}
+	_ resource.ConfigValidator = autoRemoveRestartPolicyValidator{}
+	_ validator.Object         = restartPolicyRetryValidator{}
+)
+
+// staticIPNetworkTypes are the network types whose containers get an address of the network, which can be fixed with
+// ipaddress and ipaddress6. Containers on the other network types share the addresses of the NAS or have none.
+var staticIPNetworkTypes = map[string]bool{
+	"bridge": true,
+	"ipvlan": true,
+}
+
+// staticIPNetworkTypeValidator validates that ipaddress and ipaddress6 are only set with a bridge or ipvlan networktype,
+// as Container Station ignores them on the other network types.
+type staticIPNetworkTypeValidator struct{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v staticIPNetworkTypeValidator) Description(_ context.Context) string {
+	return "ipaddress and ipaddress6 can only be set when networktype is bridge or ipvlan"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v staticIPNetworkTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation.
+func (v staticIPNetworkTypeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var networkType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("networktype"), &networkType)...)
+	if resp.Diagnostics.HasError() || networkType.IsNull() || networkType.IsUnknown() || staticIPNetworkTypes[networkType.ValueString()] {
+		return
+	}
+
+	for _, attribute := range []string{"ipaddress", "ipaddress6"} {
+		var address types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &address)...)
+		if address.IsNull() {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Static IP Address",
+			fmt.Sprintf("%s can only be set when networktype is bridge or ipvlan, containers on a %s network cannot have an address of their own.", attribute, networkType.ValueString()),
+		)
+	}
+}
+
+// hostIPValidator validates that the hostip of the port bindings are addresses the NAS can listen on: an unspecified
+// address to listen on every address of the NAS or a unicast address. Whether the NAS has the address is checked when
+// the container is created or updated.
+type hostIPValidator struct{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v hostIPValidator) Description(_ context.Context) string {
+	return "the hostip of the port bindings must be an unspecified or unicast address"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v hostIPValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation.
+func (v hostIPValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var portBindings portBindingsValue
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("portbindings"), &portBindings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, element := range portBindings.Elements() {
+		binding, ok := element.(portBindingValue)
+		if !ok {
+			continue
+		}
+		hostIP, ok := binding.Attributes()["hostip"].(types.String)
+		if !ok || hostIP.IsNull() || hostIP.IsUnknown() {
+			continue
+		}
+		ip := net.ParseIP(hostIP.ValueString())
+		if ip == nil || ip.IsUnspecified() {
+			continue
+		}
+		if ip.IsMulticast() || ip.IsInterfaceLocalMulticast() || ip.Equal(net.IPv4bcast) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("portbindings").AtListIndex(i).AtName("hostip"),
+				"Invalid Host IP Address",
+				fmt.Sprintf("hostip %s is not an address of the NAS, use an address of the NAS or 0.0.0.0 to listen on every address.", ip),
+			)
+		}
+	}
+}
+
+// restartPolicyRetryValidator validates that maximumretrycount is only set with the onFailure restart policy, the only
+// policy that gives up restarting the container.
+type restartPolicyRetryValidator struct{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v restartPolicyRetryValidator) Description(_ context.Context) string {
+	return "maximumretrycount can only be set when the restart policy is onFailure"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v restartPolicyRetryValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateObject performs the validation.
+func (v restartPolicyRetryValidator) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+	name, ok := attributes["name"].(types.String)
+	if !ok || name.IsNull() || name.IsUnknown() || name.ValueString() == "onFailure" {
+		return
+	}
+	retries, ok := attributes["maximumretrycount"].(types.Int32)
+	if !ok || retries.IsNull() || retries.IsUnknown() || retries.ValueInt32() == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path.AtName("maximumretrycount"),
+		"Invalid Restart Policy",
+		fmt.Sprintf("maximumretrycount can only be set when the restart policy is onFailure, the %s policy restarts the container without a limit.", name.ValueString()),
+	)
+}
+
+// autoRemoveRestartPolicyValidator validates that autoremove is only set with the no restart policy, as a container
+// that is removed when it exits cannot be restarted.
+type autoRemoveRestartPolicyValidator struct{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v autoRemoveRestartPolicyValidator) Description(_ context.Context) string {
+	return "autoremove can only be enabled when the restart policy is no"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v autoRemoveRestartPolicyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation.
+func (v autoRemoveRestartPolicyValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var autoRemove types.Bool
+	var name types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("autoremove"), &autoRemove)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("restartpolicy").AtName("name"), &name)...)
+	if resp.Diagnostics.HasError() || !autoRemove.ValueBool() || name.IsNull() || name.IsUnknown() || name.ValueString() == "no" {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("autoremove"),
+		"Invalid Restart Policy",
+		fmt.Sprintf("autoremove cannot be enabled with the %s restart policy, a container that is removed when it exits cannot be restarted. Set the restart policy to no or disable autoremove.", name.ValueString()),
+	)
+}
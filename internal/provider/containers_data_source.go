@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -187,8 +188,10 @@ func (d *containersDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 							Description: "The command of the container.",
 						},
 						"portbindings": schema.ListNestedAttribute{
-							Computed: true,
+							Computed:   true,
+							CustomType: newPortBindingsType(containersPortBindingAttrTypes),
 							NestedObject: schema.NestedAttributeObject{
+								CustomType: newPortBindingType(containersPortBindingAttrTypes),
 								Attributes: map[string]schema.Attribute{
 									"host": schema.Int32Attribute{
 										Required:    true,
@@ -264,10 +267,11 @@ func (d *containersDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	var state containersDataSourceModel
 
 	containers, err := d.client.GetContainers()
+	err = withRequest(err, http.MethodGet, "/container-station/api/v3/containers")
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read QNAP Containers",
-			err.Error(),
+			errorDetail("Could not read the containers of the NAS", err),
 		)
 		return
 	}
@@ -310,7 +314,7 @@ func (d *containersDataSource) Read(ctx context.Context, req datasource.ReadRequ
 			containerState.PortBindings = append(containerState.PortBindings, containersPortBindingsModel{
 				Host:        types.Int32Value(portBinding.Host),
 				Container:   types.Int32Value(portBinding.Container),
-				Protocol:    types.StringValue(portBinding.Protocol),
+				Protocol:    types.StringValue(normalizePortBindingString("protocol", portBinding.Protocol)),
 				HostIP:      types.StringValue(portBinding.HostIP),
 				ContainerIP: types.StringValue(portBinding.ContainerIP),
 			})
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// cpupinTypeShared and cpupinTypeDedicated are the types of CPU pinning: shared containers run on every CPU and dedicated
+// containers only run on their cpuids.
+const (
+	cpupinTypeShared    = "shared"
+	cpupinTypeDedicated = "dedicated"
+)
+
+// cpuSetPattern matches a comma separated list of CPU IDs and ranges.
+var cpuSetPattern = regexp.MustCompile(`^[0-9]+(-[0-9]+)?(,[0-9]+(-[0-9]+)?)*$`)
+
+// parseCPUSet parses a list of CPU IDs and ranges (e.g. 0,2-3) and returns the highest CPU ID.
+func parseCPUSet(cpuSet string) (int, error) {
+	if !cpuSetPattern.MatchString(cpuSet) {
+		return 0, fmt.Errorf("%q is not a comma separated list of CPU IDs and ranges", cpuSet)
+	}
+
+	highest := 0
+	for _, item := range strings.Split(cpuSet, ",") {
+		first, last, isRange := strings.Cut(item, "-")
+		start, _ := strconv.Atoi(first)
+		end := start
+		if isRange {
+			end, _ = strconv.Atoi(last)
+			if end < start {
+				return 0, fmt.Errorf("the range %s ends before it starts", item)
+			}
+		}
+		highest = max(highest, end)
+	}
+	return highest, nil
+}
+
+// cpuSetValidator validates a list of CPU IDs and ranges.
+type cpuSetValidator struct{}
+
+// cpuSet validates a list of CPU IDs and ranges (e.g. 0,2-3).
+func cpuSet() validator.String {
+	return cpuSetValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v cpuSetValidator) Description(_ context.Context) string {
+	return "value must be a comma separated list of CPU IDs and ranges (e.g. 0,2-3)"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v cpuSetValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v cpuSetValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseCPUSet(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid CPU IDs",
+			fmt.Sprintf("Attribute %s %s, %s.", req.Path, v.Description(ctx), err.Error()),
+		)
+	}
+}
+
+// cpupinValidator validates that the cpuids of a CPU pinning are set with the dedicated type and not with the shared
+// type.
+type cpupinValidator struct{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v cpupinValidator) Description(_ context.Context) string {
+	return "cpuids must be set when the type is dedicated and cannot be set when the type is shared"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v cpupinValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateObject performs the validation.
+func (v cpupinValidator) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+	pinType, ok := attributes["type"].(types.String)
+	if !ok || pinType.IsNull() || pinType.IsUnknown() {
+		return
+	}
+	cpuIDs, ok := attributes["cpuids"].(types.String)
+	if !ok || cpuIDs.IsUnknown() {
+		return
+	}
+
+	switch pinType.ValueString() {
+	case cpupinTypeDedicated:
+		if cpuIDs.ValueString() == "" {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtName("cpuids"),
+				"Missing CPU IDs",
+				"cpuids must be set when the CPU pinning type is dedicated, the container only runs on the CPUs it is pinned to.",
+			)
+		}
+	case cpupinTypeShared:
+		if cpuIDs.ValueString() != "" {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtName("cpuids"),
+				"Invalid CPU IDs",
+				"cpuids cannot be set when the CPU pinning type is shared, the container runs on every CPU. Set the type to dedicated to pin the container.",
+			)
+		}
+	}
+}
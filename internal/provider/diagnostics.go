@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// apiError is an error response of the QNAP API. Its message keeps the format of the client library so isNotFound,
+// isAppNotFound and apiStatus keep working.
+type apiError struct {
+	Method string
+	Path   string
+	Status int
+	Body   string
+}
+
+// Error implements error.
+func (e *apiError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.Status, e.Body)
+}
+
+// apiErrorPattern matches the status and body of an error response of the client library or of apiRequest.
+var apiErrorPattern = regexp.MustCompile(`(?s)status: (\d+), body: (.*)`)
+
+// withRequest adds the method and path of the request to an error response the client library returned, which only
+// reports the status and body.
+func withRequest(err error, method string, path string) error {
+	var apiErr *apiError
+	if err == nil || errors.As(err, &apiErr) {
+		return err
+	}
+	match := apiErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil || !strings.HasPrefix(err.Error(), "status: ") {
+		return err
+	}
+	status, _ := strconv.Atoi(match[1])
+	return &apiError{Method: method, Path: path, Status: status, Body: match[2]}
+}
+
+// errorDetail returns the detail of an error diagnostic: the message, the error, the request and QNAP error code when
+// the API rejected the request and a hint on how to fix the error.
+func errorDetail(message string, err error) string {
+	detail := message + ", unexpected error: " + err.Error()
+
+	var lines []string
+	status, code := 0, 0
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		status, code = apiErr.Status, qnapErrorCode(apiErr.Body)
+		lines = append(lines, "Request: "+apiErr.Method+" "+apiErr.Path)
+	} else if match := apiErrorPattern.FindStringSubmatch(err.Error()); match != nil {
+		status, _ = strconv.Atoi(match[1])
+		code = qnapErrorCode(match[2])
+	}
+	if status != 0 {
+		lines = append(lines, fmt.Sprintf("HTTP status: %d %s", status, http.StatusText(status)))
+	}
+	if code != 0 {
+		lines = append(lines, fmt.Sprintf("QNAP error code: %d", code))
+	}
+	if len(lines) > 0 {
+		detail += "\n\n" + strings.Join(lines, "\n")
+	}
+
+	if hint := errorHint(err, status); hint != "" {
+		detail += "\n\n" + hint
+	}
+	return detail
+}
+
+// qnapErrorCode returns the error code of a Container Station error response, zero when the body has none.
+func qnapErrorCode(body string) int {
+	var response struct {
+		Code int `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		return 0
+	}
+	return response.Code
+}
+
+// errorHint returns how to fix an error, empty when there is no general advice.
+func errorHint(err error, status int) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "The operation did not complete in time. Give it more time with the timeouts block of the resource or the default_timeouts of the provider."
+	case status == http.StatusBadRequest:
+		return "Container Station rejected the request. Check the attribute values named in the error against what the NAS supports."
+	case status == http.StatusUnauthorized:
+		return "The NAS rejected the session. Check the username and password or access token of the provider."
+	case status == http.StatusForbidden:
+		return "The account of the provider is not allowed to do this. Use an administrator account or grant it the Container Station permission."
+	case status == http.StatusNotFound:
+		return "The object does not exist on the NAS, it may have been removed outside of Terraform. If it should exist, check that the api_version of the provider is served by Container Station."
+	case status == http.StatusConflict:
+		return "An object with the same name already exists on the NAS. Import it or choose another name."
+	case status >= http.StatusInternalServerError:
+		return "Container Station failed to handle the request. Check the Container Station logs on the NAS and try again."
+	case status == 0 && errors.As(err, &netErr):
+		return "The NAS could not be reached. Check the host of the provider and that Container Station is running."
+	}
+	return ""
+}
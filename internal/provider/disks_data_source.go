@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &disksDataSource{}
+	_ datasource.DataSourceWithConfigure = &disksDataSource{}
+)
+
+// disksDataSource is the data source implementation.
+type disksDataSource struct {
+	client *qnap.Client
+}
+
+// disksDataSourceModel maps the data source schema data.
+type disksDataSourceModel struct {
+	Healthy types.Bool  `tfsdk:"healthy"`
+	Disks   []diskModel `tfsdk:"disks"`
+}
+
+// diskModel maps a physical disk of the NAS.
+type diskModel struct {
+	Slot        types.String `tfsdk:"slot"`
+	Model       types.String `tfsdk:"model"`
+	Serial      types.String `tfsdk:"serial"`
+	Capacity    types.Int64  `tfsdk:"capacity"`
+	Temperature types.Int32  `tfsdk:"temperature"`
+	SmartStatus types.String `tfsdk:"smart_status"`
+}
+
+// NewDisksDataSource is a helper function to simplify the provider implementation.
+func NewDisksDataSource() datasource.DataSource {
+	return &disksDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *disksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_disks"
+}
+
+// Schema defines the schema for the data source.
+func (d *disksDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the physical disks of the NAS and their SMART health, for example to block deployments onto a NAS with a failing disk in a precondition.",
+		Attributes: map[string]schema.Attribute{
+			"healthy": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the SMART status of every disk is good.",
+			},
+			"disks": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The physical disks of the NAS.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"slot": schema.StringAttribute{
+							Computed:    true,
+							Description: "The slot of the disk in the NAS or in an expansion unit.",
+						},
+						"model": schema.StringAttribute{
+							Computed:    true,
+							Description: "The model of the disk.",
+						},
+						"serial": schema.StringAttribute{
+							Computed:    true,
+							Description: "The serial number of the disk.",
+						},
+						"capacity": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The capacity of the disk in bytes.",
+						},
+						"temperature": schema.Int32Attribute{
+							Computed:    true,
+							Description: "The temperature of the disk in degrees Celsius.",
+						},
+						"smart_status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The SMART status of the disk (good, warning, abnormal).",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *disksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state disksDataSourceModel
+
+	disks, err := listDisks(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read QNAP Disks",
+			errorDetail("Could not read the disks of the NAS", err),
+		)
+		return
+	}
+
+	healthy := true
+	state.Disks = []diskModel{}
+	for _, disk := range disks {
+		if disk.SmartStatus != "good" {
+			healthy = false
+		}
+		state.Disks = append(state.Disks, diskModel{
+			Slot:        types.StringValue(disk.Slot),
+			Model:       types.StringValue(disk.Model),
+			Serial:      types.StringValue(disk.Serial),
+			Capacity:    types.Int64Value(disk.Capacity),
+			Temperature: types.Int32Value(disk.Temperature),
+			SmartStatus: types.StringValue(disk.SmartStatus),
+		})
+	}
+	state.Healthy = types.BoolValue(healthy)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *disksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qnap.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qnap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDisksDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "qnap_disks" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.qnap_disks.test", "healthy"),
+					resource.TestCheckResourceAttrSet("data.qnap_disks.test", "disks.0.model"),
+					resource.TestCheckResourceAttrSet("data.qnap_disks.test", "disks.0.capacity"),
+					resource.TestCheckResourceAttrSet("data.qnap_disks.test", "disks.0.smart_status"),
+				),
+			},
+		},
+	})
+}
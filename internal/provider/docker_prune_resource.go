@@ -0,0 +1,331 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &dockerPruneResource{}
+	_ resource.ResourceWithConfigure = &dockerPruneResource{}
+)
+
+type DockerPruneSpecModel struct {
+	ID              basetypes.StringValue `tfsdk:"id"`
+	DryRun          basetypes.BoolValue   `tfsdk:"dry_run"`
+	Triggers        basetypes.MapValue    `tfsdk:"triggers"`
+	PruneContainers basetypes.BoolValue   `tfsdk:"prune_containers"`
+	PruneImages     basetypes.StringValue `tfsdk:"prune_images"`
+	PruneVolumes    basetypes.BoolValue   `tfsdk:"prune_volumes"`
+	PruneNetworks   basetypes.BoolValue   `tfsdk:"prune_networks"`
+	Containers      basetypes.ListValue   `tfsdk:"containers"`
+	Images          basetypes.ListValue   `tfsdk:"images"`
+	Volumes         basetypes.ListValue   `tfsdk:"volumes"`
+	Networks        basetypes.ListValue   `tfsdk:"networks"`
+	LastUpdated     basetypes.StringValue `tfsdk:"last_updated"`
+}
+
+// dockerPruneResource is the resource implementation.
+type dockerPruneResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewDockerPruneResource is a helper function to simplify the provider implementation.
+func NewDockerPruneResource() resource.Resource {
+	return &dockerPruneResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *dockerPruneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_docker_prune"
+}
+
+// Schema defines the schema for the resource.
+func (r *dockerPruneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reclaims disk space on the NAS like docker system prune: removes the stopped containers, then the unused images, the dangling anonymous volumes and the unused networks. The prune runs when the resource is created and again when any of the triggers change. The containers, volumes and networks of docker-compose apps are never pruned.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the prune.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dry_run": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list what would be removed without removing it. As nothing is removed, the images and networks only used by stopped containers are not listed. Defaults to false.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that prune again when changed. Use timestamp() to prune on every apply.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"prune_containers": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to remove the containers that are not running, paused or restarting. Defaults to true.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"prune_images": schema.StringAttribute{
+				Optional:    true,
+				Description: "Which images no container uses to remove: dangling for the images without a tag, unused for all of them or none. Defaults to dangling.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("dangling", "unused", "none"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"prune_volumes": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to remove the anonymous volumes that no container uses. Named volumes are never pruned. Defaults to false.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"prune_networks": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to remove the networks no container is connected to. The bridge, host and none networks are never pruned. Defaults to true.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"containers": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The names of the removed containers, or of the containers that would be removed on a dry run.",
+			},
+			"images": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The removed images, name:tag or the ID of dangling images, or the images that would be removed on a dry run.",
+			},
+			"volumes": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The names of the removed volumes, or of the volumes that would be removed on a dry run.",
+			},
+			"networks": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The names of the removed networks, or of the networks that would be removed on a dry run.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the prune.",
+			},
+		},
+	}
+}
+
+// Create prunes the unused objects.
+func (r *dockerPruneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan DockerPruneSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	dryRun := plan.DryRun.ValueBool()
+
+	// Containers go first so their images, volumes and networks become unused
+	containers := []string{}
+	if plan.PruneContainers.IsNull() || plan.PruneContainers.ValueBool() {
+		stopped, err := stoppedContainers(r.client)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error pruning containers",
+				errorDetail("Could not list the stopped containers", err),
+			)
+			return
+		}
+		var errs []error
+		for _, container := range stopped {
+			if !dryRun {
+				_, err := r.client.DeleteContainer(container.ID, container.Type, false, requestToken(r.client))
+				if err = withRequest(err, http.MethodDelete, "/container-station/api/v3/containers"); err != nil && !isNotFound(err) {
+					errs = append(errs, fmt.Errorf("container %s: %w", container.Name, err))
+					continue
+				}
+			}
+			containers = append(containers, container.Name)
+		}
+		if err := errors.Join(errs...); err != nil {
+			// The objects removed before the error are still reported in state
+			resp.Diagnostics.AddWarning(
+				"Error pruning containers",
+				errorDetail("Could not remove all stopped containers", err),
+			)
+		}
+	}
+
+	images := []string{}
+	if pruneImages := plan.PruneImages.ValueString(); pruneImages != "none" {
+		unused, err := unusedImages(ctx, r.client, pruneImages == "unused")
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error pruning images",
+				errorDetail("Could not list the unused images", err),
+			)
+			return
+		}
+		var errs []error
+		for _, image := range unused {
+			name := image.ID
+			if image.Tag != "" && image.Tag != "<none>" {
+				name = image.Name + ":" + image.Tag
+			}
+			if !dryRun {
+				if err := deleteImage(ctx, r.client, image.ID); err != nil && !isNotFound(err) {
+					errs = append(errs, fmt.Errorf("image %s: %w", name, err))
+					continue
+				}
+			}
+			images = append(images, name)
+		}
+		if err := errors.Join(errs...); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Error pruning images",
+				errorDetail("Could not remove all unused images", err),
+			)
+		}
+	}
+
+	volumes := []string{}
+	if plan.PruneVolumes.ValueBool() {
+		dangling, err := danglingVolumes(ctx, r.client)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error pruning volumes",
+				errorDetail("Could not list the dangling volumes", err),
+			)
+			return
+		}
+		volumes = dangling
+		if !dryRun {
+			volumes, err = deleteVolumes(ctx, r.client, dangling)
+			if err != nil {
+				resp.Diagnostics.AddWarning(
+					"Error pruning volumes",
+					errorDetail("Could not remove all dangling volumes", err),
+				)
+			}
+		}
+	}
+
+	networks := []string{}
+	if plan.PruneNetworks.IsNull() || plan.PruneNetworks.ValueBool() {
+		unused, err := unusedNetworks(ctx, r.client)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error pruning networks",
+				errorDetail("Could not list the unused networks", err),
+			)
+			return
+		}
+		var errs []error
+		for _, network := range unused {
+			if !dryRun {
+				if err := deleteNetwork(ctx, r.client, network.ID); err != nil && !isNotFound(err) {
+					errs = append(errs, fmt.Errorf("network %s: %w", network.Name, err))
+					continue
+				}
+			}
+			networks = append(networks, network.Name)
+		}
+		if err := errors.Join(errs...); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Error pruning networks",
+				errorDetail("Could not remove all unused networks", err),
+			)
+		}
+	}
+
+	now := time.Now()
+	plan.ID = types.StringValue(now.Format(time.RFC3339))
+	plan.LastUpdated = types.StringValue(now.Format(time.RFC850))
+	for _, list := range []struct {
+		value *basetypes.ListValue
+		names []string
+	}{
+		{&plan.Containers, containers},
+		{&plan.Images, images},
+		{&plan.Volumes, volumes},
+		{&plan.Networks, networks},
+	} {
+		*list.value, diags = types.ListValueFrom(ctx, types.StringType, list.names)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the result of the last prune in state.
+func (r *dockerPruneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update is never called as every attribute requires replacement.
+func (r *dockerPruneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+// Delete removes the prune from the Terraform state.
+func (r *dockerPruneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *dockerPruneResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
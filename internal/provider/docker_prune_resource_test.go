@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccDockerPruneResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: `
+					resource "qnap_docker_prune" "dry_run" {
+					dry_run       = true
+					prune_images  = "unused"
+					prune_volumes = true
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_docker_prune.dry_run", "dry_run", "true"),
+					resource.TestCheckResourceAttrSet("qnap_docker_prune.dry_run", "containers.#"),
+					resource.TestCheckResourceAttrSet("qnap_docker_prune.dry_run", "images.#"),
+					resource.TestCheckResourceAttrSet("qnap_docker_prune.dry_run", "volumes.#"),
+					resource.TestCheckResourceAttrSet("qnap_docker_prune.dry_run", "networks.#"),
+				),
+			},
+			// test case 2 - prune again when a trigger changes
+			{
+				Config: `
+					resource "qnap_docker_prune" "dry_run" {
+					dry_run       = true
+					prune_images  = "unused"
+					prune_volumes = true
+					triggers = {
+						run = "2"
+					}
+					}
+
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_docker_prune.dry_run", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_docker_prune.dry_run", "triggers.run", "2"),
+				),
+			},
+		},
+	})
+}
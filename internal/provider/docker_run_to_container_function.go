@@ -0,0 +1,520 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &dockerRunToContainerFunction{}
+
+// dockerRunContainer is the qnap_container compatible object returned by docker_run_to_container.
+type dockerRunContainer struct {
+	Name          types.String            `tfsdk:"name"`
+	Image         types.String            `tfsdk:"image"`
+	Type          types.String            `tfsdk:"type"`
+	Cmd           []string                `tfsdk:"cmd"`
+	Entrypoint    []string                `tfsdk:"entrypoint"`
+	Env           map[string]string       `tfsdk:"env"`
+	Labels        map[string]string       `tfsdk:"labels"`
+	Hostname      types.String            `tfsdk:"hostname"`
+	Network       types.String            `tfsdk:"network"`
+	NetworkType   types.String            `tfsdk:"networktype"`
+	Privileged    types.Bool              `tfsdk:"privileged"`
+	AutoRemove    types.Bool              `tfsdk:"autoremove"`
+	Tty           types.Bool              `tfsdk:"tty"`
+	OpenStdin     types.Bool              `tfsdk:"openstdin"`
+	Runtime       types.String            `tfsdk:"runtime"`
+	DNS           []string                `tfsdk:"dns"`
+	DNSSearch     []string                `tfsdk:"dns_search"`
+	MemLimit      types.Int32             `tfsdk:"mem_limit"`
+	RestartPolicy *dockerRunRestartPolicy `tfsdk:"restartpolicy"`
+	PortBindings  []dockerRunPortBinding  `tfsdk:"portbindings"`
+	Volumes       []dockerRunVolume       `tfsdk:"volumes"`
+	Tmpfs         []dockerRunTmpfs        `tfsdk:"tmpfs"`
+	Devices       []dockerRunDevice       `tfsdk:"devices"`
+}
+
+// dockerRunRestartPolicy maps the restartpolicy of a qnap_container.
+type dockerRunRestartPolicy struct {
+	Name              types.String `tfsdk:"name"`
+	MaximumRetryCount types.Int32  `tfsdk:"maximumretrycount"`
+}
+
+// dockerRunPortBinding maps a portbindings entry of a qnap_container.
+type dockerRunPortBinding struct {
+	Host      types.Int32  `tfsdk:"host"`
+	Container types.Int32  `tfsdk:"container"`
+	Protocol  types.String `tfsdk:"protocol"`
+	HostIP    types.String `tfsdk:"hostip"`
+}
+
+// dockerRunVolume maps a volumes entry of a qnap_container.
+type dockerRunVolume struct {
+	Type        types.String `tfsdk:"type"`
+	Name        types.String `tfsdk:"name"`
+	Source      types.String `tfsdk:"source"`
+	Destination types.String `tfsdk:"destination"`
+	Permission  types.String `tfsdk:"permission"`
+}
+
+// dockerRunTmpfs maps a tmpfs entry of a qnap_container.
+type dockerRunTmpfs struct {
+	Destination types.String `tfsdk:"destination"`
+	Size        types.String `tfsdk:"size"`
+	Mode        types.String `tfsdk:"mode"`
+}
+
+// dockerRunDevice maps a devices entry of a qnap_container.
+type dockerRunDevice struct {
+	Name       types.String `tfsdk:"name"`
+	Permission types.String `tfsdk:"permission"`
+}
+
+// dockerRunContainerTypes are the attribute types of the object returned by docker_run_to_container.
+var dockerRunContainerTypes = map[string]attr.Type{
+	"name":        types.StringType,
+	"image":       types.StringType,
+	"type":        types.StringType,
+	"cmd":         types.ListType{ElemType: types.StringType},
+	"entrypoint":  types.ListType{ElemType: types.StringType},
+	"env":         types.MapType{ElemType: types.StringType},
+	"labels":      types.MapType{ElemType: types.StringType},
+	"hostname":    types.StringType,
+	"network":     types.StringType,
+	"networktype": types.StringType,
+	"privileged":  types.BoolType,
+	"autoremove":  types.BoolType,
+	"tty":         types.BoolType,
+	"openstdin":   types.BoolType,
+	"runtime":     types.StringType,
+	"dns":         types.ListType{ElemType: types.StringType},
+	"dns_search":  types.ListType{ElemType: types.StringType},
+	"mem_limit":   types.Int32Type,
+	"restartpolicy": types.ObjectType{AttrTypes: map[string]attr.Type{
+		"name":              types.StringType,
+		"maximumretrycount": types.Int32Type,
+	}},
+	"portbindings": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"host":      types.Int32Type,
+		"container": types.Int32Type,
+		"protocol":  types.StringType,
+		"hostip":    types.StringType,
+	}}},
+	"volumes": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"type":        types.StringType,
+		"name":        types.StringType,
+		"source":      types.StringType,
+		"destination": types.StringType,
+		"permission":  types.StringType,
+	}}},
+	"tmpfs": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"destination": types.StringType,
+		"size":        types.StringType,
+		"mode":        types.StringType,
+	}}},
+	"devices": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"name":       types.StringType,
+		"permission": types.StringType,
+	}}},
+}
+
+// dockerRunFlagsWithValue are the docker run options that take a value.
+var dockerRunFlagsWithValue = map[string]string{
+	"-e": "--env", "--env": "--env",
+	"-l": "--label", "--label": "--label",
+	"-p": "--publish", "--publish": "--publish",
+	"-v": "--volume", "--volume": "--volume",
+	"-h": "--hostname", "--hostname": "--hostname",
+	"-m": "--memory", "--memory": "--memory",
+	"--name": "--name", "--restart": "--restart", "--entrypoint": "--entrypoint",
+	"--network": "--network", "--net": "--network",
+	"--dns": "--dns", "--dns-search": "--dns-search",
+	"--tmpfs": "--tmpfs", "--device": "--device", "--runtime": "--runtime",
+}
+
+// dockerRunBoolFlags are the docker run options that take no value.
+var dockerRunBoolFlags = map[string]string{
+	"-d": "--detach", "--detach": "--detach",
+	"-i": "--interactive", "--interactive": "--interactive",
+	"-t": "--tty", "--tty": "--tty",
+	"--rm": "--rm", "--privileged": "--privileged",
+}
+
+// dockerRunToContainerFunction is the function implementation.
+type dockerRunToContainerFunction struct{}
+
+// NewDockerRunToContainerFunction is a helper function to simplify the provider implementation.
+func NewDockerRunToContainerFunction() function.Function {
+	return &dockerRunToContainerFunction{}
+}
+
+// Metadata returns the function name.
+func (f *dockerRunToContainerFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "docker_run_to_container"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *dockerRunToContainerFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts a docker run command line into qnap_container attributes.",
+		Description: "Converts a docker run command line into an object whose attributes have the names of the qnap_container attributes, " +
+			"to migrate containers started from shell scripts. Options that are not set are null. " +
+			"The supported options are --name, -e/--env, -l/--label, -p/--publish, -v/--volume, -h/--hostname, -m/--memory, " +
+			"--restart, --entrypoint, --network, --dns, --dns-search, --tmpfs, --device, --runtime, -d/--detach, -i/--interactive, " +
+			"-t/--tty, --rm and --privileged, any other option is an error.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "command",
+				Description: "The docker run command line, with or without the leading docker run.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: dockerRunContainerTypes,
+		},
+	}
+}
+
+// Run converts the docker run command line.
+func (f *dockerRunToContainerFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var command string
+
+	resp.Error = req.Arguments.Get(ctx, &command)
+	if resp.Error != nil {
+		return
+	}
+
+	container, err := parseDockerRun(command)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, container)
+}
+
+// parseDockerRun converts a docker run command line into qnap_container attributes.
+func parseDockerRun(command string) (*dockerRunContainer, error) {
+	args, err := splitCommandLine(command)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 0 && args[0] == "docker" {
+		args = args[1:]
+		if len(args) > 0 && args[0] == "container" {
+			args = args[1:]
+		}
+		if len(args) == 0 || args[0] != "run" {
+			return nil, fmt.Errorf("the command is not a docker run command")
+		}
+		args = args[1:]
+	}
+
+	container := &dockerRunContainer{
+		Type:        types.StringValue("docker"),
+		Network:     types.StringValue("bridge"),
+		NetworkType: types.StringValue("default"),
+	}
+	for len(args) > 0 {
+		arg := args[0]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			break
+		}
+		args = args[1:]
+		if arg == "--" {
+			break
+		}
+
+		name, value, hasValue := strings.Cut(arg, "=")
+		if !strings.HasPrefix(arg, "--") {
+			name, value, hasValue = arg[:2], arg[2:], len(arg) > 2
+		}
+		if flag, ok := dockerRunBoolFlags[name]; ok {
+			container.setBoolFlag(flag)
+			// Short options can be combined, as in -it.
+			if hasValue && !strings.HasPrefix(arg, "--") {
+				args = append([]string{"-" + value}, args...)
+			}
+			continue
+		}
+		flag, ok := dockerRunFlagsWithValue[name]
+		if !ok {
+			return nil, fmt.Errorf("the docker run option %s is not supported", name)
+		}
+		if !hasValue {
+			if len(args) == 0 {
+				return nil, fmt.Errorf("the docker run option %s needs a value", name)
+			}
+			value, args = args[0], args[1:]
+		}
+		if err := container.setFlag(flag, value); err != nil {
+			return nil, fmt.Errorf("invalid value %q for the docker run option %s: %w", value, name, err)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("the command has no image")
+	}
+	container.Image = types.StringValue(args[0])
+	if len(args) > 1 {
+		container.Cmd = args[1:]
+	}
+	return container, nil
+}
+
+// setBoolFlag sets the attribute of a docker run option that takes no value.
+func (c *dockerRunContainer) setBoolFlag(flag string) {
+	switch flag {
+	case "--interactive":
+		c.OpenStdin = types.BoolValue(true)
+	case "--tty":
+		c.Tty = types.BoolValue(true)
+	case "--rm":
+		c.AutoRemove = types.BoolValue(true)
+	case "--privileged":
+		c.Privileged = types.BoolValue(true)
+	}
+}
+
+// setFlag sets the attribute of a docker run option that takes a value.
+func (c *dockerRunContainer) setFlag(flag string, value string) error {
+	switch flag {
+	case "--name":
+		c.Name = types.StringValue(value)
+	case "--hostname":
+		c.Hostname = types.StringValue(value)
+	case "--runtime":
+		c.Runtime = types.StringValue(value)
+	case "--entrypoint":
+		c.Entrypoint = []string{value}
+	case "--dns":
+		c.DNS = append(c.DNS, value)
+	case "--dns-search":
+		c.DNSSearch = append(c.DNSSearch, value)
+	case "--env", "--label":
+		key, entry, found := strings.Cut(value, "=")
+		if !found {
+			return fmt.Errorf("expected KEY=VALUE")
+		}
+		if flag == "--env" {
+			if c.Env == nil {
+				c.Env = map[string]string{}
+			}
+			c.Env[key] = entry
+			return nil
+		}
+		if c.Labels == nil {
+			c.Labels = map[string]string{}
+		}
+		c.Labels[key] = entry
+	case "--network":
+		switch value {
+		case "bridge", "default":
+			c.Network, c.NetworkType = types.StringValue("bridge"), types.StringValue("default")
+		case "host", "none":
+			c.Network, c.NetworkType = types.StringValue(value), types.StringValue("default")
+		default:
+			c.Network, c.NetworkType = types.StringValue(value), types.StringValue("bridge")
+		}
+	case "--restart":
+		policy, retries, _ := strings.Cut(value, ":")
+		restartPolicy := &dockerRunRestartPolicy{MaximumRetryCount: types.Int32Value(0)}
+		switch policy {
+		case "no":
+			restartPolicy.Name = types.StringValue("no")
+		case "always":
+			restartPolicy.Name = types.StringValue("always")
+		case "unless-stopped":
+			restartPolicy.Name = types.StringValue("unlessStopped")
+		case "on-failure":
+			restartPolicy.Name = types.StringValue("onFailure")
+			if retries != "" {
+				count, err := strconv.ParseInt(retries, 10, 32)
+				if err != nil {
+					return fmt.Errorf("expected a number of retries")
+				}
+				restartPolicy.MaximumRetryCount = types.Int32Value(int32(count))
+			}
+		default:
+			return fmt.Errorf("expected no, always, unless-stopped or on-failure")
+		}
+		c.RestartPolicy = restartPolicy
+	case "--memory":
+		limit, err := parseMemoryMB(value)
+		if err != nil {
+			return err
+		}
+		c.MemLimit = types.Int32Value(limit)
+	case "--publish":
+		binding, err := parsePortBinding(value)
+		if err != nil {
+			return err
+		}
+		c.PortBindings = append(c.PortBindings, binding)
+	case "--volume":
+		volume, err := parseVolume(value)
+		if err != nil {
+			return err
+		}
+		c.Volumes = append(c.Volumes, volume)
+	case "--tmpfs":
+		destination, options, _ := strings.Cut(value, ":")
+		tmpfs := dockerRunTmpfs{Destination: types.StringValue(destination), Size: types.StringNull(), Mode: types.StringNull()}
+		for _, option := range strings.Split(options, ",") {
+			name, optionValue, _ := strings.Cut(option, "=")
+			switch name {
+			case "size":
+				tmpfs.Size = types.StringValue(optionValue)
+			case "mode":
+				tmpfs.Mode = types.StringValue(optionValue)
+			}
+		}
+		c.Tmpfs = append(c.Tmpfs, tmpfs)
+	case "--device":
+		parts := strings.Split(value, ":")
+		device := dockerRunDevice{Name: types.StringValue(parts[0]), Permission: types.StringValue("rwm")}
+		if len(parts) > 1 && parts[1] != parts[0] {
+			return fmt.Errorf("mapping a device to another path is not supported")
+		}
+		if len(parts) > 2 {
+			device.Permission = types.StringValue(parts[2])
+		}
+		c.Devices = append(c.Devices, device)
+	}
+	return nil
+}
+
+// parsePortBinding parses a [hostip:]host:container[/protocol] port binding.
+func parsePortBinding(value string) (dockerRunPortBinding, error) {
+	binding := dockerRunPortBinding{Protocol: types.StringValue("tcp"), HostIP: types.StringNull()}
+	ports, protocol, found := strings.Cut(value, "/")
+	if found {
+		binding.Protocol = types.StringValue(protocol)
+	}
+	parts := strings.Split(ports, ":")
+	if len(parts) == 3 {
+		binding.HostIP = types.StringValue(parts[0])
+		parts = parts[1:]
+	}
+	if len(parts) != 2 || parts[0] == "" {
+		return binding, fmt.Errorf("expected [HOSTIP:]HOSTPORT:CONTAINERPORT[/PROTOCOL]")
+	}
+	host, err := strconv.ParseInt(parts[0], 10, 32)
+	if err != nil {
+		return binding, fmt.Errorf("port ranges are not supported")
+	}
+	container, err := strconv.ParseInt(parts[1], 10, 32)
+	if err != nil {
+		return binding, fmt.Errorf("port ranges are not supported")
+	}
+	binding.Host = types.Int32Value(int32(host))
+	binding.Container = types.Int32Value(int32(container))
+	return binding, nil
+}
+
+// parseVolume parses a SOURCE:DESTINATION[:ro|rw] volume, sources that are paths are host volumes.
+func parseVolume(value string) (dockerRunVolume, error) {
+	volume := dockerRunVolume{Name: types.StringNull(), Source: types.StringNull(), Permission: types.StringValue("writable")}
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return volume, fmt.Errorf("expected SOURCE:DESTINATION[:ro|rw], anonymous volumes are not supported")
+	}
+	if len(parts) == 3 {
+		switch parts[2] {
+		case "ro":
+			volume.Permission = types.StringValue("readOnly")
+		case "rw":
+		default:
+			return volume, fmt.Errorf("expected the ro or rw mode")
+		}
+	}
+	volume.Destination = types.StringValue(parts[1])
+	if strings.HasPrefix(parts[0], "/") || strings.HasPrefix(parts[0], ".") {
+		volume.Type = types.StringValue("host")
+		volume.Source = types.StringValue(parts[0])
+		return volume, nil
+	}
+	volume.Type = types.StringValue("volume")
+	volume.Name = types.StringValue(parts[0])
+	return volume, nil
+}
+
+// parseMemoryMB parses a docker memory limit such as 512m or 2g into megabytes.
+func parseMemoryMB(value string) (int32, error) {
+	units := map[string]float64{"b": 1.0 / (1024 * 1024), "k": 1.0 / 1024, "m": 1, "g": 1024}
+	if value == "" {
+		return 0, fmt.Errorf("expected a memory size such as 512m or 2g")
+	}
+	number, unit := value, "b"
+	if last := strings.ToLower(value[len(value)-1:]); units[last] != 0 {
+		number, unit = value[:len(value)-1], last
+	}
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("expected a memory size such as 512m or 2g")
+	}
+	return int32(amount * units[unit]), nil
+}
+
+// splitCommandLine splits a command line into words the way a POSIX shell does, honoring quotes,
+// backslash escapes and line continuations.
+func splitCommandLine(command string) ([]string, error) {
+	words := []string{}
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, char := range command {
+		switch {
+		case escaped:
+			escaped = false
+			if char == '\n' {
+				continue
+			}
+			if quote == '"' && !strings.ContainsRune("$`\"\\", char) {
+				word.WriteRune('\\')
+			}
+			word.WriteRune(char)
+			inWord = true
+		case quote == '\'':
+			if char == '\'' {
+				quote = 0
+				continue
+			}
+			word.WriteRune(char)
+		case char == '\\':
+			escaped = true
+		case quote == '"':
+			if char == '"' {
+				quote = 0
+				continue
+			}
+			word.WriteRune(char)
+		case char == '\'' || char == '"':
+			quote = char
+			inWord = true
+		case char == ' ' || char == '\t' || char == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(char)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("the command has an unterminated quote or escape")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
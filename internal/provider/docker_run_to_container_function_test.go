@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDockerRunToContainerFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  web = provider::qnap::docker_run_to_container(
+    "docker run -d --name web -p 8080:80 -e TZ=UTC -v /share/web:/usr/share/nginx/html:ro --restart unless-stopped nginx:latest"
+  )
+}
+
+output "name" {
+  value = local.web.name
+}
+
+output "image" {
+  value = local.web.image
+}
+
+output "host_port" {
+  value = local.web.portbindings[0].host
+}
+
+output "permission" {
+  value = local.web.volumes[0].permission
+}
+
+output "restart" {
+  value = local.web.restartpolicy.name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("name", "web"),
+					resource.TestCheckOutput("image", "nginx:latest"),
+					resource.TestCheckOutput("host_port", "8080"),
+					resource.TestCheckOutput("permission", "readOnly"),
+					resource.TestCheckOutput("restart", "unlessStopped"),
+				),
+			},
+			{
+				Config: `
+output "web" {
+  value = provider::qnap::docker_run_to_container("docker run --cap-add NET_ADMIN nginx:latest")
+}
+`,
+				ExpectError: regexp.MustCompile("--cap-add is not supported"),
+			},
+		},
+	})
+}
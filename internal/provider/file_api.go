@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	gopath "path"
+	"strings"
+	"time"
+
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// fileStat is an entry of the File Station stat response.
+type fileStat struct {
+	Exist    int `json:"exist"`
+	IsFolder int `json:"isfolder"`
+}
+
+// pathExists reports whether the given absolute path exists on a shared folder of the NAS using the File Station API.
+func pathExists(ctx context.Context, client *qnap.Client, filePath string) (bool, error) {
+	sid, err := sessionToken(ctx, client)
+	if err != nil {
+		return false, err
+	}
+	if _, value, found := strings.Cut(sid, "="); found {
+		sid = value
+	}
+
+	query := url.Values{}
+	query.Set("func", "stat")
+	query.Set("sid", sid)
+	query.Set("path", gopath.Dir(filePath))
+	query.Set("file_total", "1")
+	query.Set("file_name", gopath.Base(filePath))
+
+	var response struct {
+		Status int        `json:"status"`
+		Datas  []fileStat `json:"datas"`
+	}
+	err = apiRequest(ctx, client, http.MethodGet, "/cgi-bin/filemanager/utilRequest.cgi?"+query.Encode(), nil, &response)
+	if err != nil {
+		return false, err
+	}
+	if len(response.Datas) == 0 {
+		return false, fmt.Errorf("file station returned no entry for %s, status: %d", filePath, response.Status)
+	}
+	return response.Datas[0].Exist == 1, nil
+}
+
+// fileArchive returns a base64 encoded tar archive that holds a single file, for the import endpoints of Container
+// Station which extract an archive.
+func fileArchive(name string, content []byte, mode int64) (string, error) {
+	var archive bytes.Buffer
+	writer := tar.NewWriter(&archive)
+	err := writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     mode,
+		Size:     int64(len(content)),
+		ModTime:  time.Now(),
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(content); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(archive.Bytes()), nil
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &firmwareDataSource{}
+	_ datasource.DataSourceWithConfigure = &firmwareDataSource{}
+)
+
+// firmwareDataSource is the data source implementation.
+type firmwareDataSource struct {
+	client *qnap.Client
+}
+
+// firmwareDataSourceModel maps the data source schema data.
+type firmwareDataSourceModel struct {
+	Model           types.String `tfsdk:"model"`
+	Platform        types.String `tfsdk:"platform"`
+	Version         types.String `tfsdk:"version"`
+	Build           types.String `tfsdk:"build"`
+	LatestVersion   types.String `tfsdk:"latest_version"`
+	LatestBuild     types.String `tfsdk:"latest_build"`
+	UpdateAvailable types.Bool   `tfsdk:"update_available"`
+}
+
+// NewFirmwareDataSource is a helper function to simplify the provider implementation.
+func NewFirmwareDataSource() datasource.DataSource {
+	return &firmwareDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *firmwareDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firmware"
+}
+
+// Schema defines the schema for the data source.
+func (d *firmwareDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the installed firmware of the NAS and whether an update is available, for example to require an up to date NAS in a precondition.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Computed:    true,
+				Description: "The model of the NAS.",
+			},
+			"platform": schema.StringAttribute{
+				Computed:    true,
+				Description: "The operating system of the NAS (QTS, QuTS hero).",
+			},
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The installed firmware version, for example 5.2.1.",
+			},
+			"build": schema.StringAttribute{
+				Computed:    true,
+				Description: "The build number of the installed firmware.",
+			},
+			"latest_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The latest firmware version published for the model, the installed version when the NAS could not check for updates.",
+			},
+			"latest_build": schema.StringAttribute{
+				Computed:    true,
+				Description: "The build number of the latest firmware.",
+			},
+			"update_available": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether a newer firmware than the installed one is available.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *firmwareDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state firmwareDataSourceModel
+
+	firmware, err := getFirmwareInfo(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read QNAP Firmware",
+			errorDetail("Could not read the firmware of the NAS", err),
+		)
+		return
+	}
+
+	latestVersion, latestBuild := firmware.LatestVersion, firmware.LatestBuild
+	if latestVersion == "" {
+		latestVersion, latestBuild = firmware.Version, firmware.Build
+	}
+
+	state.Model = types.StringValue(firmware.Model)
+	state.Platform = types.StringValue(firmware.Platform)
+	state.Version = types.StringValue(firmware.Version)
+	state.Build = types.StringValue(firmware.Build)
+	state.LatestVersion = types.StringValue(latestVersion)
+	state.LatestBuild = types.StringValue(latestBuild)
+	state.UpdateAvailable = types.BoolValue(firmware.UpdateAvailable)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *firmwareDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qnap.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qnap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
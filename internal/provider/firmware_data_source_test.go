@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFirmwareDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "qnap_firmware" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.qnap_firmware.test", "model"),
+					resource.TestCheckResourceAttrSet("data.qnap_firmware.test", "version"),
+					resource.TestCheckResourceAttrSet("data.qnap_firmware.test", "latest_version"),
+					resource.TestCheckResourceAttrSet("data.qnap_firmware.test", "update_available"),
+				),
+			},
+		},
+	})
+}
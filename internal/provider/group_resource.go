@@ -0,0 +1,369 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &groupResource{}
+	_ resource.ResourceWithConfigure   = &groupResource{}
+	_ resource.ResourceWithImportState = &groupResource{}
+)
+
+type GroupSpecModel struct {
+	ID          basetypes.StringValue `tfsdk:"id"`
+	Name        basetypes.StringValue `tfsdk:"name"`
+	GID         basetypes.Int64Value  `tfsdk:"gid"`
+	Description basetypes.StringValue `tfsdk:"description"`
+	Members     basetypes.ListValue   `tfsdk:"members"`
+	LastUpdated basetypes.StringValue `tfsdk:"last_updated"`
+}
+
+// groupResource is the resource implementation.
+type groupResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewGroupResource is a helper function to simplify the provider implementation.
+func NewGroupResource() resource.Resource {
+	return &groupResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *groupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *groupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a group of user accounts of the NAS and its members, for example to grant the users of an application access to a shared folder with qnap_smb_share. Existing groups can be imported by name. Destroying the resource removes the group, the user accounts in it are kept.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the group, its name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the group.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`), "Group name must be up to 128 characters, starts with a letter or number. Valid characters: letters (A-Z, a-z), numbers (0-9), hyphen (-), period (.), underscore (_)"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gid": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The numeric ID of the group, for example to run a container with the GID that owns the files of a shared folder. Defaults to the next free GID of the NAS.",
+				Validators: []validator.Int64{
+					int64validator.Between(100, 65535),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "The description of the group.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(128),
+				},
+			},
+			"members": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The names of the user accounts in the group. The accounts must exist, members added outside of Terraform are removed on the next apply.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The last updated timestamp of the group.",
+			},
+		},
+	}
+}
+
+// Create a new resource.
+func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan GroupSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group := nasGroup{
+		Name:        plan.Name.ValueString(),
+		GID:         plan.GID.ValueInt64(),
+		Description: plan.Description.ValueString(),
+	}
+	diags = plan.Members.ElementsAs(ctx, &group.Members, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := findGroup(ctx, r.client, group.Name)
+	if err == nil && existing != nil {
+		err = errors.New("cannot create group as a group with the same name already exists, import it instead")
+	}
+	if err == nil {
+		err = createGroup(ctx, r.client, group)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating group",
+			errorDetail("Could not create group "+group.Name, err),
+		)
+		return
+	}
+
+	state, diags := r.readGroup(ctx, group.Name, plan.Members)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state == nil {
+		resp.Diagnostics.AddError(
+			"Error creating group",
+			"Group "+group.Name+" is not found after creation. Possible options: the NAS needs more time or the group creation failed silently",
+		)
+		return
+	}
+	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Read)
+	defer cancel()
+
+	// Get current state
+	var state GroupSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	refreshed, diags := r.readGroup(ctx, state.ID.ValueString(), state.Members)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if refreshed == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	refreshed.LastUpdated = state.LastUpdated
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, refreshed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update replaces the description and the members of the group, the other attributes require replacement.
+func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan GroupSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group := nasGroup{
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+	}
+	diags = plan.Members.ElementsAs(ctx, &group.Members, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateGroup(ctx, r.client, group)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating group",
+			errorDetail("Could not update group "+group.Name, err),
+		)
+		return
+	}
+
+	state, diags := r.readGroup(ctx, group.Name, plan.Members)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state == nil {
+		resp.Diagnostics.AddError(
+			"Error updating group",
+			"Group "+group.Name+" is not found after the update, it may have been removed outside of Terraform.",
+		)
+		return
+	}
+	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the group.
+func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	// Retrieve values from state
+	var state GroupSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := deleteGroup(ctx, r.client, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting group",
+			errorDetail("Could not delete group "+state.Name.ValueString(), err),
+		)
+		return
+	}
+}
+
+// ImportState imports an existing group by its name.
+func (r *groupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *groupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+// readGroup returns the state of the group with the given name, nil when it does not exist. The members keep the
+// order of the prior members when the NAS returns the same accounts in another order.
+func (r *groupResource) readGroup(ctx context.Context, name string, priorMembers basetypes.ListValue) (*GroupSpecModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	group, err := findGroup(ctx, r.client, name)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading group "+name, err),
+		)
+		return nil, diags
+	}
+	if group == nil {
+		return nil, diags
+	}
+
+	state := &GroupSpecModel{
+		ID:          types.StringValue(group.Name),
+		Name:        types.StringValue(group.Name),
+		GID:         types.Int64Value(group.GID),
+		Description: types.StringNull(),
+		Members:     types.ListNull(types.StringType),
+		LastUpdated: types.StringNull(),
+	}
+	if group.Description != "" {
+		state.Description = types.StringValue(group.Description)
+	}
+	if len(group.Members) == 0 {
+		return state, diags
+	}
+
+	var prior []string
+	diags.Append(priorMembers.ElementsAs(ctx, &prior, false)...)
+	if sameMembers(prior, group.Members) {
+		state.Members = priorMembers
+		return state, diags
+	}
+	var listDiags diag.Diagnostics
+	state.Members, listDiags = types.ListValueFrom(ctx, types.StringType, group.Members)
+	diags.Append(listDiags...)
+	return state, diags
+}
+
+// sameMembers reports whether two lists hold the same account names in any order.
+func sameMembers(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA, sortedB := append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGroupResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: `
+					resource "qnap_group" "media" {
+					name        = "terraform_test_group"
+					description = "Created by the acceptance tests"
+					members     = ["admin"]
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_group.media", "id", "terraform_test_group"),
+					resource.TestCheckResourceAttr("qnap_group.media", "description", "Created by the acceptance tests"),
+					resource.TestCheckResourceAttr("qnap_group.media", "members.#", "1"),
+					resource.TestCheckResourceAttr("qnap_group.media", "members.0", "admin"),
+					resource.TestCheckResourceAttrSet("qnap_group.media", "gid"),
+				),
+			},
+			// test case 1 - import by name
+			{
+				ResourceName:            "qnap_group.media",
+				ImportState:             true,
+				ImportStateId:           "terraform_test_group",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// test case 2 - members removed in place
+			{
+				Config: `
+					resource "qnap_group" "media" {
+					name = "terraform_test_group"
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("qnap_group.media", "members"),
+					resource.TestCheckNoResourceAttr("qnap_group.media", "description"),
+				),
+			},
+			// test case 3 - gid out of range
+			{
+				Config: `
+					resource "qnap_group" "media" {
+					name = "terraform_test_group"
+					gid  = 10
+					}
+
+				`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Value"),
+			},
+		},
+	})
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &groupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &groupsDataSource{}
+)
+
+// groupsDataSource is the data source implementation.
+type groupsDataSource struct {
+	client *qnap.Client
+}
+
+// groupsDataSourceModel maps the data source schema data.
+type groupsDataSourceModel struct {
+	Groups []groupModel `tfsdk:"groups"`
+}
+
+// groupModel maps a group of NAS user accounts.
+type groupModel struct {
+	Name    types.String   `tfsdk:"name"`
+	GID     types.Int64    `tfsdk:"gid"`
+	Members []types.String `tfsdk:"members"`
+}
+
+// NewGroupsDataSource is a helper function to simplify the provider implementation.
+func NewGroupsDataSource() datasource.DataSource {
+	return &groupsDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *groupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_groups"
+}
+
+// Schema defines the schema for the data source.
+func (d *groupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the groups of user accounts of the NAS, for example to run a container with the GID of a group that owns a shared folder.",
+		Attributes: map[string]schema.Attribute{
+			"groups": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The groups of the NAS.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the group.",
+						},
+						"gid": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The numeric ID of the group.",
+						},
+						"members": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "The names of the user accounts in the group.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *groupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state groupsDataSourceModel
+
+	groups, err := listGroups(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read QNAP Groups",
+			errorDetail("Could not read the groups of the NAS", err),
+		)
+		return
+	}
+
+	state.Groups = []groupModel{}
+	for _, group := range groups {
+		members := []types.String{}
+		for _, member := range group.Members {
+			members = append(members, types.StringValue(member))
+		}
+		state.Groups = append(state.Groups, groupModel{
+			Name:    types.StringValue(group.Name),
+			GID:     types.Int64Value(group.GID),
+			Members: members,
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *groupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qnap.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qnap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGroupsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "qnap_groups" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.qnap_groups.test", "groups.#"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.qnap_groups.test", "groups.*", map[string]string{
+						"name": "administrators",
+						"gid":  "0",
+					}),
+				),
+			},
+		},
+	})
+}
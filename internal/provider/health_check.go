@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// apiStatus returns the HTTP status of an error of the client library or apiRequest, 0 for other errors.
+func apiStatus(err error) int {
+	var status int
+	if _, scanErr := fmt.Sscanf(err.Error(), "status: %d,", &status); scanErr != nil {
+		return 0
+	}
+	return status
+}
+
+// healthCheck signs in with the credentials of the provider and verifies that Container Station is installed and that
+// the accounts may manage containers, so a misconfigured NAS fails when the provider is configured instead of with a
+// 404 Not Found in a resource.
+func healthCheck(ctx context.Context, session *sessionTransport) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, t := range []*sessionTransport{session, session.readOnly} {
+		// Transports without a host or credentials fail every request, the provider was told to allow it.
+		if t == nil || t.configErr != nil {
+			continue
+		}
+
+		account := fmt.Sprintf("The account %q", t.credentials.username)
+		if t.credentials.accessToken != "" {
+			account = "The access token"
+		}
+		if t == session.readOnly {
+			account = "The read-only account"
+		}
+
+		token, err := t.session(ctx)
+		if err != nil {
+			diags.AddError("Unable to Sign In to the NAS", errorDetail(account+" could not sign in to the NAS", err))
+			continue
+		}
+		client := &qnap.Client{
+			HostURL: t.host,
+			HTTPClient: &http.Client{
+				Transport: &apiVersionTransport{next: t.next, version: t.apiVersion},
+				Timeout:   t.timeout,
+			},
+			Token: token,
+		}
+
+		if _, err := getSystemInfo(ctx, client); err != nil {
+			if apiStatus(err) == http.StatusNotFound {
+				diags.AddError(
+					"Container Station Not Installed",
+					"Container Station is not installed or not running on the NAS. Install it via App Center, or start it in App Center if it is stopped.",
+				)
+				return diags
+			}
+			diags.AddError("Unable to Reach Container Station", errorDetail("Could not read the system information of the NAS", err))
+			continue
+		}
+
+		err = apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/containers", nil, nil)
+		if err != nil {
+			if status := apiStatus(err); status == http.StatusUnauthorized || status == http.StatusForbidden {
+				diags.AddError(
+					"Missing Container Station Permission",
+					account+" is not allowed to manage containers. Grant it the Container Station application privilege in the user settings of the NAS, or use an administrator account.",
+				)
+				continue
+			}
+			diags.AddError("Unable to Reach Container Station", errorDetail("Could not list the containers", err))
+		}
+	}
+	return diags
+}
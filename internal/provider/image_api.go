@@ -0,0 +1,329 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// imageInfo represents an image stored on the NAS.
+type imageInfo struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Tag     string `json:"tag"`
+	Digest  string `json:"digest"`
+	Size    int64  `json:"size"`
+	Created string `json:"created"`
+}
+
+// imageRegistryAuth holds the credentials used to pull from a private registry.
+type imageRegistryAuth struct {
+	ServerAddress string `json:"serveraddress,omitempty"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+}
+
+// imagePullSpec is the payload of the Container Station image pull endpoint.
+type imagePullSpec struct {
+	Name string             `json:"name"`
+	Tag  string             `json:"tag"`
+	Auth *imageRegistryAuth `json:"auth,omitempty"`
+}
+
+// splitImageReference splits an image reference into its repository and tag, the tag defaults to latest.
+func splitImageReference(image string) (string, string) {
+	lastColon := strings.LastIndex(image, ":")
+	// A colon before the last slash separates the registry port, not the tag
+	if lastColon == -1 || lastColon < strings.LastIndex(image, "/") {
+		return image, "latest"
+	}
+	return image[:lastColon], image[lastColon+1:]
+}
+
+// imageOperationOptions configures the timeout of each attempt and the number of retries of image pulls and imports.
+type imageOperationOptions struct {
+	Timeout time.Duration
+	Retries int
+}
+
+const (
+	defaultImageOperationTimeout = 20 * time.Minute
+	defaultImageOperationRetries = 2
+)
+
+// defaultImageOperationOptions returns the options used when they are not configured.
+func defaultImageOperationOptions() imageOperationOptions {
+	return imageOperationOptions{
+		Timeout: defaultImageOperationTimeout,
+		Retries: defaultImageOperationRetries,
+	}
+}
+
+// retryImageOperation runs the operation with a timeout per attempt and retries it with a linear backoff
+// until it succeeds, fails with an error that a retry cannot fix or the retries are exhausted.
+func retryImageOperation(ctx context.Context, description string, options imageOperationOptions, operation func(ctx context.Context) (*imageInfo, error)) (*imageInfo, error) {
+	var err error
+	for attempt := 0; attempt <= options.Retries; attempt++ {
+		if attempt > 0 {
+			tflog.Warn(ctx, "Retrying image operation", map[string]interface{}{
+				"operation": description,
+				"attempt":   attempt + 1,
+				"error":     err.Error(),
+			})
+			select {
+			case <-ctx.Done():
+				return nil, err
+			case <-time.After(time.Duration(attempt) * 5 * time.Second):
+			}
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, options.Timeout)
+		var image *imageInfo
+		image, err = operation(attemptCtx)
+		cancel()
+		if err == nil {
+			return image, nil
+		}
+		if ctx.Err() != nil || !isRetryableImageError(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("%s failed after %d attempts: %w", description, options.Retries+1, err)
+}
+
+// isRetryableImageError reports whether an image operation may succeed when retried,
+// authentication and missing image errors are returned right away.
+func isRetryableImageError(err error) bool {
+	var status int
+	if _, scanErr := fmt.Sscanf(err.Error(), "status: %d,", &status); scanErr != nil {
+		return true
+	}
+	return status != http.StatusUnauthorized && status != http.StatusForbidden && status != http.StatusNotFound
+}
+
+// pullImage pulls an image on the NAS with retries and returns it once the pull task is completed.
+func pullImage(ctx context.Context, client *qnap.Client, image imagePullSpec, options imageOperationOptions) (*imageInfo, error) {
+	tflog.Info(ctx, "Pulling image", map[string]interface{}{
+		"image":   image.Name + ":" + image.Tag,
+		"timeout": options.Timeout.String(),
+		"retries": options.Retries,
+	})
+	return retryImageOperation(ctx, "pull of image "+image.Name+":"+image.Tag, options, func(ctx context.Context) (*imageInfo, error) {
+		return pullImageOnce(ctx, client, image)
+	})
+}
+
+// pullImageOnce pulls an image on the NAS and returns it once the pull task is completed.
+func pullImageOnce(ctx context.Context, client *qnap.Client, image imagePullSpec) (*imageInfo, error) {
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodPost, "/container-station/api/v3/images/pull", image, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	err = waitForTask(ctx, client, response.Data.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	pulled, err := findImageByName(ctx, client, image.Name, image.Tag)
+	if err != nil {
+		return nil, err
+	}
+	if pulled == nil {
+		return nil, errors.New("image is not found after pull. Possible options: QNAP container station needs more time or the image pull failed silently")
+	}
+	return pulled, nil
+}
+
+// imageImportSpec is the payload of the Container Station image import endpoint, either
+// a tar archive on a shared folder of the NAS or a remote URL is imported.
+type imageImportSpec struct {
+	Path string `json:"path,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// importImage imports an image archive on the NAS with retries and returns the image with the given name and tag.
+func importImage(ctx context.Context, client *qnap.Client, source imageImportSpec, name string, tag string, options imageOperationOptions) (*imageInfo, error) {
+	tflog.Info(ctx, "Importing image", map[string]interface{}{
+		"image":   name + ":" + tag,
+		"path":    source.Path,
+		"url":     source.URL,
+		"timeout": options.Timeout.String(),
+		"retries": options.Retries,
+	})
+	return retryImageOperation(ctx, "import of image "+name+":"+tag, options, func(ctx context.Context) (*imageInfo, error) {
+		return importImageOnce(ctx, client, source, name, tag)
+	})
+}
+
+// importImageOnce imports an image archive on the NAS and returns the image with the given name and tag
+// once the import task is completed.
+func importImageOnce(ctx context.Context, client *qnap.Client, source imageImportSpec, name string, tag string) (*imageInfo, error) {
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodPost, "/container-station/api/v3/images/import", source, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	err = waitForTask(ctx, client, response.Data.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	imported, err := findImageByName(ctx, client, name, tag)
+	if err != nil {
+		return nil, err
+	}
+	if imported == nil {
+		return nil, fmt.Errorf("image %s:%s is not found after import. Possible options: the archive does not contain this repository and tag or the import failed silently", name, tag)
+	}
+	return imported, nil
+}
+
+// listImages returns the images stored on the NAS.
+func listImages(ctx context.Context, client *qnap.Client) ([]imageInfo, error) {
+	var response struct {
+		Data struct {
+			Items []imageInfo `json:"items"`
+		} `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/images", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Data.Items, nil
+}
+
+// findImage returns the image with the given ID or nil when it does not exist.
+func findImage(ctx context.Context, client *qnap.Client, imageID string) (*imageInfo, error) {
+	images, err := listImages(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, image := range images {
+		if image.ID == imageID {
+			return &image, nil
+		}
+	}
+	return nil, nil
+}
+
+// findImageByName returns the image with the given repository and tag or nil when it does not exist.
+func findImageByName(ctx context.Context, client *qnap.Client, name string, tag string) (*imageInfo, error) {
+	images, err := listImages(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, image := range images {
+		if image.Name == name && image.Tag == tag {
+			return &image, nil
+		}
+	}
+	return nil, nil
+}
+
+// deleteImage removes an image from the NAS and waits for the task to complete.
+func deleteImage(ctx context.Context, client *qnap.Client, imageID string) error {
+	payload := struct {
+		Data struct {
+			Items []struct {
+				ID string `json:"id"`
+			} `json:"items"`
+		} `json:"data"`
+	}{}
+	payload.Data.Items = append(payload.Data.Items, struct {
+		ID string `json:"id"`
+	}{ID: imageID})
+
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodDelete, "/container-station/api/v3/images", payload, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Data.TaskID == "" {
+		return nil
+	}
+	return waitForTask(ctx, client, response.Data.TaskID)
+}
+
+// removeUnusedImages deletes the given images from the NAS unless a container still references them.
+func removeUnusedImages(ctx context.Context, client *qnap.Client, imageIDs []string) error {
+	containers, err := client.GetContainers()
+	if err != nil {
+		return err
+	}
+
+	used := map[string]bool{}
+	for _, container := range containers {
+		used[container.ImageID] = true
+	}
+
+	var errs []error
+	for _, imageID := range imageIDs {
+		if imageID == "" || used[imageID] {
+			continue
+		}
+		// Images shared by several removed containers are only deleted once
+		used[imageID] = true
+		if err := deleteImage(ctx, client, imageID); err != nil {
+			errs = append(errs, fmt.Errorf("image %s: %w", imageID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// unusedImages returns the images that no container uses. Unless all is set only the dangling images are returned,
+// the images without a tag.
+func unusedImages(ctx context.Context, client *qnap.Client, all bool) ([]imageInfo, error) {
+	containers, err := client.GetContainers()
+	if err != nil {
+		return nil, withRequest(err, http.MethodGet, "/container-station/api/v3/containers")
+	}
+	used := map[string]bool{}
+	for _, container := range containers {
+		used[container.ImageID] = true
+	}
+
+	images, err := listImages(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	unused := []imageInfo{}
+	for _, image := range images {
+		dangling := image.Tag == "" || image.Tag == "<none>"
+		if !used[image.ID] && (all || dangling) {
+			unused = append(unused, image)
+		}
+	}
+	return unused, nil
+}
+
+// imageExportSpec is the payload of the Container Station image export endpoint.
+type imageExportSpec struct {
+	ID   string `json:"id"`
+	Path string `json:"path"`
+}
+
+// exportImage saves an image to a tar archive on a shared folder of the NAS and waits for the task to complete.
+func exportImage(ctx context.Context, client *qnap.Client, export imageExportSpec) error {
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodPost, "/container-station/api/v3/images/export", export, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Data.TaskID == "" {
+		return nil
+	}
+	return waitForTask(ctx, client, response.Data.TaskID)
+}
@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &imageExportResource{}
+	_ resource.ResourceWithConfigure = &imageExportResource{}
+)
+
+type ImageExportSpecModel struct {
+	ID          basetypes.StringValue `tfsdk:"id"`
+	ImageID     basetypes.StringValue `tfsdk:"image_id"`
+	Path        basetypes.StringValue `tfsdk:"path"`
+	Triggers    basetypes.MapValue    `tfsdk:"triggers"`
+	LastUpdated basetypes.StringValue `tfsdk:"last_updated"`
+}
+
+// imageExportResource is the resource implementation.
+type imageExportResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewImageExportResource is a helper function to simplify the provider implementation.
+func NewImageExportResource() resource.Resource {
+	return &imageExportResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *imageExportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image_export"
+}
+
+// Schema defines the schema for the resource.
+func (r *imageExportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Saves an image to a tar archive on a shared folder of the NAS, for backup and migration. The image is exported again when the image or any of the triggers change. The archive is kept on the NAS when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the export, the image ID and the path of the archive.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"image_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the image to export (e.g. the id of qnap_image).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Required:    true,
+				Description: "The path of the tar archive on a shared folder of the NAS (e.g. '/Public/backup/app.tar').",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/.+\.tar$`), "Path must be an absolute path to a tar archive (e.g. '/Public/backup/app.tar')."),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that export the image again when changed.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the export.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create exports the image.
+func (r *imageExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan ImageExportSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := exportImage(ctx, r.client, imageExportSpec{
+		ID:   plan.ImageID.ValueString(),
+		Path: plan.Path.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error exporting image",
+			errorDetail("Could not export image "+plan.ImageID.ValueString()+" to "+plan.Path.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(plan.ImageID.ValueString() + ":" + plan.Path.ValueString())
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read removes the export from state when the image does not exist anymore, the archive itself is not tracked.
+func (r *imageExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state ImageExportSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	image, err := findImage(ctx, r.client, state.ImageID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the resource", err),
+		)
+		return
+	}
+	if image == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+}
+
+// Update is never called as every attribute requires replacement.
+func (r *imageExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+// Delete removes the export from the Terraform state and keeps the archive on the NAS.
+func (r *imageExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *imageExportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccImageExportResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: `
+					resource "qnap_image" "alpine" {
+					repository = "alpine"
+					tag        = "3.20"
+					}
+
+					resource "qnap_image_export" "alpine" {
+					image_id = qnap_image.alpine.id
+					path     = "/Public/terraform_test_alpine.tar"
+					triggers = {
+						digest = qnap_image.alpine.digest
+					}
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("qnap_image_export.alpine", "image_id", "qnap_image.alpine", "id"),
+					resource.TestCheckResourceAttr("qnap_image_export.alpine", "path", "/Public/terraform_test_alpine.tar"),
+					resource.TestCheckResourceAttrSet("qnap_image_export.alpine", "last_updated"),
+				),
+			},
+		},
+	})
+}
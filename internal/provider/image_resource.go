@@ -0,0 +1,435 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &imageResource{}
+	_ resource.ResourceWithConfigure = &imageResource{}
+)
+
+type ImageSpecModel struct {
+	ID           basetypes.StringValue `tfsdk:"id"`
+	Repository   basetypes.StringValue `tfsdk:"repository"`
+	Tag          basetypes.StringValue `tfsdk:"tag"`
+	Name         basetypes.StringValue `tfsdk:"name"`
+	Digest       basetypes.StringValue `tfsdk:"digest"`
+	Size         basetypes.Int64Value  `tfsdk:"size"`
+	RegistryAuth basetypes.ObjectValue `tfsdk:"registry_auth"`
+	ImportSource basetypes.ObjectValue `tfsdk:"import_source"`
+	KeepLocally  basetypes.BoolValue   `tfsdk:"keep_locally"`
+	PullTimeout  basetypes.StringValue `tfsdk:"pull_timeout"`
+	PullRetries  basetypes.Int32Value  `tfsdk:"pull_retries"`
+	LastUpdated  basetypes.StringValue `tfsdk:"last_updated"`
+}
+
+type ImportSourceModel struct {
+	Path basetypes.StringValue `tfsdk:"path"`
+	URL  basetypes.StringValue `tfsdk:"url"`
+}
+
+type RegistryAuthModel struct {
+	ServerAddress basetypes.StringValue `tfsdk:"server_address"`
+	Username      basetypes.StringValue `tfsdk:"username"`
+	Password      basetypes.StringValue `tfsdk:"password"`
+}
+
+// imageResource is the resource implementation.
+type imageResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewImageResource is a helper function to simplify the provider implementation.
+func NewImageResource() resource.Resource {
+	return &imageResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *imageResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image"
+}
+
+// Schema defines the schema for the resource.
+func (r *imageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Pulls or imports an image on the NAS and pins it, exposing its ID and digest so containers can depend on it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the image.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repository": schema.StringAttribute{
+				Required:    true,
+				Description: "The repository of the image (e.g. 'nginx', 'ghcr.io/owner/app').",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(?:[a-z0-9.-]+(?::[0-9]+)?/)?[a-z0-9]+(?:[._-][a-z0-9]+)*(?:/[a-z0-9]+(?:[._-][a-z0-9]+)*)*$`), "Repository must be in a valid format (e.g. 'nginx', 'myregistry.local:5000/team/nginx')."),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tag": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The tag of the image. Defaults to latest.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`), "Tag must be in a valid format (e.g. 'latest', '1.26.2')."),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The full name of the image (repository:tag) to use in the image attribute of qnap_container.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"digest": schema.StringAttribute{
+				Computed:    true,
+				Description: "The digest of the pulled image.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The size of the image in bytes.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"registry_auth": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "The credentials used to pull the image from a private registry.",
+				Attributes: map[string]schema.Attribute{
+					"server_address": schema.StringAttribute{
+						Optional:    true,
+						Description: "The address of the registry (e.g. 'ghcr.io'). Defaults to Docker Hub.",
+					},
+					"username": schema.StringAttribute{
+						Required:    true,
+						Description: "The username for the registry.",
+					},
+					"password": schema.StringAttribute{
+						Required:    true,
+						Sensitive:   true,
+						Description: "The password or access token for the registry.",
+					},
+				},
+			},
+			"import_source": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Imports the image from a tar archive instead of pulling it from a registry, for NAS without registry access. The archive must contain the repository and tag of the image.",
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("registry_auth")),
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"path": schema.StringAttribute{
+						Optional:    true,
+						Description: "The path of the tar archive on a shared folder of the NAS (e.g. '/Public/images/app.tar').",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^/.+\.(tar|tar\.gz|tgz)$`), "Path must be an absolute path to a tar archive (e.g. '/Public/images/app.tar')."),
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("url")),
+						},
+					},
+					"url": schema.StringAttribute{
+						Optional:    true,
+						Description: "The URL of the tar archive downloaded by the NAS.",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^https?://.+$`), "URL must start with http:// or https://."),
+						},
+					},
+				},
+			},
+			"pull_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "The maximum duration of each pull or import attempt (e.g. '30m', '1h'). Defaults to 20m.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^([0-9]+(h|m|s))+$`), "Timeout must be a duration (e.g. '90s', '30m', '1h30m')."),
+				},
+			},
+			"pull_retries": schema.Int32Attribute{
+				Optional:    true,
+				Description: "The number of times a failed or timed out pull or import is retried. Defaults to 2.",
+				Validators: []validator.Int32{
+					int32validator.Between(0, 10),
+				},
+			},
+			"keep_locally": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to keep the image on the NAS when the resource is destroyed. Defaults to false.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The last updated timestamp of the image.",
+			},
+		},
+	}
+}
+
+// Create pulls the image.
+func (r *imageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan ImageSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tag := plan.Tag.ValueString()
+	if plan.Tag.IsNull() || plan.Tag.IsUnknown() {
+		tag = "latest"
+	}
+	pullSpec := imagePullSpec{
+		Name: plan.Repository.ValueString(),
+		Tag:  tag,
+	}
+
+	if !plan.RegistryAuth.IsNull() && !plan.RegistryAuth.IsUnknown() {
+		var registryAuth RegistryAuthModel
+		diags := plan.RegistryAuth.As(ctx, &registryAuth, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: false, UnhandledUnknownAsEmpty: false})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		pullSpec.Auth = &imageRegistryAuth{
+			ServerAddress: registryAuth.ServerAddress.ValueString(),
+			Username:      registryAuth.Username.ValueString(),
+			Password:      registryAuth.Password.ValueString(),
+		}
+	}
+
+	options, err := imageOperationOptionsFrom(plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pull_timeout"),
+			"Invalid pull timeout",
+			errorDetail("Could not parse pull_timeout", err),
+		)
+		return
+	}
+
+	var image *imageInfo
+	if !plan.ImportSource.IsNull() && !plan.ImportSource.IsUnknown() {
+		// Import the image from an archive
+		var importSource ImportSourceModel
+		diags := plan.ImportSource.As(ctx, &importSource, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: false, UnhandledUnknownAsEmpty: false})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		image, err = importImage(ctx, r.client, imageImportSpec{
+			Path: importSource.Path.ValueString(),
+			URL:  importSource.URL.ValueString(),
+		}, pullSpec.Name, pullSpec.Tag, options)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error importing image",
+				errorDetail("Could not import image "+pullSpec.Name+":"+pullSpec.Tag, err),
+			)
+			return
+		}
+	} else {
+		// Pull the image
+		image, err = pullImage(ctx, r.client, pullSpec, options)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error pulling image",
+				errorDetail("Could not pull image "+pullSpec.Name+":"+pullSpec.Tag, err),
+			)
+			return
+		}
+	}
+
+	state := writeImageState(image)
+	// special case for attributes that are not returned by the API
+	state.RegistryAuth = plan.RegistryAuth
+	state.ImportSource = plan.ImportSource
+	state.KeepLocally = plan.KeepLocally
+	state.PullTimeout = plan.PullTimeout
+	state.PullRetries = plan.PullRetries
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *imageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state ImageSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed image value from QNAP
+	image, err := findImage(ctx, r.client, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the resource", err),
+		)
+		return
+	}
+	if image == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	newState := writeImageState(image)
+	newState.RegistryAuth = state.RegistryAuth
+	newState.ImportSource = state.ImportSource
+	newState.KeepLocally = state.KeepLocally
+	newState.PullTimeout = state.PullTimeout
+	newState.PullRetries = state.PullRetries
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only applies changes to attributes that are not sent to the API.
+func (r *imageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	var plan, state ImageSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.RegistryAuth = plan.RegistryAuth
+	state.KeepLocally = plan.KeepLocally
+	state.PullTimeout = plan.PullTimeout
+	state.PullRetries = plan.PullRetries
+	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the image from the NAS unless it should be kept locally.
+func (r *imageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	// Retrieve values from state
+	var state ImageSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.KeepLocally.ValueBool() {
+		return
+	}
+
+	err := deleteImage(ctx, r.client, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting image",
+			errorDetail("Could not delete image "+state.Name.ValueString(), err),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *imageResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+// writeImageState maps an image returned by the API to the resource model.
+func writeImageState(image *imageInfo) ImageSpecModel {
+	return ImageSpecModel{
+		ID:          types.StringValue(image.ID),
+		Repository:  types.StringValue(image.Name),
+		Tag:         types.StringValue(image.Tag),
+		Name:        types.StringValue(image.Name + ":" + image.Tag),
+		Digest:      types.StringValue(image.Digest),
+		Size:        types.Int64Value(image.Size),
+		LastUpdated: types.StringValue(time.Now().Format(time.RFC850)),
+	}
+}
+
+// imageOperationOptionsFrom returns the pull options of the resource, unset options keep their default.
+func imageOperationOptionsFrom(plan ImageSpecModel) (imageOperationOptions, error) {
+	options := defaultImageOperationOptions()
+	if !plan.PullTimeout.IsNull() && !plan.PullTimeout.IsUnknown() {
+		timeout, err := time.ParseDuration(plan.PullTimeout.ValueString())
+		if err != nil {
+			return options, err
+		}
+		options.Timeout = timeout
+	}
+	if !plan.PullRetries.IsNull() && !plan.PullRetries.IsUnknown() {
+		options.Retries = int(plan.PullRetries.ValueInt32())
+	}
+	return options, nil
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccImageResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: `
+					resource "qnap_image" "alpine" {
+					repository   = "alpine"
+					tag          = "3.20"
+					pull_timeout = "10m"
+					pull_retries = 1
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_image.alpine", "repository", "alpine"),
+					resource.TestCheckResourceAttr("qnap_image.alpine", "tag", "3.20"),
+					resource.TestCheckResourceAttr("qnap_image.alpine", "name", "alpine:3.20"),
+					resource.TestCheckResourceAttr("qnap_image.alpine", "pull_timeout", "10m"),
+					resource.TestCheckResourceAttr("qnap_image.alpine", "pull_retries", "1"),
+					resource.TestCheckResourceAttrSet("qnap_image.alpine", "id"),
+					resource.TestCheckResourceAttrSet("qnap_image.alpine", "digest"),
+				),
+			},
+			// test case 2
+			{
+				Config: `
+					resource "qnap_image" "alpine" {
+					repository   = "alpine"
+					tag          = "3.20"
+					keep_locally = false
+					}
+
+					resource "qnap_container" "alpine" {
+					name         = "terraform_test_image"
+					image        = qnap_image.alpine.name
+					image_digest = qnap_image.alpine.id
+					type         = "docker"
+					cmd          = ["sleep", "infinity"]
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_image.alpine", "keep_locally", "false"),
+					resource.TestCheckResourceAttr("qnap_container.alpine", "image", "alpine:3.20"),
+					resource.TestCheckResourceAttrPair("qnap_container.alpine", "image_digest", "qnap_image.alpine", "id"),
+				),
+			},
+			// test case 3
+			{
+				Config: `
+					resource "qnap_image" "imported" {
+					repository = "example/app"
+					tag        = "v1"
+					import_source = {
+						path = "/Public/images/app.tar"
+					}
+					registry_auth = {
+						username = "example"
+						password = "example"
+					}
+					}
+
+				`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
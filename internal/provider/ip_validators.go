@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// ipAddressValidator validates IP addresses or subnets in CIDR notation with the net package,
+// as IPv6 literals are too irregular to be validated with a regular expression.
+type ipAddressValidator struct {
+	allowIPv4 bool
+	allowIPv6 bool
+	cidr      bool
+}
+
+// ipAddress validates an IPv4 or IPv6 address.
+func ipAddress() validator.String {
+	return ipAddressValidator{allowIPv4: true, allowIPv6: true}
+}
+
+// ipv6Address validates an IPv6 address.
+func ipv6Address() validator.String {
+	return ipAddressValidator{allowIPv6: true}
+}
+
+// ipv6CIDR validates an IPv6 subnet in CIDR notation.
+func ipv6CIDR() validator.String {
+	return ipAddressValidator{allowIPv6: true, cidr: true}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v ipAddressValidator) Description(_ context.Context) string {
+	kind := "address"
+	if v.cidr {
+		kind = "subnet in CIDR notation"
+	}
+	switch {
+	case v.allowIPv4 && v.allowIPv6:
+		return "value must be a valid IPv4 or IPv6 " + kind
+	case v.allowIPv6:
+		return "value must be a valid IPv6 " + kind
+	default:
+		return "value must be a valid IPv4 " + kind
+	}
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	var ip net.IP
+	if v.cidr {
+		ip, _, _ = net.ParseCIDR(value)
+	} else {
+		ip = net.ParseIP(value)
+	}
+
+	isIPv4 := ip != nil && ip.To4() != nil
+	isIPv6 := ip != nil && ip.To4() == nil
+	if (isIPv4 && v.allowIPv4) || (isIPv6 && v.allowIPv6) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid IP Address",
+		fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), value),
+	)
+}
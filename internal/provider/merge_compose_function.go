@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"gopkg.in/yaml.v2"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &mergeComposeFunction{}
+
+// composeAppendKeys are the sequences that are appended to, rather than replaced by, the override file.
+var composeAppendKeys = map[string]bool{
+	"ports":          true,
+	"expose":         true,
+	"external_links": true,
+	"dns":            true,
+	"dns_search":     true,
+	"tmpfs":          true,
+	"volumes":        true,
+	"devices":        true,
+}
+
+// composeMappingKeys are the keys that can be written as a mapping or as a list of KEY=VALUE entries.
+var composeMappingKeys = map[string]bool{
+	"environment": true,
+	"labels":      true,
+	"extra_hosts": true,
+}
+
+// mergeComposeFunction is the function implementation.
+type mergeComposeFunction struct{}
+
+// NewMergeComposeFunction is a helper function to simplify the provider implementation.
+func NewMergeComposeFunction() function.Function {
+	return &mergeComposeFunction{}
+}
+
+// Metadata returns the function name.
+func (f *mergeComposeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "merge_compose"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *mergeComposeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Merges two compose files.",
+		Description: "Merges an override compose file into a base compose file the way docker compose merges multiple files, " +
+			"for use as the yaml of a qnap_app. Mappings are merged recursively and the values of the override win. " +
+			"The ports, expose, external_links, dns, dns_search, tmpfs, volumes and devices lists are appended to, " +
+			"environment, labels and extra_hosts are merged by key, and every other list is replaced.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "base",
+				Description: "The base compose file.",
+			},
+			function.StringParameter{
+				Name:        "override",
+				Description: "The compose file to merge into the base compose file.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run merges the compose files.
+func (f *mergeComposeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var base, override string
+
+	resp.Error = req.Arguments.Get(ctx, &base, &override)
+	if resp.Error != nil {
+		return
+	}
+
+	merged, err := mergeCompose(base, override)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, merged)
+}
+
+// mergeCompose merges the override compose file into the base compose file.
+func mergeCompose(base string, override string) (string, error) {
+	var baseCompose, overrideCompose map[interface{}]interface{}
+	if err := yaml.Unmarshal([]byte(base), &baseCompose); err != nil {
+		return "", fmt.Errorf("unable to parse the base compose file: %w", err)
+	}
+	if err := yaml.Unmarshal([]byte(override), &overrideCompose); err != nil {
+		return "", fmt.Errorf("unable to parse the override compose file: %w", err)
+	}
+
+	merged, err := yaml.Marshal(mergeComposeValue("", baseCompose, overrideCompose))
+	if err != nil {
+		return "", err
+	}
+	return string(merged), nil
+}
+
+// mergeComposeValue merges the override value of a key into its base value.
+func mergeComposeValue(key string, base interface{}, override interface{}) interface{} {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	if composeMappingKeys[key] {
+		baseMapping, baseOK := composeMapping(base)
+		overrideMapping, overrideOK := composeMapping(override)
+		if baseOK && overrideOK {
+			for name, value := range overrideMapping {
+				baseMapping[name] = value
+			}
+			return baseMapping
+		}
+	}
+
+	switch overrideValue := override.(type) {
+	case map[interface{}]interface{}:
+		baseValue, ok := base.(map[interface{}]interface{})
+		if !ok {
+			return override
+		}
+		merged := map[interface{}]interface{}{}
+		for name, value := range baseValue {
+			merged[name] = value
+		}
+		for name, value := range overrideValue {
+			merged[name] = mergeComposeValue(fmt.Sprint(name), merged[name], value)
+		}
+		return merged
+	case []interface{}:
+		baseValue, ok := base.([]interface{})
+		if !ok || !composeAppendKeys[key] {
+			return override
+		}
+		merged := append([]interface{}{}, baseValue...)
+		for _, value := range overrideValue {
+			if !containsComposeValue(merged, value) {
+				merged = append(merged, value)
+			}
+		}
+		return merged
+	}
+	return override
+}
+
+// composeMapping returns a copy of a value written as a mapping or as a list of KEY=VALUE entries.
+func composeMapping(value interface{}) (map[interface{}]interface{}, bool) {
+	mapping := map[interface{}]interface{}{}
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		for name, entry := range value {
+			mapping[name] = entry
+		}
+	case []interface{}:
+		for _, entry := range value {
+			name, entryValue, found := strings.Cut(fmt.Sprint(entry), "=")
+			if !found {
+				mapping[name] = nil
+				continue
+			}
+			mapping[name] = entryValue
+		}
+	default:
+		return nil, false
+	}
+	return mapping, true
+}
+
+// containsComposeValue returns whether a list contains a value.
+func containsComposeValue(values []interface{}, value interface{}) bool {
+	for _, existing := range values {
+		if cmp.Equal(existing, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccMergeComposeFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  merged = yamldecode(provider::qnap::merge_compose(
+    yamlencode({ services = { web = { image = "nginx:1.25", ports = ["80:80"], command = ["a"] } } }),
+    yamlencode({ services = { web = { image = "nginx:1.27", ports = ["443:443"], command = ["b"] } } }),
+  ))
+}
+
+output "image" {
+  value = local.merged.services.web.image
+}
+
+output "ports" {
+  value = join(",", local.merged.services.web.ports)
+}
+
+output "command" {
+  value = join(",", local.merged.services.web.command)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("image", "nginx:1.27"),
+					resource.TestCheckOutput("ports", "80:80,443:443"),
+					resource.TestCheckOutput("command", "b"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// networkSpec is the payload of the Container Station network create endpoint and
+// the network returned by the network list endpoint.
+type networkSpec struct {
+	ID       string   `json:"id,omitempty"`
+	Name     string   `json:"name"`
+	Driver   string   `json:"driver"`
+	Subnet   string   `json:"subnet,omitempty"`
+	Gateway  string   `json:"gateway,omitempty"`
+	IPRange  string   `json:"ipRange,omitempty"`
+	Parent   string   `json:"parent,omitempty"`
+	Mode     string   `json:"mode,omitempty"`
+	Excluded []string `json:"excludedIPRanges,omitempty"`
+	IPv6     bool     `json:"enableIPv6,omitempty"`
+	Subnet6  string   `json:"subnet6,omitempty"`
+	Gateway6 string   `json:"gateway6,omitempty"`
+}
+
+// createNetwork creates a Container Station network and returns it once it is listed.
+func createNetwork(ctx context.Context, client *qnap.Client, network networkSpec) (*networkSpec, error) {
+	existing, err := findNetworkByName(ctx, client, network.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("cannot create network as a network with the same name already exists")
+	}
+
+	var response qnap.ContainerStationTaskResponse
+	err = apiRequest(ctx, client, http.MethodPost, "/container-station/api/v3/networks", network, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Data.TaskID != "" {
+		err = waitForTask(ctx, client, response.Data.TaskID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := findNetworkByName(ctx, client, network.Name)
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return nil, errors.New("network is not found after creation. Possible options: QNAP container station needs more time or the network creation failed silently")
+	}
+	return created, nil
+}
+
+// listNetworks returns the Container Station networks.
+func listNetworks(ctx context.Context, client *qnap.Client) ([]networkSpec, error) {
+	var response struct {
+		Data struct {
+			Items []networkSpec `json:"items"`
+		} `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/networks", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Data.Items, nil
+}
+
+// findNetwork returns the network with the given ID or nil when it does not exist.
+func findNetwork(ctx context.Context, client *qnap.Client, networkID string) (*networkSpec, error) {
+	networks, err := listNetworks(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, network := range networks {
+		if network.ID == networkID {
+			return &network, nil
+		}
+	}
+	return nil, nil
+}
+
+// findNetworkByName returns the network with the given name or nil when it does not exist.
+func findNetworkByName(ctx context.Context, client *qnap.Client, name string) (*networkSpec, error) {
+	networks, err := listNetworks(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, network := range networks {
+		if network.Name == name {
+			return &network, nil
+		}
+	}
+	return nil, nil
+}
+
+// deleteNetwork removes a Container Station network.
+func deleteNetwork(ctx context.Context, client *qnap.Client, networkID string) error {
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodDelete, fmt.Sprintf("/container-station/api/v3/networks/%s", networkID), nil, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Data.TaskID == "" {
+		return nil
+	}
+	return waitForTask(ctx, client, response.Data.TaskID)
+}
+
+// defaultNetworks are the networks of docker that cannot be removed.
+var defaultNetworks = map[string]bool{
+	"bridge": true,
+	"host":   true,
+	"none":   true,
+}
+
+// unusedNetworks returns the networks that no container is connected to, except the default networks of docker.
+func unusedNetworks(ctx context.Context, client *qnap.Client) ([]networkSpec, error) {
+	containers, err := client.GetContainers()
+	if err != nil {
+		return nil, withRequest(err, http.MethodGet, "/container-station/api/v3/containers")
+	}
+	used := map[string]bool{}
+	for _, container := range containers {
+		for _, network := range container.Networks {
+			used[network.ID] = true
+			used[network.Name] = true
+		}
+	}
+
+	networks, err := listNetworks(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	unused := []networkSpec{}
+	for _, network := range networks {
+		if !defaultNetworks[network.Name] && !used[network.ID] && !used[network.Name] {
+			unused = append(unused, network)
+		}
+	}
+	return unused, nil
+}
+
+// containerNetworkSpec is the payload of the Container Station network connect and disconnect endpoints.
+type containerNetworkSpec struct {
+	Container   string   `json:"container"`
+	Network     string   `json:"network"`
+	NetworkType string   `json:"networkType,omitempty"`
+	IPAddress   string   `json:"ipAddress,omitempty"`
+	IPv6Address string   `json:"ipv6Address,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+}
+
+// connectContainerNetwork connects a container to a network.
+func connectContainerNetwork(ctx context.Context, client *qnap.Client, connection containerNetworkSpec) error {
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodPost, "/container-station/api/v3/networks/connect", connection, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Data.TaskID == "" {
+		return nil
+	}
+	return waitForTask(ctx, client, response.Data.TaskID)
+}
+
+// disconnectContainerNetwork disconnects a container from a network.
+func disconnectContainerNetwork(ctx context.Context, client *qnap.Client, connection containerNetworkSpec) error {
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodPost, "/container-station/api/v3/networks/disconnect", connection, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Data.TaskID == "" {
+		return nil
+	}
+	return waitForTask(ctx, client, response.Data.TaskID)
+}
+
+// reconnectContainer moves a running container to another network in place, which keeps the container
+// and its anonymous volumes. The previous networks are restored when the new network cannot be connected.
+func reconnectContainer(ctx context.Context, client *qnap.Client, containerID string, containerType string, connection containerNetworkSpec) error {
+	container, err := inspectContainer(ctx, client, containerID, containerType)
+	if err != nil {
+		return err
+	}
+
+	connection.Container = containerID
+	for _, network := range container.Data.Networks {
+		err = disconnectContainerNetwork(ctx, client, containerNetworkSpec{Container: containerID, Network: network.ID})
+		if err != nil {
+			return fmt.Errorf("could not disconnect network %s: %w", network.Name, err)
+		}
+	}
+
+	err = connectContainerNetwork(ctx, client, connection)
+	if err == nil {
+		return nil
+	}
+
+	restoreErrs := []error{fmt.Errorf("could not connect network %s: %w", connection.Network, err)}
+	for i, network := range container.Data.Networks {
+		restore := containerNetworkSpec{
+			Container:   containerID,
+			Network:     network.Name,
+			NetworkType: network.NetworkType,
+		}
+		if network.IsStaticIP {
+			restore.IPAddress = network.IPAddress
+			restore.IPv6Address = container.networkExtra(i).IPv6Address
+		}
+		restore.Aliases = userNetworkAliases(container, i)
+		if err := connectContainerNetwork(ctx, client, restore); err != nil {
+			restoreErrs = append(restoreErrs, fmt.Errorf("could not restore network %s: %w", network.Name, err))
+		}
+	}
+	return errors.Join(restoreErrs...)
+}
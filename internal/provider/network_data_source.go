@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &networkDataSource{}
+	_ datasource.DataSourceWithConfigure = &networkDataSource{}
+)
+
+// networkDataSource is the data source implementation.
+type networkDataSource struct {
+	client *qnap.Client
+}
+
+// networkDataSourceModel maps the data source schema data.
+type networkDataSourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Driver   types.String `tfsdk:"driver"`
+	Subnet   types.String `tfsdk:"subnet"`
+	Gateway  types.String `tfsdk:"gateway"`
+	IPRange  types.String `tfsdk:"ip_range"`
+	Parent   types.String `tfsdk:"parent"`
+	Mode     types.String `tfsdk:"mode"`
+	Excluded types.List   `tfsdk:"excluded_ip_ranges"`
+	IPv6     types.Bool   `tfsdk:"ipv6"`
+	Subnet6  types.String `tfsdk:"subnet6"`
+	Gateway6 types.String `tfsdk:"gateway6"`
+}
+
+// NewNetworkDataSource is a helper function to simplify the provider implementation.
+func NewNetworkDataSource() datasource.DataSource {
+	return &networkDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *networkDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network"
+}
+
+// Schema defines the schema for the data source.
+func (d *networkDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a Container Station network by name.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the network to look up.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the network.",
+			},
+			"driver": schema.StringAttribute{
+				Computed:    true,
+				Description: "The driver of the network (bridge, macvlan, ipvlan).",
+			},
+			"subnet": schema.StringAttribute{
+				Computed:    true,
+				Description: "The subnet of the network in CIDR notation.",
+			},
+			"gateway": schema.StringAttribute{
+				Computed:    true,
+				Description: "The gateway of the network.",
+			},
+			"ip_range": schema.StringAttribute{
+				Computed:    true,
+				Description: "The range of the subnet that container addresses are allocated from.",
+			},
+			"parent": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ethernet adaptor macvlan and ipvlan networks are bound to.",
+			},
+			"mode": schema.StringAttribute{
+				Computed:    true,
+				Description: "The mode of the macvlan or ipvlan network.",
+			},
+			"excluded_ip_ranges": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The ranges of the subnet that are never assigned to containers.",
+			},
+			"ipv6": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether IPv6 is enabled on the network.",
+			},
+			"subnet6": schema.StringAttribute{
+				Computed:    true,
+				Description: "The IPv6 subnet of the network in CIDR notation.",
+			},
+			"gateway6": schema.StringAttribute{
+				Computed:    true,
+				Description: "The IPv6 gateway of the network.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *networkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state networkDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	network, err := findNetworkByName(ctx, d.client, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read QNAP Network",
+			errorDetail("Could not read the network of the NAS", err),
+		)
+		return
+	}
+	if network == nil {
+		resp.Diagnostics.AddError(
+			"Network not found",
+			fmt.Sprintf("No Container Station network is named %q.", state.Name.ValueString()),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(network.ID)
+	state.Driver = types.StringValue(network.Driver)
+	state.Subnet = types.StringValue(network.Subnet)
+	state.Gateway = types.StringValue(network.Gateway)
+	state.IPRange = types.StringValue(network.IPRange)
+	state.Parent = types.StringValue(network.Parent)
+	state.Mode = types.StringValue(network.Mode)
+	excluded, diags := types.ListValueFrom(ctx, types.StringType, append([]string{}, network.Excluded...))
+	resp.Diagnostics.Append(diags...)
+	state.Excluded = excluded
+	state.IPv6 = types.BoolValue(network.IPv6)
+	state.Subnet6 = types.StringValue(network.Subnet6)
+	state.Gateway6 = types.StringValue(network.Gateway6)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *networkDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qnap.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qnap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
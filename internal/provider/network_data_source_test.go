@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNetworkDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+					resource "qnap_container_network" "lookup" {
+					name    = "terraform_test_lookup"
+					driver  = "bridge"
+					subnet  = "172.31.0.0/16"
+					gateway = "172.31.0.1"
+					}
+
+					data "qnap_network" "lookup" {
+					name = qnap_container_network.lookup.name
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.qnap_network.lookup", "id", "qnap_container_network.lookup", "id"),
+					resource.TestCheckResourceAttr("data.qnap_network.lookup", "driver", "bridge"),
+					resource.TestCheckResourceAttr("data.qnap_network.lookup", "subnet", "172.31.0.0/16"),
+					resource.TestCheckResourceAttr("data.qnap_network.lookup", "gateway", "172.31.0.1"),
+				),
+			},
+			// Unknown network
+			{
+				Config:      providerConfig + `data "qnap_network" "missing" { name = "terraform_test_missing" }`,
+				ExpectError: regexp.MustCompile("Network not found"),
+			},
+		},
+	})
+}
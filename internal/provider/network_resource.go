@@ -0,0 +1,477 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &networkResource{}
+	_ resource.ResourceWithConfigure      = &networkResource{}
+	_ resource.ResourceWithValidateConfig = &networkResource{}
+)
+
+// ipv4Pattern matches an IPv4 address and ipv4CIDRPattern an IPv4 subnet in CIDR notation.
+const (
+	ipv4Pattern     = `(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)`
+	ipv4CIDRPattern = ipv4Pattern + `/(3[0-2]|[12]?[0-9])`
+)
+
+type NetworkSpecModel struct {
+	ID          basetypes.StringValue `tfsdk:"id"`
+	Name        basetypes.StringValue `tfsdk:"name"`
+	Driver      basetypes.StringValue `tfsdk:"driver"`
+	Subnet      basetypes.StringValue `tfsdk:"subnet"`
+	Gateway     basetypes.StringValue `tfsdk:"gateway"`
+	IPRange     basetypes.StringValue `tfsdk:"ip_range"`
+	Parent      basetypes.StringValue `tfsdk:"parent"`
+	Mode        basetypes.StringValue `tfsdk:"mode"`
+	Excluded    basetypes.ListValue   `tfsdk:"excluded_ip_ranges"`
+	IPv6        basetypes.BoolValue   `tfsdk:"ipv6"`
+	Subnet6     basetypes.StringValue `tfsdk:"subnet6"`
+	Gateway6    basetypes.StringValue `tfsdk:"gateway6"`
+	LastUpdated basetypes.StringValue `tfsdk:"last_updated"`
+}
+
+// networkResource is the resource implementation.
+type networkResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewNetworkResource is a helper function to simplify the provider implementation.
+func NewNetworkResource() resource.Resource {
+	return &networkResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *networkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_network"
+}
+
+// Schema defines the schema for the resource.
+func (r *networkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Container Station network that containers can be connected to with the network attribute of qnap_container. Every change recreates the network.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the network.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the network.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`), "Network name must be up to 64 characters, starts with a letter or number. Valid characters: letters (A-Z, a-z), numbers (0-9), hyphen (-), period (.), underscore (_)"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"driver": schema.StringAttribute{
+				Required:    true,
+				Description: "The driver of the network (bridge, macvlan, ipvlan). macvlan and ipvlan networks are bound to the ethernet adaptor set in parent.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("bridge", "macvlan", "ipvlan"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subnet": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The subnet of the network in CIDR notation (e.g. 172.30.0.0/16). Assigned by Container Station when not set.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^`+ipv4CIDRPattern+`$`), "Subnet must be in CIDR notation (e.g. 172.30.0.0/16)."),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gateway": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The gateway of the network. Assigned by Container Station when not set.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^`+ipv4Pattern+`$`), "Gateway must be in a valid format (e.g. 172.30.0.1)."),
+					stringvalidator.AlsoRequires(path.MatchRoot("subnet")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ip_range": schema.StringAttribute{
+				Optional:    true,
+				Description: "The range of the subnet the container IP addresses are allocated from in CIDR notation (e.g. 172.30.5.0/24).",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^`+ipv4CIDRPattern+`$`), "IP range must be in CIDR notation (e.g. 172.30.5.0/24)."),
+					stringvalidator.AlsoRequires(path.MatchRoot("subnet")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parent": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ethernet adaptor of the NAS the macvlan or ipvlan network is bound to (e.g. eth0).",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]{0,14}$`), "Parent must be the name of an ethernet adaptor (e.g. eth0, bond0)."),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mode": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The mode of the macvlan (bridge, private, vepa, passthru) or ipvlan (l2, l3, l3s) network. Defaults to bridge for macvlan and l2 for ipvlan networks.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("bridge", "private", "vepa", "passthru", "l2", "l3", "l3s"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"excluded_ip_ranges": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The ranges of the subnet in CIDR notation that are never assigned to containers, such as addresses already used by other hosts on the LAN of a macvlan or ipvlan network (e.g. 192.168.1.1/32, 192.168.1.64/26).",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^`+ipv4CIDRPattern+`$`), "Excluded IP range must be in CIDR notation (e.g. 192.168.1.64/26)."),
+					),
+					listvalidator.UniqueValues(),
+					listvalidator.AlsoRequires(path.MatchRoot("subnet")),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipv6": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether IPv6 is enabled on the network. Defaults to false.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"subnet6": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The IPv6 subnet of the network in CIDR notation (e.g. fd00:30::/64). Assigned by Container Station when IPv6 is enabled and it is not set.",
+				Validators: []validator.String{
+					ipv6CIDR(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gateway6": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The IPv6 gateway of the network. Assigned by Container Station when IPv6 is enabled and it is not set.",
+				Validators: []validator.String{
+					ipv6Address(),
+					stringvalidator.AlsoRequires(path.MatchRoot("subnet6")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The last updated timestamp of the network.",
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the combination of attributes in the configuration.
+func (r *networkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config NetworkSpecModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// IPv6 subnets are only assigned to networks with IPv6 enabled
+	if !config.Subnet6.IsNull() && !config.IPv6.IsUnknown() && !config.IPv6.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("subnet6"),
+			"IPv6 not enabled",
+			"subnet6 can only be set when ipv6 is true.",
+		)
+	}
+
+	// The allocation and excluded ranges are slices of the subnet
+	if !config.Subnet.IsNull() && !config.Subnet.IsUnknown() {
+		subnet := config.Subnet.ValueString()
+		if !config.IPRange.IsNull() && !config.IPRange.IsUnknown() && !subnetContains(subnet, config.IPRange.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ip_range"),
+				"Invalid IP range",
+				"ip_range "+config.IPRange.ValueString()+" is not within the subnet "+subnet+".",
+			)
+		}
+		if !config.Excluded.IsUnknown() {
+			var excluded []types.String
+			resp.Diagnostics.Append(config.Excluded.ElementsAs(ctx, &excluded, true)...)
+			for i, item := range excluded {
+				if item.IsNull() || item.IsUnknown() || subnetContains(subnet, item.ValueString()) {
+					continue
+				}
+				resp.Diagnostics.AddAttributeError(
+					path.Root("excluded_ip_ranges").AtListIndex(i),
+					"Invalid excluded IP range",
+					"excluded IP range "+item.ValueString()+" is not within the subnet "+subnet+".",
+				)
+			}
+		}
+	}
+
+	if config.Driver.IsUnknown() || config.Parent.IsUnknown() || config.Mode.IsUnknown() {
+		return
+	}
+
+	// macvlan and ipvlan networks are bound to an adaptor, bridge networks are not
+	driver := config.Driver.ValueString()
+	if (driver == "macvlan" || driver == "ipvlan") && config.Parent.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parent"),
+			"Missing parent adaptor",
+			"parent must be set for "+driver+" networks.",
+		)
+	}
+	if driver == "bridge" && !config.Parent.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parent"),
+			"Invalid parent adaptor",
+			"parent can only be set for macvlan and ipvlan networks.",
+		)
+	}
+
+	// Each driver has its own set of modes
+	if config.Mode.IsNull() {
+		return
+	}
+	modes := map[string][]string{
+		"macvlan": {"bridge", "private", "vepa", "passthru"},
+		"ipvlan":  {"l2", "l3", "l3s"},
+	}
+	if !slices.Contains(modes[driver], config.Mode.ValueString()) {
+		detail := "mode can only be set for macvlan and ipvlan networks."
+		if len(modes[driver]) > 0 {
+			detail = "mode of " + driver + " networks must be one of: " + strings.Join(modes[driver], ", ") + "."
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mode"),
+			"Invalid network mode",
+			detail,
+		)
+	}
+}
+
+// Create a new resource.
+func (r *networkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan NetworkSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var excluded []string
+	diags = plan.Excluded.ElementsAs(ctx, &excluded, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	network, err := createNetwork(ctx, r.client, networkSpec{
+		Name:     plan.Name.ValueString(),
+		Driver:   plan.Driver.ValueString(),
+		Subnet:   plan.Subnet.ValueString(),
+		Gateway:  plan.Gateway.ValueString(),
+		IPRange:  plan.IPRange.ValueString(),
+		Parent:   plan.Parent.ValueString(),
+		Mode:     plan.Mode.ValueString(),
+		Excluded: excluded,
+		IPv6:     plan.IPv6.ValueBool(),
+		Subnet6:  plan.Subnet6.ValueString(),
+		Gateway6: plan.Gateway6.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating network",
+			errorDetail("Could not create network "+plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	state := writeNetworkState(ctx, network)
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *networkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state NetworkSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	network, err := findNetwork(ctx, r.client, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the resource", err),
+		)
+		return
+	}
+	if network == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, writeNetworkState(ctx, network))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update is never called as every attribute requires replacement.
+func (r *networkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+// Delete removes the network.
+func (r *networkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	// Retrieve values from state
+	var state NetworkSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := deleteNetwork(ctx, r.client, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting network",
+			errorDetail("Could not delete network "+state.Name.ValueString(), err),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *networkResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+// writeNetworkState maps a network returned by the API to the resource model.
+func writeNetworkState(ctx context.Context, network *networkSpec) NetworkSpecModel {
+	state := NetworkSpecModel{
+		ID:          types.StringValue(network.ID),
+		Name:        types.StringValue(network.Name),
+		Driver:      types.StringValue(network.Driver),
+		Subnet:      types.StringValue(network.Subnet),
+		Gateway:     types.StringValue(network.Gateway),
+		IPv6:        types.BoolValue(network.IPv6),
+		Subnet6:     types.StringValue(network.Subnet6),
+		Gateway6:    types.StringValue(network.Gateway6),
+		IPRange:     types.StringNull(),
+		Parent:      types.StringNull(),
+		Mode:        types.StringValue(network.Mode),
+		Excluded:    types.ListNull(types.StringType),
+		LastUpdated: types.StringValue(time.Now().Format(time.RFC850)),
+	}
+	// Optional attributes without a computed value stay null when unset
+	if network.IPRange != "" {
+		state.IPRange = types.StringValue(network.IPRange)
+	}
+	if network.Parent != "" {
+		state.Parent = types.StringValue(network.Parent)
+	}
+	if len(network.Excluded) > 0 {
+		state.Excluded, _ = types.ListValueFrom(ctx, types.StringType, network.Excluded)
+	}
+	return state
+}
+
+// subnetContains reports whether the range in CIDR notation is within the subnet.
+func subnetContains(subnet string, ipRange string) bool {
+	_, subnetNet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return false
+	}
+	rangeIP, rangeNet, err := net.ParseCIDR(ipRange)
+	if err != nil {
+		return false
+	}
+	subnetOnes, _ := subnetNet.Mask.Size()
+	rangeOnes, _ := rangeNet.Mask.Size()
+	return subnetNet.Contains(rangeIP) && rangeOnes >= subnetOnes
+}
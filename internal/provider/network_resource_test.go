@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccNetworkResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: `
+					resource "qnap_container_network" "bridge" {
+					name     = "terraform_test_bridge"
+					driver   = "bridge"
+					subnet   = "172.30.0.0/16"
+					gateway  = "172.30.0.1"
+					ip_range = "172.30.5.0/24"
+					ipv6     = true
+					subnet6  = "fd00:30::/64"
+					}
+
+					resource "qnap_container" "bridge" {
+					name              = "terraform_test_network"
+					image             = "nginx:latest"
+					type              = "docker"
+					network           = qnap_container_network.bridge.name
+					networktype       = "bridge"
+					network_aliases   = ["web", "web.internal"]
+					removeanonvolumes = true
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container_network.bridge", "name", "terraform_test_bridge"),
+					resource.TestCheckResourceAttr("qnap_container_network.bridge", "driver", "bridge"),
+					resource.TestCheckResourceAttr("qnap_container_network.bridge", "subnet", "172.30.0.0/16"),
+					resource.TestCheckResourceAttr("qnap_container_network.bridge", "gateway", "172.30.0.1"),
+					resource.TestCheckResourceAttr("qnap_container_network.bridge", "ip_range", "172.30.5.0/24"),
+					resource.TestCheckResourceAttrSet("qnap_container_network.bridge", "id"),
+					resource.TestCheckResourceAttr("qnap_container_network.bridge", "ipv6", "true"),
+					resource.TestCheckResourceAttr("qnap_container_network.bridge", "subnet6", "fd00:30::/64"),
+					resource.TestMatchResourceAttr("qnap_container.bridge", "ipaddress6", regexp.MustCompile(`^fd00:30::`)),
+					resource.TestMatchResourceAttr("qnap_container.bridge", "networks.0.ipaddress6", regexp.MustCompile(`^fd00:30::`)),
+					resource.TestCheckResourceAttr("qnap_container.bridge", "network", "terraform_test_bridge"),
+				),
+			},
+			// test case 2
+			{
+				Config: `
+					resource "qnap_container_network" "bridge" {
+					name     = "terraform_test_bridge"
+					driver   = "bridge"
+					subnet   = "172.30.0.0/16"
+					gateway  = "172.30.0.1"
+					ip_range = "172.30.5.0/24"
+					ipv6     = true
+					subnet6  = "fd00:30::/64"
+					}
+
+					resource "qnap_container_network" "bridge_2" {
+					name   = "terraform_test_bridge_2"
+					driver = "bridge"
+					subnet = "172.31.0.0/16"
+					}
+
+					resource "qnap_container" "bridge" {
+					name              = "terraform_test_network"
+					image             = "nginx:latest"
+					type              = "docker"
+					network           = qnap_container_network.bridge_2.name
+					networktype       = "bridge"
+					network_aliases   = ["web", "web.internal"]
+					removeanonvolumes = true
+					}
+
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_container.bridge", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container.bridge", "network", "terraform_test_bridge_2"),
+					resource.TestCheckResourceAttr("qnap_container.bridge", "network_aliases.#", "2"),
+					resource.TestCheckResourceAttr("qnap_container.bridge", "network_aliases.0", "web"),
+					resource.TestMatchResourceAttr("qnap_container.bridge", "ipaddress", regexp.MustCompile(`^172\.31\.`)),
+				),
+			},
+			// test case 3
+			{
+				Config: `
+					resource "qnap_container_network" "ipvlan" {
+					name               = "terraform_test_ipvlan"
+					driver             = "ipvlan"
+					parent             = "eth0"
+					mode               = "l2"
+					subnet             = "192.168.200.0/24"
+					gateway            = "192.168.200.1"
+					ip_range           = "192.168.200.128/25"
+					excluded_ip_ranges = ["192.168.200.128/32", "192.168.200.192/28"]
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_container_network.ipvlan", "driver", "ipvlan"),
+					resource.TestCheckResourceAttr("qnap_container_network.ipvlan", "parent", "eth0"),
+					resource.TestCheckResourceAttr("qnap_container_network.ipvlan", "mode", "l2"),
+					resource.TestCheckResourceAttr("qnap_container_network.ipvlan", "excluded_ip_ranges.#", "2"),
+					resource.TestCheckResourceAttr("qnap_container_network.ipvlan", "excluded_ip_ranges.1", "192.168.200.192/28"),
+				),
+			},
+			// test case 4
+			{
+				Config: `
+					resource "qnap_container_network" "macvlan" {
+					name   = "terraform_test_macvlan"
+					driver = "macvlan"
+					}
+
+				`,
+				ExpectError: regexp.MustCompile("Missing parent adaptor"),
+			},
+			// test case 5
+			{
+				Config: `
+					resource "qnap_container_network" "macvlan" {
+					name   = "terraform_test_macvlan"
+					driver = "macvlan"
+					parent = "eth0"
+					mode   = "l3"
+					}
+
+				`,
+				ExpectError: regexp.MustCompile("Invalid network mode"),
+			},
+		},
+	})
+}
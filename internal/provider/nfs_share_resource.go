@@ -0,0 +1,354 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &nfsShareResource{}
+	_ resource.ResourceWithConfigure   = &nfsShareResource{}
+	_ resource.ResourceWithImportState = &nfsShareResource{}
+)
+
+// defaultNFSAccess and defaultNFSSquash are used when a rule does not set access or squash.
+const (
+	defaultNFSAccess = "ro"
+	defaultNFSSquash = "root_squash"
+)
+
+type NFSShareSpecModel struct {
+	ID          basetypes.StringValue `tfsdk:"id"`
+	Share       basetypes.StringValue `tfsdk:"share"`
+	Rules       basetypes.ListValue   `tfsdk:"rules"`
+	LastUpdated basetypes.StringValue `tfsdk:"last_updated"`
+}
+
+type NFSRuleModel struct {
+	Host   basetypes.StringValue `tfsdk:"host"`
+	Access basetypes.StringValue `tfsdk:"access"`
+	Squash basetypes.StringValue `tfsdk:"squash"`
+}
+
+// nfsRuleAttrTypes are the attribute types of a rule.
+var nfsRuleAttrTypes = map[string]attr.Type{
+	"host":   types.StringType,
+	"access": types.StringType,
+	"squash": types.StringType,
+}
+
+// shareNamePattern matches the names of the shared folders of the NAS.
+var shareNamePattern = regexp.MustCompile(`^[^"+=/\\:|*?<>;\[\]%,` + "`" + `']{1,64}$`)
+
+// nfsShareResource is the resource implementation.
+type nfsShareResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewNFSShareResource is a helper function to simplify the provider implementation.
+func NewNFSShareResource() resource.Resource {
+	return &nfsShareResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *nfsShareResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nfs_share"
+}
+
+// Schema defines the schema for the resource.
+func (r *nfsShareResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the NFS access of a shared folder of the NAS, the hosts it is exported to and how, for example to provision the persistent volumes of a Kubernetes cluster next to the containers using the folder. The shared folder must exist and the NFS service must be enabled. Destroying the resource disables the NFS access of the shared folder, its data is kept.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the NFS share, the name of the shared folder.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"share": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the shared folder (e.g. 'Container', 'k8s-data').",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(shareNamePattern, "Share must be the name of a shared folder, up to 64 characters without \" + = / \\ : | * ? < > ; [ ] % , ` '."),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Required:    true,
+				Description: "The export rules of the shared folder, a host that matches none of them has no access.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"host": schema.StringAttribute{
+							Required:    true,
+							Description: "The hosts the rule applies to: an IP address, a network in CIDR notation (e.g. 192.168.1.0/24), a hostname with optional wildcards (e.g. *.k8s.local) or * for every host.",
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9*?._:-]+(/[0-9]{1,3})?$`), "Host must be an IP address, a network in CIDR notation, a hostname or * (e.g. 192.168.1.0/24)."),
+							},
+						},
+						"access": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Whether the hosts may write to the shared folder (rw) or only read it (ro). Defaults to ro.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("rw", "ro"),
+							},
+						},
+						"squash": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "How the users of the hosts are mapped to the users of the NAS: root_squash maps root to the guest account, all_squash maps every user to the guest account and no_root_squash keeps root, as Kubernetes provisioners that change the owner of volumes need. Defaults to root_squash.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("root_squash", "all_squash", "no_root_squash"),
+							},
+						},
+					},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The last updated timestamp of the NFS share.",
+			},
+		},
+	}
+}
+
+// Create enables the NFS access of the shared folder with the rules.
+func (r *nfsShareResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan NFSShareSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.apply(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *nfsShareResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Read)
+	defer cancel()
+
+	// Get current state
+	var state NFSShareSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := getShareNFS(ctx, r.client, state.ID.ValueString())
+	if err != nil && apiStatus(err) == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the NFS access of shared folder "+state.ID.ValueString(), err),
+		)
+		return
+	}
+	// The NFS access was disabled outside of Terraform
+	if !settings.Enabled {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	refreshed, diags := writeNFSShareState(state.ID.ValueString(), settings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	refreshed.LastUpdated = state.LastUpdated
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, refreshed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update replaces the rules of the shared folder.
+func (r *nfsShareResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan NFSShareSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.apply(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete disables the NFS access of the shared folder and removes its rules.
+func (r *nfsShareResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	// Retrieve values from state
+	var state NFSShareSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setShareNFS(ctx, r.client, state.ID.ValueString(), nfsSettings{Enabled: false})
+	if err != nil && apiStatus(err) != http.StatusNotFound {
+		resp.Diagnostics.AddError(
+			"Error deleting NFS share",
+			errorDetail("Could not disable the NFS access of shared folder "+state.ID.ValueString(), err),
+		)
+		return
+	}
+}
+
+// ImportState imports the NFS access of a shared folder by the name of the shared folder.
+func (r *nfsShareResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *nfsShareResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+// apply replaces the NFS settings of the shared folder with the rules of the plan and returns the state read back
+// from the NAS.
+func (r *nfsShareResource) apply(ctx context.Context, plan *NFSShareSpecModel) (NFSShareSpecModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var rules []NFSRuleModel
+	diags.Append(plan.Rules.ElementsAs(ctx, &rules, false)...)
+	if diags.HasError() {
+		return NFSShareSpecModel{}, diags
+	}
+
+	settings := nfsSettings{Enabled: true}
+	for _, rule := range rules {
+		access, squash := rule.Access.ValueString(), rule.Squash.ValueString()
+		if access == "" {
+			access = defaultNFSAccess
+		}
+		if squash == "" {
+			squash = defaultNFSSquash
+		}
+		settings.Rules = append(settings.Rules, nfsRule{Host: rule.Host.ValueString(), Access: access, Squash: squash})
+	}
+
+	share := plan.Share.ValueString()
+	err := setShareNFS(ctx, r.client, share, settings)
+	if err != nil {
+		diags.AddError(
+			"Error configuring NFS share",
+			errorDetail("Could not configure the NFS access of shared folder "+share, err),
+		)
+		return NFSShareSpecModel{}, diags
+	}
+
+	applied, err := getShareNFS(ctx, r.client, share)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the NFS access of shared folder "+share, err),
+		)
+		return NFSShareSpecModel{}, diags
+	}
+
+	state, stateDiags := writeNFSShareState(share, applied)
+	diags.Append(stateDiags...)
+	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	return state, diags
+}
+
+// writeNFSShareState maps the NFS settings of a shared folder returned by the API to the resource model.
+func writeNFSShareState(share string, settings *nfsSettings) (NFSShareSpecModel, diag.Diagnostics) {
+	rules := []attr.Value{}
+	for _, rule := range settings.Rules {
+		rules = append(rules, types.ObjectValueMust(nfsRuleAttrTypes, map[string]attr.Value{
+			"host":   types.StringValue(rule.Host),
+			"access": types.StringValue(rule.Access),
+			"squash": types.StringValue(rule.Squash),
+		}))
+	}
+	list, diags := types.ListValue(types.ObjectType{AttrTypes: nfsRuleAttrTypes}, rules)
+
+	return NFSShareSpecModel{
+		ID:          types.StringValue(share),
+		Share:       types.StringValue(share),
+		Rules:       list,
+		LastUpdated: types.StringNull(),
+	}, diags
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNFSShareResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: `
+					resource "qnap_nfs_share" "k8s" {
+					share = "Public"
+					rules = [
+						{
+							host = "192.168.1.0/24"
+						},
+					]
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_nfs_share.k8s", "id", "Public"),
+					resource.TestCheckResourceAttr("qnap_nfs_share.k8s", "rules.#", "1"),
+					resource.TestCheckResourceAttr("qnap_nfs_share.k8s", "rules.0.host", "192.168.1.0/24"),
+					resource.TestCheckResourceAttr("qnap_nfs_share.k8s", "rules.0.access", "ro"),
+					resource.TestCheckResourceAttr("qnap_nfs_share.k8s", "rules.0.squash", "root_squash"),
+				),
+			},
+			// test case 2 - rules changed in place
+			{
+				Config: `
+					resource "qnap_nfs_share" "k8s" {
+					share = "Public"
+					rules = [
+						{
+							host   = "192.168.1.0/24"
+							access = "rw"
+							squash = "no_root_squash"
+						},
+						{
+							host = "*"
+						},
+					]
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_nfs_share.k8s", "rules.#", "2"),
+					resource.TestCheckResourceAttr("qnap_nfs_share.k8s", "rules.0.access", "rw"),
+					resource.TestCheckResourceAttr("qnap_nfs_share.k8s", "rules.0.squash", "no_root_squash"),
+					resource.TestCheckResourceAttr("qnap_nfs_share.k8s", "rules.1.host", "*"),
+				),
+			},
+			// test case 2 - import by the name of the shared folder
+			{
+				ResourceName:            "qnap_nfs_share.k8s",
+				ImportState:             true,
+				ImportStateId:           "Public",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// test case 3 - unknown squash option
+			{
+				Config: `
+					resource "qnap_nfs_share" "k8s" {
+					share = "Public"
+					rules = [
+						{
+							host   = "192.168.1.0/24"
+							squash = "squash_everything"
+						},
+					]
+					}
+
+				`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Match"),
+			},
+		},
+	})
+}
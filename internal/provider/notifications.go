@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// defaultNotificationSource is the sender of the notifications when the notifications block does not set one.
+const defaultNotificationSource = "Terraform"
+
+// notificationsModel maps the notifications block of the provider.
+type notificationsModel struct {
+	Level  types.String `tfsdk:"level"`
+	Source types.String `tfsdk:"source"`
+}
+
+// notificationSpec is the payload of the Notification Center message endpoint, the NAS forwards the message to the
+// push, email and other channels configured for its level.
+type notificationSpec struct {
+	Level   string `json:"level"`
+	Source  string `json:"source"`
+	Message string `json:"message"`
+}
+
+// notifier posts the changes made by the resources to the Notification Center of the NAS, a nil notifier posts
+// nothing.
+type notifier struct {
+	client *qnap.Client
+	level  string
+	source string
+}
+
+// newNotifier returns the notifier of the notifications block of the provider, nil when the block is not set.
+func newNotifier(client *qnap.Client, config *notificationsModel) *notifier {
+	if config == nil {
+		return nil
+	}
+	n := &notifier{client: client, level: "info", source: defaultNotificationSource}
+	if !config.Level.IsNull() && !config.Level.IsUnknown() {
+		n.level = config.Level.ValueString()
+	}
+	if !config.Source.IsNull() && !config.Source.IsUnknown() {
+		n.source = config.Source.ValueString()
+	}
+	return n
+}
+
+// notify posts a message once an operation succeeded. The change is already applied, so a message that cannot be
+// posted is reported as a warning.
+func (n *notifier) notify(ctx context.Context, diags *diag.Diagnostics, message string) {
+	if n == nil || diags.HasError() {
+		return
+	}
+	path := "/notification-center/api/v1/messages"
+	err := apiRequest(ctx, n.client, http.MethodPost, path, notificationSpec{
+		Level:   n.level,
+		Source:  n.source,
+		Message: message,
+	}, nil)
+	if err != nil {
+		diags.AddWarning(
+			"Error posting notification",
+			errorDetail("Could not post \""+message+"\" to the Notification Center", withRequest(err, http.MethodPost, path)),
+		)
+	}
+}
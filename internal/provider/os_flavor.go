@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// The operating systems of the NAS. QuTS hero manages its storage with ZFS, so some of its endpoints differ.
+const (
+	osFlavorQTS      = "qts"
+	osFlavorQuTSHero = "quts_hero"
+)
+
+// quTSHeroPaths maps the API paths of QTS to the paths of the same endpoints on QuTS hero.
+var quTSHeroPaths = map[string]string{
+	"/container-station/api/v3/system/snapshots": "/container-station/api/v3/system/zfs/snapshots",
+}
+
+// detectOSFlavor returns the operating system of the NAS from the platform of its firmware.
+func detectOSFlavor(ctx context.Context, client *qnap.Client) string {
+	firmware, err := getFirmwareInfo(ctx, client)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to detect the QNAP operating system, assuming QTS", map[string]any{"error": err.Error()})
+		return osFlavorQTS
+	}
+	platform := strings.ToLower(firmware.Platform)
+	if strings.Contains(platform, "hero") {
+		return osFlavorQuTSHero
+	}
+	return osFlavorQTS
+}
+
+// osFlavor returns the operating system of the NAS, detected when the provider signs in unless it is configured.
+func osFlavor(ctx context.Context, client *qnap.Client) (string, error) {
+	session, ok := client.HTTPClient.Transport.(*sessionTransport)
+	if !ok {
+		return osFlavorQTS, nil
+	}
+	session = session.reader()
+	if _, err := session.session(ctx); err != nil {
+		return "", err
+	}
+	return session.flavor, nil
+}
+
+// flavorPath returns the path of an endpoint on the operating system of the NAS.
+func flavorPath(ctx context.Context, client *qnap.Client, path string) (string, error) {
+	heroPath, ok := quTSHeroPaths[path]
+	if !ok {
+		return path, nil
+	}
+	flavor, err := osFlavor(ctx, client)
+	if err != nil {
+		return "", err
+	}
+	if flavor == osFlavorQuTSHero {
+		return heroPath, nil
+	}
+	return path, nil
+}
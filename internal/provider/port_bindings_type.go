@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.ListTypable                      = portBindingsType{}
+	_ basetypes.ListValuableWithSemanticEquals   = portBindingsValue{}
+	_ basetypes.ObjectTypable                    = portBindingType{}
+	_ basetypes.ObjectValuableWithSemanticEquals = portBindingValue{}
+)
+
+// portBindingAttrTypes are the attributes of a port binding of the container resource.
+var portBindingAttrTypes = map[string]attr.Type{
+	"host":      basetypes.Int32Type{},
+	"container": basetypes.Int32Type{},
+	"protocol":  basetypes.StringType{},
+	"hostip":    basetypes.StringType{},
+}
+
+// containersPortBindingAttrTypes are the attributes of a port binding read by the container data sources.
+var containersPortBindingAttrTypes = map[string]attr.Type{
+	"host":        basetypes.Int32Type{},
+	"container":   basetypes.Int32Type{},
+	"protocol":    basetypes.StringType{},
+	"hostip":      basetypes.StringType{},
+	"containerip": basetypes.StringType{},
+}
+
+// newPortBindingsType returns the type of a portbindings list whose bindings have the given attributes.
+func newPortBindingsType(attrTypes map[string]attr.Type) portBindingsType {
+	return portBindingsType{ListType: basetypes.ListType{ElemType: newPortBindingType(attrTypes)}}
+}
+
+// newPortBindingType returns the type of a port binding with the given attributes.
+func newPortBindingType(attrTypes map[string]attr.Type) portBindingType {
+	return portBindingType{ObjectType: basetypes.ObjectType{AttrTypes: attrTypes}}
+}
+
+// portBindingsType is a list of port bindings which the API may return in any order.
+type portBindingsType struct {
+	basetypes.ListType
+}
+
+// Equal returns whether the types are the same.
+func (t portBindingsType) Equal(o attr.Type) bool {
+	other, ok := o.(portBindingsType)
+	if !ok {
+		return false
+	}
+	return t.ListType.Equal(other.ListType)
+}
+
+// String returns a human readable name of the type.
+func (t portBindingsType) String() string {
+	return "portBindingsType"
+}
+
+// ValueFromList wraps a list value in the custom value type.
+func (t portBindingsType) ValueFromList(_ context.Context, in basetypes.ListValue) (basetypes.ListValuable, diag.Diagnostics) {
+	return portBindingsValue{ListValue: in}, nil
+}
+
+// ValueFromTerraform returns the custom value of a Terraform value.
+func (t portBindingsType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.ListType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := value.(basetypes.ListValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+	return portBindingsValue{ListValue: list}, nil
+}
+
+// ValueType returns the value type of the type.
+func (t portBindingsType) ValueType(_ context.Context) attr.Value {
+	return portBindingsValue{}
+}
+
+// portBindingsValue is the value of a portBindingsType.
+type portBindingsValue struct {
+	basetypes.ListValue
+}
+
+// Equal returns whether the values are exactly the same.
+func (v portBindingsValue) Equal(o attr.Value) bool {
+	other, ok := o.(portBindingsValue)
+	if !ok {
+		return false
+	}
+	return v.ListValue.Equal(other.ListValue)
+}
+
+// Type returns the type of the value.
+func (v portBindingsValue) Type(ctx context.Context) attr.Type {
+	return portBindingsType{ListType: basetypes.ListType{ElemType: v.ElementType(ctx)}}
+}
+
+// ListSemanticEquals returns whether the lists hold the same bindings, in any order.
+func (v portBindingsValue) ListSemanticEquals(_ context.Context, o basetypes.ListValuable) (bool, diag.Diagnostics) {
+	other, ok := o.(portBindingsValue)
+	if !ok {
+		return false, nil
+	}
+	if v.IsNull() || v.IsUnknown() || other.IsNull() || other.IsUnknown() {
+		return false, nil
+	}
+
+	keys, otherKeys := portBindingKeys(v.Elements()), portBindingKeys(other.Elements())
+	if len(keys) != len(otherKeys) {
+		return false, nil
+	}
+	for i := range keys {
+		if keys[i] != otherKeys[i] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// portBindingType is a port binding whose protocol and host IP the API may spell differently.
+type portBindingType struct {
+	basetypes.ObjectType
+}
+
+// Equal returns whether the types are the same.
+func (t portBindingType) Equal(o attr.Type) bool {
+	other, ok := o.(portBindingType)
+	if !ok {
+		return false
+	}
+	return t.ObjectType.Equal(other.ObjectType)
+}
+
+// String returns a human readable name of the type.
+func (t portBindingType) String() string {
+	return "portBindingType"
+}
+
+// ValueFromObject wraps an object value in the custom value type.
+func (t portBindingType) ValueFromObject(_ context.Context, in basetypes.ObjectValue) (basetypes.ObjectValuable, diag.Diagnostics) {
+	return portBindingValue{ObjectValue: in}, nil
+}
+
+// ValueFromTerraform returns the custom value of a Terraform value.
+func (t portBindingType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.ObjectType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := value.(basetypes.ObjectValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+	return portBindingValue{ObjectValue: object}, nil
+}
+
+// ValueType returns the value type of the type.
+func (t portBindingType) ValueType(_ context.Context) attr.Value {
+	return portBindingValue{}
+}
+
+// portBindingValue is the value of a portBindingType.
+type portBindingValue struct {
+	basetypes.ObjectValue
+}
+
+// Equal returns whether the values are exactly the same.
+func (v portBindingValue) Equal(o attr.Value) bool {
+	other, ok := o.(portBindingValue)
+	if !ok {
+		return false
+	}
+	return v.ObjectValue.Equal(other.ObjectValue)
+}
+
+// Type returns the type of the value.
+func (v portBindingValue) Type(ctx context.Context) attr.Type {
+	return newPortBindingType(v.AttributeTypes(ctx))
+}
+
+// ObjectSemanticEquals returns whether the bindings publish the same port.
+func (v portBindingValue) ObjectSemanticEquals(_ context.Context, o basetypes.ObjectValuable) (bool, diag.Diagnostics) {
+	other, ok := o.(portBindingValue)
+	if !ok {
+		return false, nil
+	}
+	if v.IsNull() || v.IsUnknown() || other.IsNull() || other.IsUnknown() {
+		return false, nil
+	}
+	return portBindingKey(v.ObjectValue) == portBindingKey(other.ObjectValue), nil
+}
+
+// portBindingKeys returns the sorted keys of a list of port bindings.
+func portBindingKeys(elements []attr.Value) []string {
+	keys := make([]string, 0, len(elements))
+	for _, element := range elements {
+		switch element := element.(type) {
+		case portBindingValue:
+			keys = append(keys, portBindingKey(element.ObjectValue))
+		case basetypes.ObjectValue:
+			keys = append(keys, portBindingKey(element))
+		default:
+			keys = append(keys, element.String())
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// portBindingKey returns a string that is the same for bindings that publish the same port.
+// The protocol is compared case-insensitively and an empty host IP is the same as 0.0.0.0.
+func portBindingKey(binding basetypes.ObjectValue) string {
+	attributes := binding.Attributes()
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		value := attributes[name]
+		part := value.String()
+		if str, ok := value.(basetypes.StringValue); ok && !str.IsNull() && !str.IsUnknown() {
+			part = normalizePortBindingString(name, str.ValueString())
+		}
+		parts = append(parts, name+"="+part)
+	}
+	return strings.Join(parts, ",")
+}
+
+// normalizePortBindingString returns the value of a string attribute of a port binding as the provider stores it.
+func normalizePortBindingString(name string, value string) string {
+	switch name {
+	case "protocol":
+		return strings.ToLower(value)
+	case "hostip":
+		if value == "" {
+			return "0.0.0.0"
+		}
+	}
+	return value
+}
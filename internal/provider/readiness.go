@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = regexValidator{}
+
+// defaultReadinessTimeout and defaultReadinessInterval are used when the readiness of a container does not set them.
+const (
+	defaultReadinessTimeout  = 5 * time.Minute
+	defaultReadinessInterval = 5 * time.Second
+)
+
+// readinessModel is the readiness attribute of a container, the conditions it must meet before the apply continues.
+type readinessModel struct {
+	TCPPort    types.Int32  `tfsdk:"tcp_port"`
+	Host       types.String `tfsdk:"host"`
+	LogPattern types.String `tfsdk:"log_pattern"`
+	Timeout    types.String `tfsdk:"timeout"`
+	Interval   types.String `tfsdk:"interval"`
+}
+
+// readinessAttribute returns the schema of the readiness attribute.
+func readinessAttribute() schema.SingleNestedAttribute {
+	duration := stringvalidator.RegexMatches(regexp.MustCompile(`^([0-9]+(h|m|s))+$`), "Must be a duration (e.g. '5s', '2m').")
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "Conditions the running container must meet after it is created, recreated or restarted before the apply continues, so dependent resources only start when the service is usable. The apply fails with the unmet conditions when they are not met within timeout.",
+		Attributes: map[string]schema.Attribute{
+			"tcp_port": schema.Int32Attribute{
+				Optional:    true,
+				Description: "A TCP port that must accept connections, usually a published host port.",
+				Validators: []validator.Int32{
+					int32validator.Between(1, 65535),
+				},
+			},
+			"host": schema.StringAttribute{
+				Optional:    true,
+				Description: "The host to connect to tcp_port on. Defaults to the host of the provider.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("tcp_port")),
+				},
+			},
+			"log_pattern": schema.StringAttribute{
+				Optional:    true,
+				Description: "A regular expression that must match the log of the container (e.g. 'ready to accept connections').",
+				Validators: []validator.String{
+					regexValidator{},
+					stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("tcp_port")),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "The maximum duration to wait for the conditions (e.g. '90s', '10m'). Defaults to 5m.",
+				Validators:  []validator.String{duration},
+			},
+			"interval": schema.StringAttribute{
+				Optional:    true,
+				Description: "The duration between two checks of the conditions. Defaults to 5s.",
+				Validators:  []validator.String{duration},
+			},
+		},
+	}
+}
+
+// regexValidator validates that a string is a valid regular expression.
+type regexValidator struct{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v regexValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			err.Error(),
+		)
+	}
+}
+
+// waitForReadiness polls the conditions of the readiness of a container until they are all met. When they are not met
+// in time it returns the unmet conditions.
+func waitForReadiness(ctx context.Context, client *qnap.Client, containerID string, containerType string, readiness *readinessModel) error {
+	timeout, interval := defaultReadinessTimeout, defaultReadinessInterval
+	var err error
+	if !readiness.Timeout.IsNull() {
+		if timeout, err = time.ParseDuration(readiness.Timeout.ValueString()); err != nil {
+			return err
+		}
+	}
+	if !readiness.Interval.IsNull() {
+		if interval, err = time.ParseDuration(readiness.Interval.ValueString()); err != nil {
+			return err
+		}
+	}
+
+	var address string
+	if !readiness.TCPPort.IsNull() {
+		host := readiness.Host.ValueString()
+		if host == "" {
+			host = client.HostURL
+			if hostURL, err := url.Parse(client.HostURL); err == nil && hostURL.Hostname() != "" {
+				host = hostURL.Hostname()
+			}
+		}
+		address = net.JoinHostPort(host, strconv.Itoa(int(readiness.TCPPort.ValueInt32())))
+	}
+	var pattern *regexp.Regexp
+	if !readiness.LogPattern.IsNull() {
+		if pattern, err = regexp.Compile(readiness.LogPattern.ValueString()); err != nil {
+			return err
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		var pending []string
+		if address != "" {
+			conn, err := net.DialTimeout("tcp", address, interval)
+			if err != nil {
+				pending = append(pending, "port "+address+" does not accept connections")
+			} else {
+				conn.Close()
+			}
+		}
+		if pattern != nil {
+			logs, err := containerLogs(ctx, client, containerID, containerType)
+			if err != nil {
+				return err
+			}
+			if !pattern.MatchString(logs) {
+				pending = append(pending, "log does not match "+pattern.String())
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return &containersNotReadyError{pending: pending, cause: fmt.Errorf("timed out after %s", timeout)}
+		}
+
+		tflog.Info(ctx, "Waiting for container readiness", map[string]interface{}{
+			"container": containerID,
+			"pending":   pending,
+		})
+		select {
+		case <-ctx.Done():
+			return &containersNotReadyError{pending: pending, cause: ctx.Err()}
+		case <-time.After(interval):
+		}
+	}
+}
+
+// waitForDependencies polls the containers a container depends on until they are all running, and healthy when
+// healthy is set and they have a health check. When they are not ready in time it returns the pending containers.
+func waitForDependencies(ctx context.Context, client *qnap.Client, containerIDs []string, healthy bool) error {
+	deadline := time.Now().Add(defaultReadinessTimeout)
+	for {
+		var pending []string
+		for _, containerID := range containerIDs {
+			container, err := inspectContainer(ctx, client, containerID, "docker")
+			if err != nil {
+				if !isNotFound(err) {
+					return err
+				}
+				pending = append(pending, containerID+": not found")
+				continue
+			}
+			switch {
+			case container.Data.Status != qnap.ContainerStatusRunning:
+				pending = append(pending, container.Data.Name+": "+container.Data.Status)
+			case healthy && container.Data.DockerStatus.Health != "" && container.Data.DockerStatus.Health != "healthy":
+				pending = append(pending, container.Data.Name+": "+container.Data.Status+" ("+container.Data.DockerStatus.Health+")")
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return &containersNotReadyError{pending: pending, cause: fmt.Errorf("timed out after %s", defaultReadinessTimeout)}
+		}
+
+		tflog.Info(ctx, "Waiting for the containers the container depends on", map[string]interface{}{
+			"pending": pending,
+		})
+		select {
+		case <-ctx.Done():
+			return &containersNotReadyError{pending: pending, cause: ctx.Err()}
+		case <-time.After(defaultReadinessInterval):
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// dockerHubRegistry is the registry of the images whose reference has no registry host.
+const dockerHubRegistry = "registry-1.docker.io"
+
+// manifestMediaTypes are the manifest formats accepted when resolving a digest, the digest of a multi-platform image is
+// the digest of its index as for docker pull.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// challengeParamPattern matches the parameters of a WWW-Authenticate challenge.
+var challengeParamPattern = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// splitRegistryReference splits an image reference into its registry host, repository and tag the way docker does:
+// the first component is the registry when it contains a dot or a port or is localhost, images of Docker Hub without
+// a namespace are in the library namespace.
+func splitRegistryReference(image string) (string, string, string) {
+	name, tag := splitImageReference(image)
+	registry := dockerHubRegistry
+	if first, rest, found := strings.Cut(name, "/"); found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		registry, name = first, rest
+	}
+	if registry == dockerHubRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	return registry, name, tag
+}
+
+// remoteImageDigest returns the digest the tag of an image resolves to in its registry, using the registry HTTP API
+// from the machine running Terraform. The credentials are optional.
+func remoteImageDigest(ctx context.Context, image string, auth *imageRegistryAuth) (string, error) {
+	registry, repository, tag := splitRegistryReference(image)
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, repository, tag)
+
+	resp, err := headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err := registryAuthorization(ctx, resp.Header.Get("WWW-Authenticate"), auth)
+		if err != nil {
+			return "", err
+		}
+		resp, err = headManifest(ctx, manifestURL, authorization)
+		if err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry %s returned %s for %s:%s", registry, resp.Status, repository, tag)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("registry %s returned no digest for %s:%s", registry, repository, tag)
+	}
+	return digest, nil
+}
+
+// headManifest requests the headers of a manifest.
+func headManifest(ctx context.Context, manifestURL string, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// registryAuthorization returns the Authorization header that answers the challenge of a registry, requesting a bearer
+// token from the token service of the registry when it asks for one.
+func registryAuthorization(ctx context.Context, challenge string, auth *imageRegistryAuth) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if strings.EqualFold(scheme, "Basic") {
+		if auth == nil {
+			return "", errors.New("registry requires credentials, set registry_auth")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(auth.Username+":"+auth.Password)), nil
+	}
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported registry authentication %q", challenge)
+	}
+
+	values := map[string]string{}
+	for _, match := range challengeParamPattern.FindAllStringSubmatch(params, -1) {
+		values[match[1]] = match[2]
+	}
+	if values["realm"] == "" {
+		return "", fmt.Errorf("registry authentication challenge has no realm: %q", challenge)
+	}
+	query := url.Values{}
+	for _, name := range []string{"service", "scope"} {
+		if values[name] != "" {
+			query.Set(name, values[name])
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, values["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	if auth != nil {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry token service returned %s", resp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	return "Bearer " + token.Token, nil
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &resourceUsageDataSource{}
+	_ datasource.DataSourceWithConfigure = &resourceUsageDataSource{}
+)
+
+// resourceUsageDataSource is the data source implementation.
+type resourceUsageDataSource struct {
+	client *qnap.Client
+}
+
+// resourceUsageDataSourceModel maps the data source schema data.
+type resourceUsageDataSourceModel struct {
+	CPUCores    types.Int32           `tfsdk:"cpu_cores"`
+	CPUUsage    types.Float64         `tfsdk:"cpu_usage"`
+	MemoryTotal types.Int64           `tfsdk:"memory_total"`
+	MemoryUsed  types.Int64           `tfsdk:"memory_used"`
+	MemoryUsage types.Float64         `tfsdk:"memory_usage"`
+	Containers  []containerUsageModel `tfsdk:"containers"`
+}
+
+// containerUsageModel maps the usage of a container.
+type containerUsageModel struct {
+	ID     types.String  `tfsdk:"id"`
+	Name   types.String  `tfsdk:"name"`
+	Status types.String  `tfsdk:"status"`
+	CPU    types.Float64 `tfsdk:"cpu"`
+	Memory types.Float64 `tfsdk:"memory"`
+}
+
+// NewResourceUsageDataSource is a helper function to simplify the provider implementation.
+func NewResourceUsageDataSource() datasource.DataSource {
+	return &resourceUsageDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *resourceUsageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource_usage"
+}
+
+// Schema defines the schema for the data source.
+func (d *resourceUsageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the current CPU and memory usage of the NAS and of each container, for example to check in a precondition that the NAS has room for a deployment.",
+		Attributes: map[string]schema.Attribute{
+			"cpu_cores": schema.Int32Attribute{
+				Computed:    true,
+				Description: "The number of CPUs of the NAS.",
+			},
+			"cpu_usage": schema.Float64Attribute{
+				Computed:    true,
+				Description: "The CPU usage of the NAS in percent.",
+			},
+			"memory_total": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The memory of the NAS in bytes.",
+			},
+			"memory_used": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The memory used on the NAS in bytes.",
+			},
+			"memory_usage": schema.Float64Attribute{
+				Computed:    true,
+				Description: "The memory usage of the NAS in percent.",
+			},
+			"containers": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The usage of each container.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the container.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the container.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the container.",
+						},
+						"cpu": schema.Float64Attribute{
+							Computed:    true,
+							Description: "The CPU usage of the container in percent.",
+						},
+						"memory": schema.Float64Attribute{
+							Computed:    true,
+							Description: "The memory used by the container in bytes.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *resourceUsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state resourceUsageDataSourceModel
+
+	system, err := getSystemInfo(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read QNAP Resource Usage",
+			errorDetail("Could not read the resource usage of the NAS", err),
+		)
+		return
+	}
+	usage, err := getSystemUsage(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read QNAP Resource Usage",
+			errorDetail("Could not read the resource usage of the NAS", err),
+		)
+		return
+	}
+	overview, err := d.client.GetContainerStationOverview()
+	err = withRequest(err, http.MethodGet, "/container-station/api/v3/overview")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read QNAP Resource Usage",
+			errorDetail("Could not read the resource usage of the NAS", err),
+		)
+		return
+	}
+
+	state.CPUCores = types.Int32Value(int32(system.CPUCores))
+	state.CPUUsage = types.Float64Value(usage.CPUUsage)
+	state.MemoryTotal = types.Int64Value(usage.MemoryTotal)
+	state.MemoryUsed = types.Int64Value(usage.MemoryUsed)
+	state.MemoryUsage = types.Float64Value(0)
+	if usage.MemoryTotal > 0 {
+		state.MemoryUsage = types.Float64Value(float64(usage.MemoryUsed) * 100 / float64(usage.MemoryTotal))
+	}
+
+	state.Containers = []containerUsageModel{}
+	for _, container := range overview.Data.Container {
+		state.Containers = append(state.Containers, containerUsageModel{
+			ID:     types.StringValue(container.ID),
+			Name:   types.StringValue(container.Name),
+			Status: types.StringValue(container.Status),
+			CPU:    types.Float64Value(container.CPU),
+			Memory: types.Float64Value(container.Memory),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *resourceUsageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qnap.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qnap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceUsageDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "qnap_resource_usage" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.qnap_resource_usage.test", "cpu_cores"),
+					resource.TestCheckResourceAttrSet("data.qnap_resource_usage.test", "cpu_usage"),
+					resource.TestCheckResourceAttrSet("data.qnap_resource_usage.test", "memory_total"),
+					resource.TestCheckResourceAttrSet("data.qnap_resource_usage.test", "memory_usage"),
+					resource.TestCheckResourceAttrSet("data.qnap_resource_usage.test", "containers.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// containerScheduleSpec is the payload of the Container Station schedule endpoints and a schedule returned by the
+// schedule list endpoint. The NAS runs the action on the containers at the times of the cron expression.
+type containerScheduleSpec struct {
+	ID      string      `json:"id,omitempty"`
+	Name    string      `json:"name"`
+	Action  string      `json:"action"`
+	Cron    string      `json:"cron"`
+	Enabled bool        `json:"enabled"`
+	Items   []qnap.Item `json:"items"`
+}
+
+// createSchedule creates a container schedule and returns it once it is listed.
+func createSchedule(ctx context.Context, client *qnap.Client, schedule containerScheduleSpec) (*containerScheduleSpec, error) {
+	existing, err := findScheduleByName(ctx, client, schedule.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("cannot create schedule as a schedule with the same name already exists")
+	}
+
+	err = apiRequest(ctx, client, http.MethodPost, "/container-station/api/v3/schedules", schedule, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := findScheduleByName(ctx, client, schedule.Name)
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return nil, errors.New("schedule is not found after creation. Possible options: QNAP container station needs more time or the schedule creation failed silently")
+	}
+	return created, nil
+}
+
+// listSchedules returns the container schedules.
+func listSchedules(ctx context.Context, client *qnap.Client) ([]containerScheduleSpec, error) {
+	var response struct {
+		Data struct {
+			Items []containerScheduleSpec `json:"items"`
+		} `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/schedules", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Data.Items, nil
+}
+
+// findSchedule returns the schedule with the given ID or nil when it does not exist.
+func findSchedule(ctx context.Context, client *qnap.Client, scheduleID string) (*containerScheduleSpec, error) {
+	schedules, err := listSchedules(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, schedule := range schedules {
+		if schedule.ID == scheduleID {
+			return &schedule, nil
+		}
+	}
+	return nil, nil
+}
+
+// findScheduleByName returns the schedule with the given name or nil when it does not exist.
+func findScheduleByName(ctx context.Context, client *qnap.Client, name string) (*containerScheduleSpec, error) {
+	schedules, err := listSchedules(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, schedule := range schedules {
+		if schedule.Name == name {
+			return &schedule, nil
+		}
+	}
+	return nil, nil
+}
+
+// updateSchedule replaces the settings of a container schedule.
+func updateSchedule(ctx context.Context, client *qnap.Client, schedule containerScheduleSpec) error {
+	return apiRequest(ctx, client, http.MethodPut, fmt.Sprintf("/container-station/api/v3/schedules/%s", schedule.ID), schedule, nil)
+}
+
+// deleteSchedule removes a container schedule.
+func deleteSchedule(ctx context.Context, client *qnap.Client, scheduleID string) error {
+	return apiRequest(ctx, client, http.MethodDelete, fmt.Sprintf("/container-station/api/v3/schedules/%s", scheduleID), nil, nil)
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringService is the service name the provider looks up its password under in the keyring of the OS.
+const keyringService = "terraform-provider-qnap"
+
+// readSecretFile returns the content of a file holding a password or token, without the trailing newline.
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	secret := strings.TrimRight(string(data), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return secret, nil
+}
+
+// keyringPassword returns the password of the user from the keyring of the OS, through secret-tool on Linux (the
+// Secret Service of GNOME Keyring or KWallet) and the security tool on macOS.
+func keyringPassword(ctx context.Context, username string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.CommandContext(ctx, "secret-tool", "lookup", "service", keyringService, "username", username)
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "security", "find-generic-password", "-s", keyringService, "-a", username, "-w")
+	default:
+		return "", fmt.Errorf("the keyring is not supported on %s", runtime.GOOS)
+	}
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%w: %s", err, message)
+		}
+		return "", err
+	}
+	password := strings.TrimRight(string(out), "\r\n")
+	if password == "" {
+		return "", errors.New("no password is stored in the keyring for the " + keyringService + " service and user " + username)
+	}
+	return password, nil
+}
@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// sessionPlaceholder is the token of the qnap client. The client library and apiRequest send it in the Cookie and
+// Authorization headers of their requests, and sessionTransport replaces it with the session of the provider.
+const sessionPlaceholder = "NAS_SID=session"
+
+// sessions are the session transports of the provider, signed out by Shutdown when the provider server stops.
+var sessions struct {
+	mu         sync.Mutex
+	transports []*sessionTransport
+}
+
+// registerSession adds a session transport to the sessions signed out by Shutdown.
+func registerSession(t *sessionTransport) {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+	sessions.transports = append(sessions.transports, t)
+}
+
+// Shutdown signs out the sessions the provider signed in, so frequent runs do not exhaust the concurrent session
+// limit of the NAS. Sessions from an access token or kept in the session cache for the following runs stay valid.
+func Shutdown(ctx context.Context) {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+	for _, t := range sessions.transports {
+		if err := t.signOut(ctx); err != nil {
+			tflog.Warn(ctx, "Unable to sign out of the NAS", map[string]any{"host": t.host, "error": err.Error()})
+		}
+	}
+	sessions.transports = nil
+}
+
+// sessionTransport signs in to the NAS on the first request that needs a session, so the provider can be configured,
+// for example to plan new resources, without contacting the NAS. It authenticates with the access token, reuses a
+// cached session the NAS still accepts or signs in with the credentials. It is the only holder of the session token,
+// shared by the resources and data sources of the provider through their client, and guards it with mu.
+type sessionTransport struct {
+	next        http.RoundTripper
+	host        string
+	credentials clientCredentials
+	cache       sessionCache
+	timeout     time.Duration
+	// flavor is the operating system of the NAS, detected on sign in when it is not configured.
+	flavor string
+	// apiVersion is the Container Station API version, probed on sign in when it is not configured.
+	apiVersion string
+	// configErr is returned by every request when the provider was configured without a host or credentials.
+	configErr error
+	// readOnly, when set, sends the requests that do not change the NAS with read-only credentials.
+	readOnly *sessionTransport
+
+	mu    sync.Mutex
+	token string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if reader := t.reader(); reader != t && (req.Method == http.MethodGet || req.Method == http.MethodHead) {
+		return reader.RoundTrip(req)
+	}
+	if req.Header.Get("Cookie") != sessionPlaceholder {
+		return t.next.RoundTrip(req)
+	}
+
+	token, err := t.session(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	res, err := t.send(req, token)
+	if err != nil || !sessionExpired(res) {
+		return res, err
+	}
+
+	// The session expired during the run, sign in again and send the request once more, unless its body cannot be
+	// sent again.
+	if req.Body != nil && req.GetBody == nil {
+		return res, nil
+	}
+	tflog.Debug(req.Context(), "QNAP session expired, signing in again", map[string]any{"path": req.URL.Path})
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+	t.expire(token)
+	token, err = t.session(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	return t.send(req, token)
+}
+
+// send sends a request with the session token.
+func (t *sessionTransport) send(req *http.Request, token string) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+	setSessionHeaders(req, token)
+	setAPIVersion(req, t.apiVersion)
+	return t.next.RoundTrip(req)
+}
+
+// sessionExpired returns whether the NAS rejected a request because its session expired. The NAS answers 401
+// Unauthorized, or reports the expired session in the body of another client error. The body is kept readable.
+func sessionExpired(res *http.Response) bool {
+	if res.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	if res.StatusCode < 400 || res.StatusCode >= 500 {
+		return false
+	}
+	prefix, err := io.ReadAll(io.LimitReader(res.Body, 4096))
+	res.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(prefix), res.Body), res.Body}
+	if err != nil {
+		return false
+	}
+	body := strings.ToLower(string(prefix))
+	return strings.Contains(body, "session expired") || strings.Contains(body, "invalid session")
+}
+
+// expire forgets the session token after the NAS rejected it, unless a concurrent request already signed in again.
+func (t *sessionTransport) expire(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token == token {
+		t.token = ""
+	}
+}
+
+// setSessionHeaders sets the session token in the headers of a request the way the client library does.
+func setSessionHeaders(req *http.Request, token string) {
+	if parts := strings.SplitN(token, "=", 2); len(parts) == 2 {
+		req.Header.Set("Authorization", "Bearer "+parts[1])
+	}
+	req.Header.Set("Cookie", token)
+}
+
+// requestToken returns the token to pass to a client library call. The shared client only holds the session
+// placeholder, which never changes, and every call gets a copy of its own so no call holds a pointer into the client
+// while sessionTransport signs in again.
+func requestToken(client *qnap.Client) *string {
+	token := client.Token
+	return &token
+}
+
+// sessionToken returns the session token of the client for the APIs that take it as a parameter, which only read the
+// NAS, signing in when there is no session yet.
+func sessionToken(ctx context.Context, client *qnap.Client) (string, error) {
+	if session, ok := client.HTTPClient.Transport.(*sessionTransport); ok && client.Token == sessionPlaceholder {
+		return session.reader().session(ctx)
+	}
+	return client.Token, nil
+}
+
+// reader returns the transport of the requests that do not change the NAS.
+func (t *sessionTransport) reader() *sessionTransport {
+	if t.readOnly != nil {
+		return t.readOnly
+	}
+	return t
+}
+
+// session returns the session token, signing in when there is none yet. Concurrent requests wait for a single login.
+func (t *sessionTransport) session(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.configErr != nil {
+		return "", t.configErr
+	}
+	if t.token != "" {
+		return t.token, nil
+	}
+
+	client, err := t.signIn(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to sign in to the NAS: %w", err)
+	}
+	if t.flavor == "" {
+		t.flavor = detectOSFlavor(ctx, client)
+		tflog.Debug(ctx, "Detected QNAP operating system", map[string]any{"os_flavor": t.flavor})
+	}
+	t.token = client.Token
+	return t.token, nil
+}
+
+// signIn returns a client with a new session token. The requests are sent with a client of their own, which sends them through the
+// next transport, as the session transport is locked.
+func (t *sessionTransport) signIn(ctx context.Context) (*qnap.Client, error) {
+	client := &qnap.Client{
+		HostURL: t.host,
+		HTTPClient: &http.Client{
+			Transport: t.next,
+			Timeout:   t.timeout,
+		},
+	}
+	if t.apiVersion == "" {
+		t.apiVersion = probeAPIVersion(ctx, client)
+		tflog.Debug(ctx, "Probed Container Station API version", map[string]any{"api_version": t.apiVersion})
+	}
+	client.HTTPClient.Transport = &apiVersionTransport{next: t.next, version: t.apiVersion}
+
+	if t.credentials.accessToken != "" {
+		err := useAccessToken(ctx, client, t.credentials.accessToken)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+
+	key := sessionCacheKey(t.host, t.credentials.username)
+	if token := t.cache.load(ctx, key); token != "" {
+		client.Token = token
+		if _, err := getSystemInfo(ctx, client); err == nil {
+			tflog.Debug(ctx, "Reusing cached QNAP session")
+			return client, nil
+		}
+		tflog.Debug(ctx, "Cached QNAP session was rejected, signing in")
+	}
+
+	err := signIn(ctx, client, t.credentials)
+	if err != nil {
+		return nil, err
+	}
+	t.cache.store(ctx, key, client.Token)
+	return client, nil
+}
+
+// signOut invalidates the session the transport signed in, if any.
+func (t *sessionTransport) signOut(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == "" || t.credentials.accessToken != "" || t.cache.path != "" {
+		return nil
+	}
+	client := &qnap.Client{
+		HostURL: t.host,
+		HTTPClient: &http.Client{
+			Transport: t.next,
+			Timeout:   t.timeout,
+		},
+		Token: t.token,
+	}
+	t.token = ""
+	return apiRequest(ctx, client, http.MethodPost, "/container-station/api/v1/logout", nil, nil)
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sessionCache stores the session tokens of the provider in a file, so the following runs reuse a session instead of
+// signing in again, which triggers the brute-force protection and the notifications of the NAS. The zero value does
+// not cache anything.
+type sessionCache struct {
+	path string
+}
+
+// sessionCacheKey identifies the session of a user on a NAS in the cache.
+func sessionCacheKey(host string, username string) string {
+	return username + "@" + host
+}
+
+// load returns the cached session token for the key, empty when there is none.
+func (c sessionCache) load(ctx context.Context, key string) string {
+	if c.path == "" {
+		return ""
+	}
+	sessions, err := c.read()
+	if err != nil {
+		tflog.Warn(ctx, "Unable to read the QNAP session cache, signing in", map[string]interface{}{"path": c.path, "error": err.Error()})
+		return ""
+	}
+	return sessions[key]
+}
+
+// store saves the session token for the key, the file is only readable by the current user. A corrupt file is
+// replaced, otherwise every following run would sign in again.
+func (c sessionCache) store(ctx context.Context, key string, token string) {
+	if c.path == "" {
+		return
+	}
+	sessions, err := c.read()
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		sessions, err = map[string]string{}, nil
+	}
+	if err == nil {
+		sessions[key] = token
+		err = c.write(sessions)
+	}
+	if err != nil {
+		tflog.Warn(ctx, "Unable to write the QNAP session cache", map[string]interface{}{"path": c.path, "error": err.Error()})
+	}
+}
+
+// read returns the cached sessions, an empty cache when the file does not exist yet.
+func (c sessionCache) read() (map[string]string, error) {
+	sessions := map[string]string{}
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return sessions, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return sessions, nil
+	}
+	err = json.Unmarshal(data, &sessions)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// write replaces the cache file, through a rename so that concurrent runs never read a partial file.
+func (c sessionCache) write(sessions map[string]string) error {
+	data, err := json.Marshal(sessions)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(c.path), 0o700)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), c.path)
+}
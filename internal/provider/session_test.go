@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSessionTransportReadOnly(t *testing.T) {
+	testCases := map[string]struct {
+		method string
+		cookie string
+		sent   string
+	}{
+		"get": {
+			method: http.MethodGet,
+			cookie: sessionPlaceholder,
+			sent:   "NAS_SID=reader",
+		},
+		"head": {
+			method: http.MethodHead,
+			cookie: sessionPlaceholder,
+			sent:   "NAS_SID=reader",
+		},
+		"post": {
+			method: http.MethodPost,
+			cookie: sessionPlaceholder,
+			sent:   "NAS_SID=primary",
+		},
+		"delete": {
+			method: http.MethodDelete,
+			cookie: sessionPlaceholder,
+			sent:   "NAS_SID=primary",
+		},
+		"without session": {
+			method: http.MethodPost,
+			sent:   "",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var cookies []string
+			next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				cookies = append(cookies, req.Header.Get("Cookie"))
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+			})
+			transport := &sessionTransport{
+				next:       next,
+				host:       "https://nas.local",
+				flavor:     "qts",
+				apiVersion: defaultAPIVersion,
+				token:      "NAS_SID=primary",
+				readOnly: &sessionTransport{
+					next:       next,
+					host:       "https://nas.local",
+					flavor:     "qts",
+					apiVersion: defaultAPIVersion,
+					token:      "NAS_SID=reader",
+				},
+			}
+
+			req, err := http.NewRequest(testCase.method, "https://nas.local/container-station/api/v3/container", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if testCase.cookie != "" {
+				req.Header.Set("Cookie", testCase.cookie)
+			}
+			if _, err := transport.RoundTrip(req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(cookies) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(cookies))
+			}
+			if cookies[0] != testCase.sent {
+				t.Errorf("expected session %q, got %q", testCase.sent, cookies[0])
+			}
+		})
+	}
+}
+
+func TestSessionTransportExpired(t *testing.T) {
+	testCases := map[string]struct {
+		method   string
+		body     string
+		statuses []int
+		messages []string
+		logins   int
+		attempts int
+		status   int
+	}{
+		"unauthorized": {
+			method:   http.MethodGet,
+			statuses: []int{http.StatusUnauthorized, http.StatusOK},
+			logins:   1,
+			attempts: 2,
+			status:   http.StatusOK,
+		},
+		"session expired": {
+			method:   http.MethodPost,
+			body:     `{"name":"web"}`,
+			statuses: []int{http.StatusBadRequest, http.StatusOK},
+			messages: []string{`{"message":"Session expired"}`, `{}`},
+			logins:   1,
+			attempts: 2,
+			status:   http.StatusOK,
+		},
+		"still unauthorized": {
+			method:   http.MethodGet,
+			statuses: []int{http.StatusUnauthorized},
+			logins:   1,
+			attempts: 2,
+			status:   http.StatusUnauthorized,
+		},
+		"other client error": {
+			method:   http.MethodPost,
+			body:     `{"name":"web"}`,
+			statuses: []int{http.StatusBadRequest},
+			messages: []string{`{"message":"Invalid parameter"}`},
+			attempts: 1,
+			status:   http.StatusBadRequest,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			logins := 0
+			var cookies, bodies []string
+			next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path == "/container-station/api/v1/login" {
+					logins++
+					header := http.Header{}
+					header.Set("Set-Cookie", "NAS_SID=new; path=/")
+					return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+				}
+				body := ""
+				if req.Body != nil {
+					content, err := io.ReadAll(req.Body)
+					if err != nil {
+						t.Fatalf("unexpected error reading the body: %v", err)
+					}
+					body = string(content)
+				}
+				cookies = append(cookies, req.Header.Get("Cookie"))
+				bodies = append(bodies, body)
+				attempt := min(len(cookies), len(testCase.statuses)) - 1
+				message := "{}"
+				if attempt < len(testCase.messages) {
+					message = testCase.messages[attempt]
+				}
+				return &http.Response{StatusCode: testCase.statuses[attempt], Body: io.NopCloser(strings.NewReader(message))}, nil
+			})
+			transport := &sessionTransport{
+				next:        next,
+				host:        "https://nas.local",
+				credentials: clientCredentials{username: "admin", password: "secret"},
+				flavor:      "qts",
+				apiVersion:  defaultAPIVersion,
+				token:       "NAS_SID=old",
+			}
+
+			var body io.Reader
+			if testCase.body != "" {
+				body = strings.NewReader(testCase.body)
+			}
+			req, err := http.NewRequest(testCase.method, "https://nas.local/container-station/api/v3/container", body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Set("Cookie", sessionPlaceholder)
+			res, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.StatusCode != testCase.status {
+				t.Errorf("expected status %d, got %d", testCase.status, res.StatusCode)
+			}
+
+			if logins != testCase.logins {
+				t.Errorf("expected %d sign ins, got %d", testCase.logins, logins)
+			}
+			if len(cookies) != testCase.attempts {
+				t.Fatalf("expected %d attempts, got %d", testCase.attempts, len(cookies))
+			}
+			if cookies[0] != "NAS_SID=old" {
+				t.Errorf("expected the first attempt with session %q, got %q", "NAS_SID=old", cookies[0])
+			}
+			if testCase.attempts > 1 && cookies[1] != "NAS_SID=new" {
+				t.Errorf("expected the second attempt with session %q, got %q", "NAS_SID=new", cookies[1])
+			}
+			for attempt, sent := range bodies {
+				if sent != testCase.body {
+					t.Errorf("expected body %q on attempt %d, got %q", testCase.body, attempt+1, sent)
+				}
+			}
+		})
+	}
+
+	t.Run("sign in fails", func(t *testing.T) {
+		attempts := 0
+		next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/container-station/api/v1/login" {
+				return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"message":"Locked"}`))}, nil
+			}
+			attempts++
+			return &http.Response{StatusCode: http.StatusUnauthorized, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+		})
+		transport := &sessionTransport{
+			next:        next,
+			host:        "https://nas.local",
+			credentials: clientCredentials{username: "admin", password: "secret"},
+			flavor:      "qts",
+			apiVersion:  defaultAPIVersion,
+			token:       "NAS_SID=old",
+		}
+
+		req, err := http.NewRequest(http.MethodGet, "https://nas.local/container-station/api/v3/container", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		req.Header.Set("Cookie", sessionPlaceholder)
+		_, err = transport.RoundTrip(req)
+		if err == nil || !strings.Contains(err.Error(), "unable to sign in") {
+			t.Errorf("expected a sign in error, got %v", err)
+		}
+		if attempts != 1 {
+			t.Errorf("expected 1 attempt, got %d", attempts)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// nfsRule is an export rule of a shared folder, the hosts it is exported to and how.
+type nfsRule struct {
+	Host   string `json:"host"`
+	Access string `json:"access"`
+	Squash string `json:"squash"`
+}
+
+// nfsSettings are the NFS settings of a shared folder, the payload and response of the NFS endpoint of a share.
+type nfsSettings struct {
+	Enabled bool      `json:"enabled"`
+	Rules   []nfsRule `json:"rules"`
+}
+
+// sharePath returns the path of an endpoint of a shared folder of the NAS.
+func sharePath(share string, endpoint string) string {
+	return fmt.Sprintf("/container-station/api/v3/system/shares/%s/%s", url.PathEscape(share), endpoint)
+}
+
+// getShareNFS returns the NFS settings of a shared folder.
+func getShareNFS(ctx context.Context, client *qnap.Client, share string) (*nfsSettings, error) {
+	var response struct {
+		Data nfsSettings `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, sharePath(share, "nfs"), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// setShareNFS replaces the NFS settings of a shared folder.
+func setShareNFS(ctx context.Context, client *qnap.Client, share string, settings nfsSettings) error {
+	if settings.Rules == nil {
+		settings.Rules = []nfsRule{}
+	}
+	return apiRequest(ctx, client, http.MethodPut, sharePath(share, "nfs"), settings, nil)
+}
+
+// smbSettings are the SMB settings of a shared folder, the payload and response of the SMB endpoint of a share. Users
+// and Groups map the names of the accounts to their permission.
+type smbSettings struct {
+	Visible     bool              `json:"visible"`
+	GuestAccess string            `json:"guestAccess"`
+	Users       map[string]string `json:"users"`
+	Groups      map[string]string `json:"groups"`
+}
+
+// getShareSMB returns the SMB settings of a shared folder.
+func getShareSMB(ctx context.Context, client *qnap.Client, share string) (*smbSettings, error) {
+	var response struct {
+		Data smbSettings `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, sharePath(share, "smb"), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// setShareSMB replaces the SMB settings of a shared folder.
+func setShareSMB(ctx context.Context, client *qnap.Client, share string, settings smbSettings) error {
+	if settings.Users == nil {
+		settings.Users = map[string]string{}
+	}
+	if settings.Groups == nil {
+		settings.Groups = map[string]string{}
+	}
+	return apiRequest(ctx, client, http.MethodPut, sharePath(share, "smb"), settings, nil)
+}
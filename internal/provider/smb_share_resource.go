@@ -0,0 +1,327 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &smbShareResource{}
+	_ resource.ResourceWithConfigure   = &smbShareResource{}
+	_ resource.ResourceWithImportState = &smbShareResource{}
+)
+
+// defaultSMBGuestAccess is used when guest_access is not set.
+const defaultSMBGuestAccess = "none"
+
+type SMBShareSpecModel struct {
+	ID          basetypes.StringValue `tfsdk:"id"`
+	Share       basetypes.StringValue `tfsdk:"share"`
+	Visible     basetypes.BoolValue   `tfsdk:"visible"`
+	GuestAccess basetypes.StringValue `tfsdk:"guest_access"`
+	Users       basetypes.MapValue    `tfsdk:"users"`
+	Groups      basetypes.MapValue    `tfsdk:"groups"`
+	LastUpdated basetypes.StringValue `tfsdk:"last_updated"`
+}
+
+// smbShareResource is the resource implementation.
+type smbShareResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewSMBShareResource is a helper function to simplify the provider implementation.
+func NewSMBShareResource() resource.Resource {
+	return &smbShareResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *smbShareResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_smb_share"
+}
+
+// Schema defines the schema for the resource.
+func (r *smbShareResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	permission := mapvalidator.ValueStringsAre(stringvalidator.OneOf("ro", "rw", "deny"))
+	resp.Schema = schema.Schema{
+		Description: "Manages the SMB access of a shared folder of the NAS: whether it is listed, the guest access and the permissions of users and groups, for example to reach the files the containers write from Windows and macOS clients. The shared folder must exist. Users and groups that are not set have no access, except the administrators of the NAS. Destroying the resource removes the permissions and the guest access and lists the shared folder again, its data is kept.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the SMB share, the name of the shared folder.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"share": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the shared folder (e.g. 'Container', 'media').",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(shareNamePattern, "Share must be the name of a shared folder, up to 64 characters without \" + = / \\ : | * ? < > ; [ ] % , ` '."),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"visible": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the shared folder is listed when browsing the NAS in the network neighborhood. A hidden shared folder is still reachable by its path. Defaults to true.",
+			},
+			"guest_access": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The access of the guest account, used by clients that connect without credentials (none, ro, rw). Defaults to none.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("none", "ro", "rw"),
+				},
+			},
+			"users": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The permission of user accounts of the NAS on the shared folder by name: ro, rw or deny, which takes precedence over the permissions of their groups.",
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					permission,
+				},
+			},
+			"groups": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The permission of groups of the NAS on the shared folder by name: ro, rw or deny.",
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					permission,
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The last updated timestamp of the SMB share.",
+			},
+		},
+	}
+}
+
+// Create configures the SMB access of the shared folder.
+func (r *smbShareResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan SMBShareSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.apply(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *smbShareResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Read)
+	defer cancel()
+
+	// Get current state
+	var state SMBShareSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := getShareSMB(ctx, r.client, state.ID.ValueString())
+	if err != nil && apiStatus(err) == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the SMB access of shared folder "+state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	refreshed, diags := writeSMBShareState(ctx, state.ID.ValueString(), settings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	refreshed.LastUpdated = state.LastUpdated
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, refreshed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update replaces the SMB settings of the shared folder.
+func (r *smbShareResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan SMBShareSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.apply(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the permissions and the guest access of the shared folder and lists it again.
+func (r *smbShareResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	// Retrieve values from state
+	var state SMBShareSpecModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setShareSMB(ctx, r.client, state.ID.ValueString(), smbSettings{Visible: true, GuestAccess: defaultSMBGuestAccess})
+	if err != nil && apiStatus(err) != http.StatusNotFound {
+		resp.Diagnostics.AddError(
+			"Error deleting SMB share",
+			errorDetail("Could not reset the SMB access of shared folder "+state.ID.ValueString(), err),
+		)
+		return
+	}
+}
+
+// ImportState imports the SMB access of a shared folder by the name of the shared folder.
+func (r *smbShareResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *smbShareResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+// apply replaces the SMB settings of the shared folder with the plan and returns the state read back from the NAS.
+func (r *smbShareResource) apply(ctx context.Context, plan *SMBShareSpecModel) (SMBShareSpecModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	settings := smbSettings{
+		Visible:     plan.Visible.IsNull() || plan.Visible.IsUnknown() || plan.Visible.ValueBool(),
+		GuestAccess: plan.GuestAccess.ValueString(),
+	}
+	if settings.GuestAccess == "" {
+		settings.GuestAccess = defaultSMBGuestAccess
+	}
+	diags.Append(plan.Users.ElementsAs(ctx, &settings.Users, false)...)
+	diags.Append(plan.Groups.ElementsAs(ctx, &settings.Groups, false)...)
+	if diags.HasError() {
+		return SMBShareSpecModel{}, diags
+	}
+
+	share := plan.Share.ValueString()
+	err := setShareSMB(ctx, r.client, share, settings)
+	if err != nil {
+		diags.AddError(
+			"Error configuring SMB share",
+			errorDetail("Could not configure the SMB access of shared folder "+share, err),
+		)
+		return SMBShareSpecModel{}, diags
+	}
+
+	applied, err := getShareSMB(ctx, r.client, share)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the SMB access of shared folder "+share, err),
+		)
+		return SMBShareSpecModel{}, diags
+	}
+
+	state, stateDiags := writeSMBShareState(ctx, share, applied)
+	diags.Append(stateDiags...)
+	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	return state, diags
+}
+
+// writeSMBShareState maps the SMB settings of a shared folder returned by the API to the resource model. Users and
+// groups without permissions are null as the attributes cannot be empty.
+func writeSMBShareState(ctx context.Context, share string, settings *smbSettings) (SMBShareSpecModel, diag.Diagnostics) {
+	var diags, mapDiags diag.Diagnostics
+	state := SMBShareSpecModel{
+		ID:          types.StringValue(share),
+		Share:       types.StringValue(share),
+		Visible:     types.BoolValue(settings.Visible),
+		GuestAccess: types.StringValue(settings.GuestAccess),
+		Users:       types.MapNull(types.StringType),
+		Groups:      types.MapNull(types.StringType),
+		LastUpdated: types.StringNull(),
+	}
+	if len(settings.Users) > 0 {
+		state.Users, mapDiags = types.MapValueFrom(ctx, types.StringType, settings.Users)
+		diags.Append(mapDiags...)
+	}
+	if len(settings.Groups) > 0 {
+		state.Groups, mapDiags = types.MapValueFrom(ctx, types.StringType, settings.Groups)
+		diags.Append(mapDiags...)
+	}
+	return state, diags
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSMBShareResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: `
+					resource "qnap_smb_share" "public" {
+					share = "Public"
+					groups = {
+						everyone = "ro"
+					}
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_smb_share.public", "id", "Public"),
+					resource.TestCheckResourceAttr("qnap_smb_share.public", "visible", "true"),
+					resource.TestCheckResourceAttr("qnap_smb_share.public", "guest_access", "none"),
+					resource.TestCheckResourceAttr("qnap_smb_share.public", "groups.everyone", "ro"),
+				),
+			},
+			// test case 2 - hidden share with guest access and user permissions
+			{
+				Config: `
+					resource "qnap_smb_share" "public" {
+					share        = "Public"
+					visible      = false
+					guest_access = "ro"
+					users = {
+						admin = "rw"
+					}
+					groups = {
+						everyone = "deny"
+					}
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_smb_share.public", "visible", "false"),
+					resource.TestCheckResourceAttr("qnap_smb_share.public", "guest_access", "ro"),
+					resource.TestCheckResourceAttr("qnap_smb_share.public", "users.admin", "rw"),
+					resource.TestCheckResourceAttr("qnap_smb_share.public", "groups.everyone", "deny"),
+				),
+			},
+			// test case 2 - import by the name of the shared folder
+			{
+				ResourceName:            "qnap_smb_share.public",
+				ImportState:             true,
+				ImportStateId:           "Public",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// test case 3 - unknown permission
+			{
+				Config: `
+					resource "qnap_smb_share" "public" {
+					share = "Public"
+					users = {
+						admin = "full"
+					}
+					}
+
+				`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Match"),
+			},
+		},
+	})
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &snapshotsDataSource{}
+	_ datasource.DataSourceWithConfigure = &snapshotsDataSource{}
+)
+
+// snapshotsDataSource is the data source implementation.
+type snapshotsDataSource struct {
+	client *qnap.Client
+}
+
+// snapshotsDataSourceModel maps the data source schema data.
+type snapshotsDataSourceModel struct {
+	Volume    types.String    `tfsdk:"volume"`
+	Snapshots []snapshotModel `tfsdk:"snapshots"`
+}
+
+// snapshotModel maps a snapshot of a storage volume.
+type snapshotModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Volume  types.String `tfsdk:"volume"`
+	Created types.String `tfsdk:"created"`
+	Size    types.Int64  `tfsdk:"size"`
+}
+
+// NewSnapshotsDataSource is a helper function to simplify the provider implementation.
+func NewSnapshotsDataSource() datasource.DataSource {
+	return &snapshotsDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *snapshotsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshots"
+}
+
+// Schema defines the schema for the data source.
+func (d *snapshotsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the snapshots of the storage volumes of the NAS, for example to check in a precondition that a recent snapshot exists before a deployment.",
+		Attributes: map[string]schema.Attribute{
+			"volume": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the snapshots of this storage volume.",
+			},
+			"snapshots": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The snapshots, as ordered by the NAS.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the snapshot.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the snapshot.",
+						},
+						"volume": schema.StringAttribute{
+							Computed:    true,
+							Description: "The storage volume the snapshot was taken of.",
+						},
+						"created": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the snapshot was taken.",
+						},
+						"size": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The space used by the snapshot in bytes.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *snapshotsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state snapshotsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshots, err := listSnapshots(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read QNAP Snapshots",
+			errorDetail("Could not read the snapshots of the NAS", err),
+		)
+		return
+	}
+
+	state.Snapshots = []snapshotModel{}
+	for _, snapshot := range snapshots {
+		if !state.Volume.IsNull() && snapshot.Volume != state.Volume.ValueString() {
+			continue
+		}
+		state.Snapshots = append(state.Snapshots, snapshotModel{
+			ID:      types.StringValue(snapshot.ID),
+			Name:    types.StringValue(snapshot.Name),
+			Volume:  types.StringValue(snapshot.Volume),
+			Created: types.StringValue(snapshot.Created),
+			Size:    types.Int64Value(snapshot.Size),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *snapshotsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qnap.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qnap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
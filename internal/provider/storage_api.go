@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// nasDisk is a physical disk of the NAS and its SMART health.
+type nasDisk struct {
+	Slot        string `json:"slot"`
+	Model       string `json:"model"`
+	Serial      string `json:"serial"`
+	Capacity    int64  `json:"capacity"`
+	Temperature int32  `json:"temperature"`
+	SmartStatus string `json:"smartStatus"`
+}
+
+// listDisks returns the physical disks of the NAS.
+func listDisks(ctx context.Context, client *qnap.Client) ([]nasDisk, error) {
+	var response struct {
+		Data []nasDisk `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/system/disks", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
+
+// nasSnapshot is a snapshot of a storage volume of the NAS.
+type nasSnapshot struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Volume  string `json:"volume"`
+	Pool    string `json:"pool"`
+	Created string `json:"created"`
+	Size    int64  `json:"size"`
+}
+
+// listSnapshots returns the snapshots of the storage volumes of the NAS.
+func listSnapshots(ctx context.Context, client *qnap.Client) ([]nasSnapshot, error) {
+	var response struct {
+		Data []nasSnapshot `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/system/snapshots", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	// QuTS hero takes the snapshots of the ZFS pools that hold the volumes.
+	for i, snapshot := range response.Data {
+		if snapshot.Volume == "" {
+			response.Data[i].Volume = snapshot.Pool
+		}
+	}
+	return response.Data, nil
+}
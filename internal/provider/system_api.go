@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// systemInfo is the hardware of the NAS and the release of Container Station.
+type systemInfo struct {
+	CPUCores int    `json:"cpuCore"`
+	Version  string `json:"version"`
+}
+
+// getSystemInfo returns the hardware of the NAS and the release of Container Station.
+func getSystemInfo(ctx context.Context, client *qnap.Client) (*systemInfo, error) {
+	var response struct {
+		Data systemInfo `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/system", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// systemUsage is the CPU and memory usage of the NAS.
+type systemUsage struct {
+	CPUUsage    float64 `json:"cpuUsage"`
+	MemoryTotal int64   `json:"memoryTotal"`
+	MemoryUsed  int64   `json:"memoryUsed"`
+}
+
+// getSystemUsage returns the current CPU and memory usage of the NAS.
+func getSystemUsage(ctx context.Context, client *qnap.Client) (*systemUsage, error) {
+	var response struct {
+		Data systemUsage `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/system/resource", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// firmwareInfo is the installed firmware of the NAS and the latest release published for its model.
+type firmwareInfo struct {
+	Model           string `json:"model"`
+	Platform        string `json:"platform"`
+	Version         string `json:"version"`
+	Build           string `json:"build"`
+	LatestVersion   string `json:"latestVersion"`
+	LatestBuild     string `json:"latestBuild"`
+	UpdateAvailable bool   `json:"updateAvailable"`
+}
+
+// getFirmwareInfo returns the installed firmware of the NAS and whether an update is available.
+func getFirmwareInfo(ctx context.Context, client *qnap.Client) (*firmwareInfo, error) {
+	var response struct {
+		Data firmwareInfo `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/system/firmware", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// nasInterface is a network interface of the NAS and its addresses.
+type nasInterface struct {
+	Name string   `json:"name"`
+	IPv4 []string `json:"ipv4"`
+	IPv6 []string `json:"ipv6"`
+}
+
+// listHostAddresses returns the IP addresses of the network interfaces of the NAS.
+func listHostAddresses(ctx context.Context, client *qnap.Client) ([]string, error) {
+	var response struct {
+		Data []nasInterface `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/system/interfaces", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	addresses := []string{}
+	for _, nic := range response.Data {
+		addresses = append(addresses, nic.IPv4...)
+		addresses = append(addresses, nic.IPv6...)
+	}
+	return addresses, nil
+}
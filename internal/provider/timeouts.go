@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// providerData is what the provider passes to its resources: the client, the default timeouts of their operations and
+// the notifier of their changes.
+type providerData struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+	notifier *notifier
+}
+
+// operationTimeouts are the maximum durations of the create, read, update and delete operations of a resource, zero
+// does not limit the operation.
+type operationTimeouts struct {
+	Create time.Duration
+	Read   time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// timeoutsModel maps the timeouts block of a resource.
+type timeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsBlock returns the timeouts block of a resource, which overrides the default_timeouts of the provider.
+func timeoutsBlock() schema.SingleNestedBlock {
+	attributes := map[string]schema.Attribute{}
+	for _, operation := range []string{"create", "read", "update", "delete"} {
+		attributes[operation] = schema.StringAttribute{
+			Optional:    true,
+			Description: "The maximum duration to " + operation + " the resource (e.g. '10m', '1h'), overriding the default_timeouts of the provider.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^([0-9]+(h|m|s))+$`), "Timeout must be a duration (e.g. '90s', '30m', '1h30m')."),
+			},
+		}
+	}
+	return schema.SingleNestedBlock{
+		Description: "The maximum durations of the operations of the resource, for example to give a slow image pull more time or to fail a stuck delete fast.",
+		Attributes:  attributes,
+	}
+}
+
+// resourceTimeouts returns the timeouts of a resource: the defaults of the provider overridden by the timeouts block
+// read with getAttribute from the plan or state.
+func resourceTimeouts(ctx context.Context, defaults operationTimeouts, getAttribute func(context.Context, path.Path, interface{}) diag.Diagnostics) (operationTimeouts, diag.Diagnostics) {
+	timeouts := defaults
+
+	var config *timeoutsModel
+	diags := getAttribute(ctx, path.Root("timeouts"), &config)
+	if diags.HasError() || config == nil {
+		return timeouts, diags
+	}
+
+	for _, timeout := range []struct {
+		name  string
+		value types.String
+		out   *time.Duration
+	}{
+		{"create", config.Create, &timeouts.Create},
+		{"read", config.Read, &timeouts.Read},
+		{"update", config.Update, &timeouts.Update},
+		{"delete", config.Delete, &timeouts.Delete},
+	} {
+		if timeout.value.IsNull() || timeout.value.IsUnknown() {
+			continue
+		}
+		duration, err := time.ParseDuration(timeout.value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("timeouts").AtName(timeout.name), "Invalid Timeout", err.Error())
+			return timeouts, diags
+		}
+		*timeout.out = duration
+	}
+	return timeouts, diags
+}
+
+// withTimeout returns a context that is canceled when the timeout expires, zero only makes the context cancelable.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
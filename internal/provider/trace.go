@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// traceBodyLimit is the number of bytes of the request and response bodies that are logged.
+const traceBodyLimit = 2048
+
+// redactedKeys matches the JSON keys and headers whose values are never logged.
+var redactedKeys = regexp.MustCompile(`(?i)(password|passwd|pwd|secret|token|security_code|sid|authorization|cookie)`)
+
+// traceTransport logs every request sent to the NAS and its response at TRACE level, with the credentials redacted.
+// The client library sends its requests without a context, those are logged with the context of the provider.
+type traceTransport struct {
+	next http.RoundTripper
+	ctx  context.Context
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if ctx == context.Background() {
+		ctx = t.ctx
+	}
+
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			prefix, _ := io.ReadAll(io.LimitReader(body, traceBodyLimit))
+			body.Close()
+			fields["request_body"] = redactBody(prefix)
+		}
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	fields["duration"] = time.Since(start).String()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Trace(ctx, "QNAP API request failed", fields)
+		return res, err
+	}
+
+	prefix, readErr := io.ReadAll(io.LimitReader(res.Body, traceBodyLimit))
+	res.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(prefix), res.Body), res.Body}
+	fields["status"] = res.StatusCode
+	if readErr == nil {
+		fields["response_body"] = redactBody(prefix)
+	}
+	tflog.Trace(ctx, "QNAP API request", fields)
+	return res, nil
+}
+
+// redactBody returns a body for the logs, with the values of the credential keys of JSON bodies replaced. A body
+// that was truncated or is not JSON is only logged when it does not mention a credential key.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err == nil {
+		redacted, err := json.Marshal(redactValue(value))
+		if err == nil {
+			return string(redacted)
+		}
+	}
+	if redactedKeys.Match(body) {
+		return "(redacted)"
+	}
+	suffix := ""
+	if len(body) == traceBodyLimit {
+		suffix = "...(truncated)"
+	}
+	return string(body) + suffix
+}
+
+// redactValue replaces the values of the credential keys of a decoded JSON value.
+func redactValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if redactedKeys.MatchString(key) {
+				value[key] = "(redacted)"
+				continue
+			}
+			value[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+	return value
+}
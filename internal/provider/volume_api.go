@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// volumeSpec is the payload of the Container Station volume create endpoint.
+type volumeSpec struct {
+	Name       string            `json:"name"`
+	Driver     string            `json:"driver,omitempty"`
+	DriverOpts map[string]string `json:"driverOpts,omitempty"`
+}
+
+// remoteVolume is an NFS export or a CIFS share mounted by the local volume driver.
+type remoteVolume struct {
+	Type     string
+	Server   string
+	Path     string
+	Options  []string
+	Username string
+	Password string
+}
+
+// bindDriverOpts returns the options of the local volume driver that store the volume data in a directory of the NAS.
+func bindDriverOpts(hostPath string) map[string]string {
+	return map[string]string{
+		"type":   "none",
+		"device": hostPath,
+		"o":      "bind",
+	}
+}
+
+// driverOpts returns the options of the local volume driver that mount the remote storage.
+func (v remoteVolume) driverOpts() map[string]string {
+	options := []string{"addr=" + v.Server}
+	device := ":" + v.Path
+	if v.Type == "cifs" {
+		device = "//" + v.Server + "/" + strings.TrimPrefix(v.Path, "/")
+		if v.Username != "" {
+			options = append(options, "username="+v.Username, "password="+v.Password)
+		}
+	}
+	options = append(options, v.Options...)
+
+	return map[string]string{
+		"type":   v.Type,
+		"device": device,
+		"o":      strings.Join(options, ","),
+	}
+}
+
+// volumeInfo is a volume returned by the volume list endpoint.
+type volumeInfo struct {
+	Name       string `json:"name"`
+	Driver     string `json:"driver"`
+	MountPoint string `json:"mountPoint"`
+	Project    string `json:"project"`
+	Used       bool   `json:"used"`
+	Size       int64  `json:"size"`
+	Created    string `json:"created"`
+}
+
+// createVolume creates a Container Station volume and returns it once it is listed.
+func createVolume(ctx context.Context, client *qnap.Client, volume volumeSpec) (*volumeInfo, error) {
+	existing, err := findVolume(ctx, client, volume.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("cannot create volume as a volume with the same name already exists")
+	}
+
+	var response qnap.ContainerStationTaskResponse
+	err = apiRequest(ctx, client, http.MethodPost, "/container-station/api/v3/volumes", volume, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Data.TaskID != "" {
+		err = waitForTask(ctx, client, response.Data.TaskID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := findVolume(ctx, client, volume.Name)
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return nil, errors.New("volume is not found after creation. Possible options: QNAP container station needs more time or the volume creation failed silently")
+	}
+	return created, nil
+}
+
+// listVolumes returns the Container Station volumes.
+func listVolumes(ctx context.Context, client *qnap.Client) ([]volumeInfo, error) {
+	var response struct {
+		Data struct {
+			Items []volumeInfo `json:"items"`
+		} `json:"data"`
+	}
+	err := apiRequest(ctx, client, http.MethodGet, "/container-station/api/v3/volumes", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Data.Items, nil
+}
+
+// findVolume returns the volume with the given name or nil when it does not exist.
+func findVolume(ctx context.Context, client *qnap.Client, name string) (*volumeInfo, error) {
+	volumes, err := listVolumes(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, volume := range volumes {
+		if volume.Name == name {
+			return &volume, nil
+		}
+	}
+	return nil, nil
+}
+
+// deleteVolume removes a Container Station volume and waits for the task to complete.
+func deleteVolume(ctx context.Context, client *qnap.Client, name string) error {
+	payload := struct {
+		Data struct {
+			Items []struct {
+				Name string `json:"name"`
+			} `json:"items"`
+		} `json:"data"`
+	}{}
+	payload.Data.Items = append(payload.Data.Items, struct {
+		Name string `json:"name"`
+	}{Name: name})
+
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodDelete, "/container-station/api/v3/volumes", payload, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Data.TaskID == "" {
+		return nil
+	}
+	return waitForTask(ctx, client, response.Data.TaskID)
+}
+
+// anonymousVolumeName matches the random names docker gives to anonymous volumes.
+var anonymousVolumeName = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// danglingVolumes returns the names of the anonymous volumes that no container uses.
+func danglingVolumes(ctx context.Context, client *qnap.Client) ([]string, error) {
+	volumes, err := listVolumes(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	dangling := []string{}
+	for _, volume := range volumes {
+		if !volume.Used && volume.Project == "" && anonymousVolumeName.MatchString(volume.Name) {
+			dangling = append(dangling, volume.Name)
+		}
+	}
+	return dangling, nil
+}
+
+// deleteVolumes deletes the given volumes and returns the names of the deleted volumes.
+func deleteVolumes(ctx context.Context, client *qnap.Client, names []string) ([]string, error) {
+	deleted := []string{}
+	var errs []error
+	for _, name := range names {
+		if err := deleteVolume(ctx, client, name); err != nil {
+			errs = append(errs, fmt.Errorf("volume %s: %w", name, err))
+			continue
+		}
+		deleted = append(deleted, name)
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// volumeContentSpec is the payload of the Container Station volume import endpoint, either a tar or zip
+// archive on a shared folder of the NAS or a base64 encoded archive is extracted into the volume.
+type volumeContentSpec struct {
+	Path    string `json:"path,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// seedVolume extracts an archive into a volume and waits for the task to complete.
+func seedVolume(ctx context.Context, client *qnap.Client, name string, content volumeContentSpec) error {
+	var response qnap.ContainerStationTaskResponse
+	err := apiRequest(ctx, client, http.MethodPost, fmt.Sprintf("/container-station/api/v3/volumes/%s/import", name), content, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Data.TaskID == "" {
+		return nil
+	}
+	return waitForTask(ctx, client, response.Data.TaskID)
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &volumeDataSource{}
+	_ datasource.DataSourceWithConfigure = &volumeDataSource{}
+)
+
+// volumeDataSource is the data source implementation.
+type volumeDataSource struct {
+	client *qnap.Client
+}
+
+// volumeDataSourceModel maps the data source schema data.
+type volumeDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Driver     types.String `tfsdk:"driver"`
+	Mountpoint types.String `tfsdk:"mountpoint"`
+	Project    types.String `tfsdk:"project"`
+	Used       types.Bool   `tfsdk:"used"`
+	Size       types.Int64  `tfsdk:"size"`
+	Created    types.String `tfsdk:"created"`
+}
+
+// NewVolumeDataSource is a helper function to simplify the provider implementation.
+func NewVolumeDataSource() datasource.DataSource {
+	return &volumeDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *volumeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_volume"
+}
+
+// Schema defines the schema for the data source.
+func (d *volumeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a Container Station volume by name, so that containers can mount a volume that is not managed by Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the volume to look up.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the volume, the same as its name.",
+			},
+			"driver": schema.StringAttribute{
+				Computed:    true,
+				Description: "The driver of the volume.",
+			},
+			"mountpoint": schema.StringAttribute{
+				Computed:    true,
+				Description: "The path of the volume data on the NAS.",
+			},
+			"project": schema.StringAttribute{
+				Computed:    true,
+				Description: "The application the volume belongs to, empty for standalone volumes.",
+			},
+			"used": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether a container mounts the volume.",
+			},
+			"size": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The size of the volume data in bytes.",
+			},
+			"created": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the volume was created.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *volumeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state volumeDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	volume, err := findVolume(ctx, d.client, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read QNAP Volume",
+			errorDetail("Could not read the volume of the NAS", err),
+		)
+		return
+	}
+	if volume == nil {
+		resp.Diagnostics.AddError(
+			"Volume not found",
+			fmt.Sprintf("No Container Station volume is named %q.", state.Name.ValueString()),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(volume.Name)
+	state.Driver = types.StringValue(volume.Driver)
+	state.Mountpoint = types.StringValue(volume.MountPoint)
+	state.Project = types.StringValue(volume.Project)
+	state.Used = types.BoolValue(volume.Used)
+	state.Size = types.Int64Value(volume.Size)
+	state.Created = types.StringValue(volume.Created)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *volumeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qnap.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qnap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
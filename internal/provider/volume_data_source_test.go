@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccVolumeDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+					resource "qnap_volume" "lookup" {
+					name = "terraform_test_volume_lookup"
+					}
+
+					data "qnap_volume" "lookup" {
+					name = qnap_volume.lookup.name
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.qnap_volume.lookup", "mountpoint", "qnap_volume.lookup", "mountpoint"),
+					resource.TestCheckResourceAttr("data.qnap_volume.lookup", "driver", "local"),
+					resource.TestCheckResourceAttr("data.qnap_volume.lookup", "used", "false"),
+					resource.TestCheckResourceAttrSet("data.qnap_volume.lookup", "size"),
+				),
+			},
+			// Unknown volume
+			{
+				Config:      providerConfig + `data "qnap_volume" "missing" { name = "terraform_test_missing" }`,
+				ExpectError: regexp.MustCompile("Volume not found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &volumePruneResource{}
+	_ resource.ResourceWithConfigure = &volumePruneResource{}
+)
+
+type VolumePruneSpecModel struct {
+	ID          basetypes.StringValue `tfsdk:"id"`
+	DryRun      basetypes.BoolValue   `tfsdk:"dry_run"`
+	Triggers    basetypes.MapValue    `tfsdk:"triggers"`
+	Volumes     basetypes.ListValue   `tfsdk:"volumes"`
+	LastUpdated basetypes.StringValue `tfsdk:"last_updated"`
+}
+
+// volumePruneResource is the resource implementation.
+type volumePruneResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewVolumePruneResource is a helper function to simplify the provider implementation.
+func NewVolumePruneResource() resource.Resource {
+	return &volumePruneResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *volumePruneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_volume_prune"
+}
+
+// Schema defines the schema for the resource.
+func (r *volumePruneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Removes the dangling anonymous volumes, the anonymous volumes that no container uses anymore. The volumes are pruned when the resource is created and again when any of the triggers change. Named volumes and the volumes of docker-compose apps are never pruned.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the prune.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dry_run": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list the volumes that would be removed in volumes without removing them. Defaults to false.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that prune the volumes again when changed.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"volumes": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The names of the removed volumes, or of the volumes that would be removed on a dry run.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the prune.",
+			},
+		},
+	}
+}
+
+// Create prunes the dangling volumes.
+func (r *volumePruneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan VolumePruneSpecModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	volumes, err := danglingVolumes(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error pruning volumes",
+			errorDetail("Could not list the dangling volumes", err),
+		)
+		return
+	}
+
+	if !plan.DryRun.ValueBool() {
+		volumes, err = deleteVolumes(ctx, r.client, volumes)
+		if err != nil {
+			// The volumes removed before the error are still reported in state
+			resp.Diagnostics.AddWarning(
+				"Error pruning volumes",
+				errorDetail("Could not remove all dangling volumes", err),
+			)
+		}
+	}
+
+	now := time.Now()
+	plan.ID = types.StringValue(now.Format(time.RFC3339))
+	plan.LastUpdated = types.StringValue(now.Format(time.RFC850))
+	plan.Volumes, diags = types.ListValueFrom(ctx, types.StringType, volumes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the result of the last prune in state.
+func (r *volumePruneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update is never called as every attribute requires replacement.
+func (r *volumePruneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+// Delete removes the prune from the Terraform state.
+func (r *volumePruneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *volumePruneResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
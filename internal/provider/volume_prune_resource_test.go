@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccVolumePruneResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: `
+					resource "qnap_volume_prune" "dry_run" {
+					dry_run = true
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_volume_prune.dry_run", "dry_run", "true"),
+					resource.TestCheckResourceAttrSet("qnap_volume_prune.dry_run", "volumes.#"),
+				),
+			},
+			// test case 2 - prune again when a trigger changes
+			{
+				Config: `
+					resource "qnap_volume_prune" "dry_run" {
+					dry_run = true
+					triggers = {
+						run = "2"
+					}
+					}
+
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("qnap_volume_prune.dry_run", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_volume_prune.dry_run", "triggers.run", "2"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,531 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mohamed-mfarag/qnap-client-lib"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &volumeResource{}
+	_ resource.ResourceWithConfigure      = &volumeResource{}
+	_ resource.ResourceWithImportState    = &volumeResource{}
+	_ resource.ResourceWithValidateConfig = &volumeResource{}
+)
+
+type VolumeSpecModel struct {
+	ID          basetypes.StringValue `tfsdk:"id"`
+	Name        basetypes.StringValue `tfsdk:"name"`
+	Remote      basetypes.ObjectValue `tfsdk:"remote"`
+	HostPath    basetypes.StringValue `tfsdk:"host_path"`
+	Content     basetypes.ObjectValue `tfsdk:"initial_content"`
+	Driver      basetypes.StringValue `tfsdk:"driver"`
+	MountPoint  basetypes.StringValue `tfsdk:"mountpoint"`
+	Project     basetypes.StringValue `tfsdk:"project"`
+	Created     basetypes.StringValue `tfsdk:"created"`
+	LastUpdated basetypes.StringValue `tfsdk:"last_updated"`
+	Timeouts    *timeoutsModel        `tfsdk:"timeouts"`
+}
+
+type RemoteVolumeModel struct {
+	Type     basetypes.StringValue `tfsdk:"type"`
+	Server   basetypes.StringValue `tfsdk:"server"`
+	Path     basetypes.StringValue `tfsdk:"path"`
+	Options  basetypes.ListValue   `tfsdk:"options"`
+	Username basetypes.StringValue `tfsdk:"username"`
+	Password basetypes.StringValue `tfsdk:"password"`
+}
+
+type VolumeContentModel struct {
+	Path          basetypes.StringValue `tfsdk:"path"`
+	ContentBase64 basetypes.StringValue `tfsdk:"content_base64"`
+}
+
+// remoteVolumeAttrTypes are the attribute types of the remote object.
+var remoteVolumeAttrTypes = map[string]attr.Type{
+	"type":     types.StringType,
+	"server":   types.StringType,
+	"path":     types.StringType,
+	"options":  types.ListType{ElemType: types.StringType},
+	"username": types.StringType,
+	"password": types.StringType,
+}
+
+// volumeContentAttrTypes are the attribute types of the initial content object.
+var volumeContentAttrTypes = map[string]attr.Type{
+	"path":           types.StringType,
+	"content_base64": types.StringType,
+}
+
+// volumeResource is the resource implementation.
+type volumeResource struct {
+	client   *qnap.Client
+	timeouts operationTimeouts
+}
+
+// NewVolumeResource is a helper function to simplify the provider implementation.
+func NewVolumeResource() resource.Resource {
+	return &volumeResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *volumeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_volume"
+}
+
+// Schema defines the schema for the resource.
+func (r *volumeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Container Station volume. Existing volumes, such as the volumes of docker-compose apps or volumes created in the UI, can be imported by name. The remote storage and host path of imported volumes are not read back from the NAS.",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the volume, its name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the volume.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`), "Volume name must be up to 64 characters, starts with a letter or number. Valid characters: letters (A-Z, a-z), numbers (0-9), hyphen (-), period (.), underscore (_)"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"remote": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Mounts an NFS export or a CIFS share of another server as the volume instead of storing its data on the NAS. The password is stored in state as a sensitive value.",
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Required:    true,
+						Description: "The protocol of the remote storage (nfs, cifs).",
+						Validators: []validator.String{
+							stringvalidator.OneOf("nfs", "cifs"),
+						},
+					},
+					"server": schema.StringAttribute{
+						Required:    true,
+						Description: "The hostname or IP address of the server.",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9.:-]*[A-Za-z0-9])?$`), "Server must be a hostname or an IP address (e.g. nas2.local, 192.168.1.20)."),
+						},
+					},
+					"path": schema.StringAttribute{
+						Required:    true,
+						Description: "The exported path for nfs (e.g. /share/backup) or the share and the optional directory in it for cifs (e.g. backup/app).",
+					},
+					"options": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "Additional mount options (e.g. nfsvers=4, ro, vers=3.0).",
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(
+								stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9_.-]+(=[^,]+)?$`), "Mount option must be a name with an optional value and must not contain commas (e.g. nfsvers=4)."),
+							),
+						},
+					},
+					"username": schema.StringAttribute{
+						Optional:    true,
+						Description: "The username for the cifs share.",
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("password")),
+						},
+					},
+					"password": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "The password for the cifs share.",
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("username")),
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"host_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "The directory on a shared folder of the NAS that stores the volume data (e.g. /SSD-Data/volumes/app), which places the data on the storage pool of that shared folder. The directory must exist. Defaults to the volume path of Container Station.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(/[^/]+)+$`), "Host path must be an absolute path to a directory on a shared folder without a trailing slash (e.g. /SSD-Data/volumes/app)."),
+					stringvalidator.ConflictsWith(path.MatchRoot("remote")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"initial_content": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "An archive extracted into the volume when it is created, to bootstrap the configuration of a container. Changing it later does not change the data of the volume.",
+				Attributes: map[string]schema.Attribute{
+					"path": schema.StringAttribute{
+						Optional:    true,
+						Description: "The path of a tar or zip archive on a shared folder of the NAS (e.g. '/Public/seed/config.tar.gz').",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^/.+\.(tar|tar\.gz|tgz|zip)$`), "Path must be an absolute path to a tar or zip archive (e.g. '/Public/seed/config.tar.gz')."),
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("content_base64")),
+						},
+					},
+					"content_base64": schema.StringAttribute{
+						Optional:    true,
+						Description: "A base64 encoded tar or zip archive (e.g. filebase64(\"config.tar.gz\")).",
+					},
+				},
+			},
+			"driver": schema.StringAttribute{
+				Computed:    true,
+				Description: "The driver of the volume.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mountpoint": schema.StringAttribute{
+				Computed:    true,
+				Description: "The path of the volume data on the NAS.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Computed:    true,
+				Description: "The docker-compose app the volume belongs to, empty for standalone volumes.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created": schema.StringAttribute{
+				Computed:    true,
+				Description: "The creation timestamp of the volume.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The last updated timestamp of the volume.",
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the combination of attributes in the configuration.
+func (r *volumeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config VolumeSpecModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Content.IsNull() && !config.Content.IsUnknown() {
+		var content VolumeContentModel
+		diags = config.Content.As(ctx, &content, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: false, UnhandledUnknownAsEmpty: true})
+		resp.Diagnostics.Append(diags...)
+		if !content.ContentBase64.IsNull() && !content.ContentBase64.IsUnknown() {
+			if _, err := base64.StdEncoding.DecodeString(content.ContentBase64.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("initial_content").AtName("content_base64"),
+					"Invalid archive content",
+					"content_base64 must be base64 encoded: "+err.Error(),
+				)
+			}
+		}
+	}
+
+	if config.Remote.IsNull() || config.Remote.IsUnknown() {
+		return
+	}
+
+	var remote RemoteVolumeModel
+	diags = config.Remote.As(ctx, &remote, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: false, UnhandledUnknownAsEmpty: true})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || remote.Type.IsUnknown() {
+		return
+	}
+
+	// NFS exports are absolute paths and only CIFS shares take credentials
+	if remote.Type.ValueString() == "nfs" {
+		if !remote.Path.IsUnknown() && !strings.HasPrefix(remote.Path.ValueString(), "/") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("remote").AtName("path"),
+				"Invalid NFS export path",
+				"path of nfs volumes must be the absolute exported path (e.g. /share/backup).",
+			)
+		}
+		if !remote.Username.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("remote").AtName("username"),
+				"Invalid NFS credentials",
+				"username and password can only be set for cifs volumes, nfs exports are authorized by the address of the NAS.",
+			)
+		}
+	}
+}
+
+// Create a new resource.
+func (r *volumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	timeouts, diags := resourceTimeouts(ctx, r.timeouts, req.Plan.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, timeouts.Create)
+	defer cancel()
+
+	// Retrieve values from plan
+	var plan VolumeSpecModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec := volumeSpec{
+		Name: plan.Name.ValueString(),
+	}
+	if !plan.Remote.IsNull() {
+		var remote RemoteVolumeModel
+		diags = plan.Remote.As(ctx, &remote, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: false, UnhandledUnknownAsEmpty: false})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		mount := remoteVolume{
+			Type:     remote.Type.ValueString(),
+			Server:   remote.Server.ValueString(),
+			Path:     remote.Path.ValueString(),
+			Username: remote.Username.ValueString(),
+			Password: remote.Password.ValueString(),
+		}
+		diags = remote.Options.ElementsAs(ctx, &mount.Options, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		spec.Driver = "local"
+		spec.DriverOpts = mount.driverOpts()
+	}
+	if !plan.HostPath.IsNull() {
+		spec.Driver = "local"
+		spec.DriverOpts = bindDriverOpts(plan.HostPath.ValueString())
+	}
+
+	volume, err := createVolume(ctx, r.client, spec)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating volume",
+			errorDetail("Could not create volume "+plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	state := writeVolumeState(volume)
+	// special case for remote and host path as the mount options are not returned by the API
+	state.Remote = plan.Remote
+	state.HostPath = plan.HostPath
+	// special case for initial content as it is only used on create
+	state.Content = plan.Content
+	state.Timeouts = plan.Timeouts
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The volume is kept in state when it cannot be seeded so it is replaced on the next apply
+	if !plan.Content.IsNull() {
+		var content VolumeContentModel
+		diags = plan.Content.As(ctx, &content, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: false, UnhandledUnknownAsEmpty: false})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		err = seedVolume(ctx, r.client, volume.Name, volumeContentSpec{
+			Path:    content.Path.ValueString(),
+			Content: content.ContentBase64.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("initial_content"),
+				"Error seeding volume",
+				errorDetail("Could not extract the initial content into volume "+volume.Name, err),
+			)
+		}
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *volumeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	timeouts, diags := resourceTimeouts(ctx, r.timeouts, req.State.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, timeouts.Read)
+	defer cancel()
+
+	// Get current state
+	var state VolumeSpecModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	volume, err := findVolume(ctx, r.client, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			errorDetail("An error occurred while reading the resource", err),
+		)
+		return
+	}
+	if volume == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	refreshed := writeVolumeState(volume)
+	refreshed.Remote = state.Remote
+	refreshed.HostPath = state.HostPath
+	refreshed.Content = state.Content
+	refreshed.Timeouts = state.Timeouts
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, refreshed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only stores the initial content as the other attributes require replacement.
+func (r *volumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	timeouts, diags := resourceTimeouts(ctx, r.timeouts, req.Plan.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, timeouts.Update)
+	defer cancel()
+
+	// Retrieve values from plan and prior state
+	var plan, state VolumeSpecModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Content = plan.Content
+	state.Timeouts = plan.Timeouts
+	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the volume and its data.
+func (r *volumeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	timeouts, diags := resourceTimeouts(ctx, r.timeouts, req.State.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, timeouts.Delete)
+	defer cancel()
+
+	// Retrieve values from state
+	var state VolumeSpecModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := deleteVolume(ctx, r.client, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting volume",
+			errorDetail("Could not delete volume "+state.Name.ValueString(), err),
+		)
+		return
+	}
+}
+
+// ImportState imports an existing volume by its name.
+func (r *volumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *volumeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+// writeVolumeState maps a volume returned by the API to the resource model.
+func writeVolumeState(volume *volumeInfo) VolumeSpecModel {
+	return VolumeSpecModel{
+		ID:          types.StringValue(volume.Name),
+		Name:        types.StringValue(volume.Name),
+		Remote:      types.ObjectNull(remoteVolumeAttrTypes),
+		HostPath:    types.StringNull(),
+		Content:     types.ObjectNull(volumeContentAttrTypes),
+		Driver:      types.StringValue(volume.Driver),
+		MountPoint:  types.StringValue(volume.MountPoint),
+		Project:     types.StringValue(volume.Project),
+		Created:     types.StringValue(volume.Created),
+		LastUpdated: types.StringValue(time.Now().Format(time.RFC850)),
+	}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccVolumeResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// test case 1
+			{
+				Config: `
+					resource "qnap_volume" "data" {
+					name = "terraform_test_volume"
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_volume.data", "id", "terraform_test_volume"),
+					resource.TestCheckResourceAttr("qnap_volume.data", "name", "terraform_test_volume"),
+					resource.TestCheckResourceAttr("qnap_volume.data", "driver", "local"),
+					resource.TestCheckResourceAttrSet("qnap_volume.data", "mountpoint"),
+				),
+			},
+			// test case 1 - import by name
+			{
+				ResourceName:            "qnap_volume.data",
+				ImportState:             true,
+				ImportStateId:           "terraform_test_volume",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// test case 2 - volume data on a chosen shared folder
+			{
+				Config: `
+					resource "qnap_volume" "placed" {
+					name      = "terraform_test_placed_volume"
+					host_path = "/Container/terraform_test_placed_volume"
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_volume.placed", "host_path", "/Container/terraform_test_placed_volume"),
+					resource.TestCheckResourceAttr("qnap_volume.placed", "driver", "local"),
+				),
+			},
+			// test case 3 - content that is not base64 encoded
+			{
+				Config: `
+					resource "qnap_volume" "seeded" {
+					name = "terraform_test_seeded_volume"
+					initial_content = {
+						content_base64 = "not base64!"
+					}
+					}
+
+				`,
+				ExpectError: regexp.MustCompile("Invalid archive content"),
+			},
+			// test case 4 - nfs exports are absolute paths
+			{
+				Config: `
+					resource "qnap_volume" "nfs" {
+					name = "terraform_test_nfs_volume"
+					remote = {
+						type   = "nfs"
+						server = "192.168.1.20"
+						path   = "share/backup"
+					}
+					}
+
+				`,
+				ExpectError: regexp.MustCompile("Invalid NFS export path"),
+			},
+			// test case 5 - timeouts override the provider defaults
+			{
+				Config: `
+					resource "qnap_volume" "timed" {
+					name = "terraform_test_timed_volume"
+					timeouts {
+						create = "10m"
+						delete = "30s"
+					}
+					}
+
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qnap_volume.timed", "timeouts.create", "10m"),
+					resource.TestCheckResourceAttr("qnap_volume.timed", "timeouts.delete", "30s"),
+				),
+			},
+			// test case 6 - timeouts are durations
+			{
+				Config: `
+					resource "qnap_volume" "timed" {
+					name = "terraform_test_timed_volume"
+					timeouts {
+						delete = "soon"
+					}
+					}
+
+				`,
+				ExpectError: regexp.MustCompile("Timeout must be a duration"),
+			},
+		},
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"log"
 	"terraform-provider-qnap/internal/provider"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
@@ -46,6 +47,11 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	// Sign out of the NAS once Terraform stops the provider.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	provider.Shutdown(ctx)
+	cancel()
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}